@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	_ "time/tzdata" // embed the time zone database, as events can be in any IANA time zone regardless of the host.
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/config"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
@@ -71,14 +73,156 @@ func main() {
 		database,
 	)
 
-	jwtService := service.NewJsonWebTokenService(
-		&envConfig.Security.JsonWebToken,
+	eventRepo := repository.NewSQLEventRepository(
+		database,
+	)
+
+	attendanceRepo := repository.NewSQLAttendanceRepository(
+		database,
+	)
+
+	engagementRepo := repository.NewSQLEngagementRepository(
+		database,
+	)
+
+	reviewRepo := repository.NewSQLReviewRepository(
+		database,
+	)
+
+	tagRepo := repository.NewSQLTagRepository(
+		database,
+	)
+
+	categoryRepo := repository.NewSQLCategoryRepository(
+		database,
+	)
+
+	calendarFeedRepo := repository.NewSQLCalendarFeedRepository(
+		database,
+	)
+
+	occurrenceRepo := repository.NewSQLOccurrenceRepository(
+		database,
+	)
+
+	ticketTypeRepo := repository.NewSQLTicketTypeRepository(
+		database,
+	)
+
+	staffRepo := repository.NewSQLEventStaffRepository(
+		database,
+	)
+
+	orderRepo := repository.NewSQLOrderRepository(
+		database,
+	)
+
+	organizerApplicationRepo := repository.NewSQLOrganizerApplicationRepository(
+		database,
+	)
+
+	userTokenRepo := repository.NewSQLUserTokenRepository(
+		database,
+	)
+
+	mailQueueRepo := repository.NewSQLMailQueueRepository(
+		database,
+	)
+
+	sessionRepo := repository.NewSQLSessionRepository(
+		database,
+	)
+
+	// access tokens are only accepted while the session they were issued for is active.
+	jwtService := service.NewSessionJsonWebTokenService(
+		service.NewJsonWebTokenService(
+			&envConfig.Security.JsonWebToken,
+			lw,
+		),
+		sessionRepo,
+	)
+
+	// periodically mark published events which have ended as completed.
+	eventLifecycleService := service.NewEventLifecycleService(
+		&envConfig.Events,
+		eventRepo,
+		lw,
+	)
+	go eventLifecycleService.Run(context.Background())
+
+	paymentProvider, err := service.NewPaymentProvider(
+		&envConfig.Payments,
+	)
+	if err != nil {
+		mainLogger.Fatal(err, "payment provider configuration error")
+	}
+
+	// periodically cancel pending orders which were not paid before their tickets were released.
+	orderService := service.NewOrderService(
+		&envConfig.Orders,
+		paymentProvider,
+		orderRepo,
+		ticketTypeRepo,
+		lw,
+	)
+	go orderService.Run(context.Background())
+
+	mailer, err := service.NewMailer(
+		&envConfig.Mail,
+		lw,
+	)
+	if err != nil {
+		mainLogger.Fatal(err, "mail configuration error")
+	}
+
+	// periodically send queued emails, retrying those which failed to send.
+	mailService := service.NewMailService(
+		&envConfig.Mail,
+		mailer,
+		mailQueueRepo,
+		lw,
+	)
+	go mailService.Run(context.Background())
+
+	// users are notified by email.
+	notifier := service.NewMailNotifier(
+		mailService,
+	)
+
+	organizerApplicationService := service.NewOrganizerApplicationService(
+		organizerApplicationRepo,
+		userRepo,
+		notifier,
+		lw,
+	)
+
+	emailVerificationService := service.NewEmailVerificationService(
+		&envConfig.EmailVerification,
+		userTokenRepo,
+		userRepo,
+		notifier,
+		lw,
+	)
+
+	passwordResetService := service.NewPasswordResetService(
+		&envConfig.PasswordReset,
+		userTokenRepo,
+		userRepo,
+		notifier,
+		lw,
+	)
+
+	checkInService := service.NewCheckInService(
+		&envConfig.CheckIns,
+		attendanceRepo,
 		lw,
 	)
 
 	authService := service.NewJsonWebTokenAuthenticationService(
 		userRepo,
+		sessionRepo,
 		jwtService,
+		emailVerificationService,
 		lw,
 		&service.AuthenticationServiceConfiguration{
 			IsProduction: envConfig.Env.IsProduction(),
@@ -93,7 +237,159 @@ func main() {
 		lw,
 	)
 
+	routes.NewJsonWebTokenEventRoutes(
+		router,
+		eventRepo,
+		engagementRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenOccurrenceRoutes(
+		router,
+		eventRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenAttendanceRoutes(
+		router,
+		attendanceRepo,
+		eventRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenCheckInRoutes(
+		router,
+		checkInService,
+		attendanceRepo,
+		eventRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenTicketRoutes(
+		router,
+		ticketTypeRepo,
+		eventRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenOrderRoutes(
+		router,
+		orderService,
+		orderRepo,
+		eventRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewPaymentWebhookRoutes(
+		router,
+		orderService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenStaffRoutes(
+		router,
+		staffRepo,
+		eventRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenOrganizerApplicationRoutes(
+		router,
+		organizerApplicationService,
+		organizerApplicationRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenEngagementRoutes(
+		router,
+		engagementRepo,
+		eventRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenReviewRoutes(
+		router,
+		reviewRepo,
+		eventRepo,
+		attendanceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenTagRoutes(
+		router,
+		tagRepo,
+		eventRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenCategoryRoutes(
+		router,
+		categoryRepo,
+		eventRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenCalendarRoutes(
+		router,
+		calendarFeedRepo,
+		eventRepo,
+		occurrenceRepo,
+		staffRepo,
+		userRepo,
+		&jwtService,
+		lw,
+	)
+
 	routes.NewJsonWebTokenAuthenticationRoutes(
+		router,
+		authService,
+		emailVerificationService,
+		passwordResetService,
+		&jwtService,
+		lw,
+	)
+
+	routes.NewJsonWebTokenSessionRoutes(
 		router,
 		authService,
 		&jwtService,
@@ -105,7 +401,6 @@ func main() {
 		service.NewGoogleAuthenticationService(
 			&envConfig.Security.Google,
 			userRepo,
-			jwtService,
 			lw,
 		),
 		authService,
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.event_categories_category_id_idx;
+DROP INDEX IF EXISTS public.event_tags_tag_id_idx;
+DROP INDEX IF EXISTS public.events_attendees_id_idx;
+DROP INDEX IF EXISTS public.events_likes_id_idx;
+DROP INDEX IF EXISTS public.events_start_date_id_idx;
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS events_start_date_id_idx ON public.events (start_date, id);
+CREATE INDEX IF NOT EXISTS events_likes_id_idx ON public.events (likes, id);
+CREATE INDEX IF NOT EXISTS events_attendees_id_idx ON public.events (attendees, id);
+CREATE INDEX IF NOT EXISTS event_tags_tag_id_idx ON public.event_tags (tag_id);
+CREATE INDEX IF NOT EXISTS event_categories_category_id_idx ON public.event_categories (category_id);
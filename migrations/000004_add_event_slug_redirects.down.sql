@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.event_slug_redirects;
+
+DROP INDEX IF EXISTS public.events_slug_key;
@@ -0,0 +1,21 @@
+-- suffix any duplicate slugs created before slugs were enforced to be unique.
+-- the suffix is the start of the event id rather than a counter, which could produce a slug another event already has such as "launch-2".
+UPDATE public.events e
+SET slug = d.slug || '-' || left(d.id::text, 8)
+FROM (
+   SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS n
+   FROM public.events
+) d
+WHERE e.id = d.id AND d.n > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_slug_key ON public.events (slug);
+
+-- previous slugs of renamed events, used to redirect to the current slug.
+CREATE TABLE IF NOT EXISTS public.event_slug_redirects (
+   slug text PRIMARY KEY,
+   event_id UUID NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS event_slug_redirects_event_id_idx ON public.event_slug_redirects (event_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.event_waitlist;
+
+ALTER TABLE public.events
+DROP COLUMN capacity;
@@ -0,0 +1,14 @@
+-- a NULL capacity represents an event without an attendee limit.
+ALTER TABLE public.events
+ADD COLUMN capacity INT CHECK (capacity IS NULL OR capacity > 0);
+
+CREATE TABLE IF NOT EXISTS public.event_waitlist (
+   event_id UUID NOT NULL,
+   user_id UUID NOT NULL,
+   queued_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE,
+   PRIMARY KEY(event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS event_waitlist_event_id_queued_at_idx ON public.event_waitlist (event_id, queued_at, user_id);
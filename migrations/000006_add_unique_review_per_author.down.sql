@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.reviews_event_id_author_id_key;
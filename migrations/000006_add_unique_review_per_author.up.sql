@@ -0,0 +1,8 @@
+-- keep only the most recent review by each author for an event before enforcing uniqueness.
+DELETE FROM public.reviews r
+USING public.reviews newer
+WHERE r.event_id = newer.event_id
+   AND r.author_id = newer.author_id
+   AND (r.created_at, r.id) < (newer.created_at, newer.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS reviews_event_id_author_id_key ON public.reviews (event_id, author_id);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS update_rating_summary ON public.reviews;
+DROP FUNCTION IF EXISTS update_event_rating_summary;
+
+DROP TABLE IF EXISTS public.event_rating_summaries;
+
+ALTER TABLE public.reviews
+DROP COLUMN rating;
@@ -0,0 +1,67 @@
+-- reviews written before ratings were introduced are left without a rating.
+ALTER TABLE public.reviews
+ADD COLUMN rating SMALLINT CHECK (rating BETWEEN 1 AND 5);
+
+CREATE TABLE IF NOT EXISTS public.event_rating_summaries (
+   event_id UUID PRIMARY KEY,
+   rating_count INT NOT NULL DEFAULT 0,
+   rating_total INT NOT NULL DEFAULT 0,
+   one_star INT NOT NULL DEFAULT 0,
+   two_star INT NOT NULL DEFAULT 0,
+   three_star INT NOT NULL DEFAULT 0,
+   four_star INT NOT NULL DEFAULT 0,
+   five_star INT NOT NULL DEFAULT 0,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE
+);
+
+-- ============================================================================================================
+-- Function & Trigger to update the rating summary when a rated REVIEW is added / changed / removed.
+-- ============================================================================================================
+-- > Function
+CREATE OR REPLACE FUNCTION update_event_rating_summary()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP IN ('UPDATE', 'DELETE')) AND OLD.rating IS NOT NULL THEN
+        UPDATE public.event_rating_summaries
+        SET rating_count = GREATEST(rating_count - 1, 0),
+            rating_total = GREATEST(rating_total - OLD.rating, 0),
+            one_star = GREATEST(one_star - (OLD.rating = 1)::int, 0),
+            two_star = GREATEST(two_star - (OLD.rating = 2)::int, 0),
+            three_star = GREATEST(three_star - (OLD.rating = 3)::int, 0),
+            four_star = GREATEST(four_star - (OLD.rating = 4)::int, 0),
+            five_star = GREATEST(five_star - (OLD.rating = 5)::int, 0)
+        WHERE event_id = OLD.event_id;
+    END IF;
+    IF (TG_OP IN ('INSERT', 'UPDATE')) AND NEW.rating IS NOT NULL THEN
+        INSERT INTO public.event_rating_summaries AS s (event_id, rating_count, rating_total, one_star, two_star, three_star, four_star, five_star)
+        VALUES (
+            NEW.event_id,
+            1,
+            NEW.rating,
+            (NEW.rating = 1)::int,
+            (NEW.rating = 2)::int,
+            (NEW.rating = 3)::int,
+            (NEW.rating = 4)::int,
+            (NEW.rating = 5)::int
+        )
+        ON CONFLICT (event_id) DO UPDATE
+        SET rating_count = s.rating_count + 1,
+            rating_total = s.rating_total + EXCLUDED.rating_total,
+            one_star = s.one_star + EXCLUDED.one_star,
+            two_star = s.two_star + EXCLUDED.two_star,
+            three_star = s.three_star + EXCLUDED.three_star,
+            four_star = s.four_star + EXCLUDED.four_star,
+            five_star = s.five_star + EXCLUDED.five_star;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- ============================================================================================================
+-- > Trigger
+CREATE TRIGGER update_rating_summary
+AFTER INSERT OR UPDATE OF rating, event_id OR DELETE ON public.reviews
+FOR EACH ROW
+EXECUTE FUNCTION update_event_rating_summary();
+
+-- ============================================================================================================
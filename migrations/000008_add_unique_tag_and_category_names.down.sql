@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.categories_name_key;
+DROP INDEX IF EXISTS public.tags_name_key;
@@ -0,0 +1,33 @@
+-- merge tags whose names only differ by case into the oldest (lowest id) tag, before enforcing uniqueness.
+INSERT INTO public.event_tags (event_id, tag_id)
+SELECT et.event_id, d.keep_id
+FROM public.event_tags et
+JOIN (
+   SELECT id, FIRST_VALUE(id) OVER (PARTITION BY lower(name) ORDER BY id) AS keep_id
+   FROM public.tags
+) d ON d.id = et.tag_id
+WHERE d.id <> d.keep_id
+ON CONFLICT (event_id, tag_id) DO NOTHING;
+
+DELETE FROM public.tags t
+USING public.tags older
+WHERE lower(t.name) = lower(older.name) AND older.id < t.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_key ON public.tags (lower(name));
+
+-- merge categories in the same way.
+INSERT INTO public.event_categories (event_id, category_id)
+SELECT ec.event_id, d.keep_id
+FROM public.event_categories ec
+JOIN (
+   SELECT id, FIRST_VALUE(id) OVER (PARTITION BY lower(name) ORDER BY id) AS keep_id
+   FROM public.categories
+) d ON d.id = ec.category_id
+WHERE d.id <> d.keep_id
+ON CONFLICT (event_id, category_id) DO NOTHING;
+
+DELETE FROM public.categories c
+USING public.categories older
+WHERE lower(c.name) = lower(older.name) AND older.id < c.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS categories_name_key ON public.categories (lower(name));
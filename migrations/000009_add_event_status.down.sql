@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS public.events_status_end_date_idx;
+
+ALTER TABLE public.events
+   DROP CONSTRAINT IF EXISTS events_cancellation_reason_check,
+   DROP COLUMN IF EXISTS cancellation_reason,
+   DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS event_status;
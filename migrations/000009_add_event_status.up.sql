@@ -0,0 +1,14 @@
+CREATE TYPE event_status AS ENUM ('draft', 'published', 'cancelled', 'completed');
+
+-- events created before statuses were introduced were publicly visible, so are published.
+ALTER TABLE public.events
+   ADD COLUMN IF NOT EXISTS status event_status NOT NULL DEFAULT 'published',
+   ADD COLUMN IF NOT EXISTS cancellation_reason VARCHAR(500),
+   ADD CONSTRAINT events_cancellation_reason_check CHECK (status = 'cancelled' OR cancellation_reason IS NULL);
+
+ALTER TABLE public.events ALTER COLUMN status SET DEFAULT 'draft';
+
+UPDATE public.events SET status = 'completed' WHERE end_date < CURRENT_TIMESTAMP;
+
+-- used to find published events which have ended and need to be completed.
+CREATE INDEX IF NOT EXISTS events_status_end_date_idx ON public.events (status, end_date);
@@ -0,0 +1,7 @@
+-- secret calendar subscription feeds, the token itself is never stored only its sha256 hash.
+CREATE TABLE IF NOT EXISTS public.calendar_feeds (
+   user_id UUID PRIMARY KEY,
+   token_hash text NOT NULL UNIQUE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
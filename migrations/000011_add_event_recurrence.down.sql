@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS public.event_occurrence_exceptions;
+
+-- only a single attendance and waitlist entry per user can be kept for each event.
+DELETE FROM public.event_waitlist w USING public.event_waitlist d
+WHERE w.event_id = d.event_id AND w.user_id = d.user_id AND w.occurrence_start > d.occurrence_start;
+
+DROP INDEX IF EXISTS public.event_waitlist_event_id_occurrence_start_queued_at_idx;
+ALTER TABLE public.event_waitlist
+   DROP CONSTRAINT IF EXISTS event_waitlist_pkey,
+   DROP COLUMN IF EXISTS occurrence_start,
+   ADD PRIMARY KEY (event_id, user_id);
+CREATE INDEX IF NOT EXISTS event_waitlist_event_id_queued_at_idx ON public.event_waitlist (event_id, queued_at, user_id);
+
+DELETE FROM public.event_attendees a USING public.event_attendees d
+WHERE a.event_id = d.event_id AND a.attendee_id = d.attendee_id AND a.occurrence_start > d.occurrence_start;
+
+ALTER TABLE public.event_attendees
+   DROP CONSTRAINT IF EXISTS event_attendees_pkey,
+   DROP COLUMN IF EXISTS occurrence_start,
+   ADD PRIMARY KEY (event_id, attendee_id);
+
+DROP INDEX IF EXISTS public.events_status_last_end_date_idx;
+CREATE INDEX IF NOT EXISTS events_status_end_date_idx ON public.events (status, end_date);
+
+ALTER TABLE public.events
+   DROP COLUMN IF EXISTS last_end_date,
+   DROP COLUMN IF EXISTS recurrence_rule;
@@ -0,0 +1,43 @@
+-- a NULL recurrence rule represents an event with a single occurrence.
+-- last_end_date is the end of the last occurrence, which is NULL for a series without an end.
+ALTER TABLE public.events
+   ADD COLUMN IF NOT EXISTS recurrence_rule VARCHAR(255),
+   ADD COLUMN IF NOT EXISTS last_end_date TIMESTAMPTZ;
+
+UPDATE public.events SET last_end_date = end_date;
+
+DROP INDEX IF EXISTS public.events_status_end_date_idx;
+
+-- used to find published events which have ended and need to be completed.
+CREATE INDEX IF NOT EXISTS events_status_last_end_date_idx ON public.events (status, last_end_date);
+
+-- occurrences are identified by their originally scheduled start, which for an event without a recurrence rule is its start date.
+ALTER TABLE public.event_attendees ADD COLUMN IF NOT EXISTS occurrence_start TIMESTAMPTZ;
+UPDATE public.event_attendees a SET occurrence_start = e.start_date FROM public.events e WHERE e.id = a.event_id;
+ALTER TABLE public.event_attendees
+   ALTER COLUMN occurrence_start SET NOT NULL,
+   DROP CONSTRAINT IF EXISTS event_attendees_pkey,
+   ADD PRIMARY KEY (event_id, occurrence_start, attendee_id);
+
+ALTER TABLE public.event_waitlist ADD COLUMN IF NOT EXISTS occurrence_start TIMESTAMPTZ;
+UPDATE public.event_waitlist w SET occurrence_start = e.start_date FROM public.events e WHERE e.id = w.event_id;
+ALTER TABLE public.event_waitlist
+   ALTER COLUMN occurrence_start SET NOT NULL,
+   DROP CONSTRAINT IF EXISTS event_waitlist_pkey,
+   ADD PRIMARY KEY (event_id, occurrence_start, user_id);
+
+DROP INDEX IF EXISTS public.event_waitlist_event_id_queued_at_idx;
+CREATE INDEX IF NOT EXISTS event_waitlist_event_id_occurrence_start_queued_at_idx ON public.event_waitlist (event_id, occurrence_start, queued_at, user_id);
+
+-- exceptions cancel or reschedule a single occurrence of a recurring event.
+CREATE TABLE IF NOT EXISTS public.event_occurrence_exceptions (
+   event_id UUID NOT NULL,
+   occurrence_start TIMESTAMPTZ NOT NULL,
+   cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+   start_date TIMESTAMPTZ,
+   end_date TIMESTAMPTZ,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE,
+   PRIMARY KEY(event_id, occurrence_start),
+   CONSTRAINT event_occurrence_exceptions_dates_check CHECK ((start_date IS NULL) = (end_date IS NULL) AND (start_date IS NULL OR start_date < end_date))
+);
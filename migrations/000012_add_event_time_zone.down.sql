@@ -0,0 +1,2 @@
+ALTER TABLE public.events
+   DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,3 @@
+-- events created before time zones were introduced are shown in UTC.
+ALTER TABLE public.events
+   ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.order_items;
+DROP TABLE IF EXISTS public.orders;
+DROP TYPE IF EXISTS order_status;
+DROP TABLE IF EXISTS public.ticket_types;
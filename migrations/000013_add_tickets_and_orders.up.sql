@@ -0,0 +1,58 @@
+-- ticket types are the places which can be ordered for a paid event, prices are in the minor unit of their currency such as cents.
+-- the quantity of a ticket type applies to each occurrence of the event.
+CREATE TABLE IF NOT EXISTS public.ticket_types (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   event_id UUID NOT NULL,
+   name VARCHAR(100) NOT NULL,
+   description VARCHAR(500),
+   price BIGINT NOT NULL CHECK (price >= 0),
+   currency CHAR(3) NOT NULL,
+   quantity INT NOT NULL CHECK (quantity > 0),
+   sales_start TIMESTAMPTZ,
+   sales_end TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE,
+   CONSTRAINT ticket_types_sales_window_check CHECK (sales_start IS NULL OR sales_end IS NULL OR sales_start < sales_end)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ticket_types_event_id_name_key ON public.ticket_types (event_id, lower(name));
+
+DROP TYPE IF EXISTS order_status;
+CREATE TYPE order_status AS ENUM ('pending', 'paid', 'cancelled', 'refunded');
+
+-- pending orders hold their tickets until they expire, paid orders until they are refunded.
+-- orders are a record of payments, so events with orders can not be deleted.
+CREATE TABLE IF NOT EXISTS public.orders (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   event_id UUID NOT NULL,
+   user_id UUID NOT NULL,
+   occurrence_start TIMESTAMPTZ NOT NULL,
+   status order_status NOT NULL DEFAULT 'pending',
+   total BIGINT NOT NULL CHECK (total >= 0),
+   currency CHAR(3) NOT NULL,
+   expires_at TIMESTAMPTZ NOT NULL,
+   paid_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT,
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_id_created_at_idx ON public.orders (user_id, created_at);
+CREATE INDEX IF NOT EXISTS orders_event_id_occurrence_start_idx ON public.orders (event_id, occurrence_start);
+-- used to find pending orders which have expired.
+CREATE INDEX IF NOT EXISTS orders_status_expires_at_idx ON public.orders (status, expires_at);
+
+-- the unit price is copied from the ticket type when ordering, so that later price changes do not alter the order.
+CREATE TABLE IF NOT EXISTS public.order_items (
+   order_id UUID NOT NULL,
+   ticket_type_id UUID NOT NULL,
+   quantity INT NOT NULL CHECK (quantity > 0),
+   unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+   FOREIGN KEY (order_id) REFERENCES public.orders(id) ON DELETE CASCADE,
+   FOREIGN KEY (ticket_type_id) REFERENCES public.ticket_types(id) ON DELETE RESTRICT,
+   PRIMARY KEY(order_id, ticket_type_id)
+);
+
+CREATE INDEX IF NOT EXISTS order_items_ticket_type_id_idx ON public.order_items (ticket_type_id);
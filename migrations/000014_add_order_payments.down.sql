@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS public.payment_events;
+
+ALTER TABLE public.orders
+   DROP COLUMN IF EXISTS payment_intent_id,
+   DROP COLUMN IF EXISTS payment_provider;
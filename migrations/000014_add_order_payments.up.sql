@@ -0,0 +1,15 @@
+-- the payment intent an order is being paid through, orders which were marked as paid by hand have none.
+ALTER TABLE public.orders
+   ADD COLUMN IF NOT EXISTS payment_provider VARCHAR(50),
+   ADD COLUMN IF NOT EXISTS payment_intent_id VARCHAR(255);
+
+-- webhook events which have been processed, as payment providers may deliver the same event more than once.
+CREATE TABLE IF NOT EXISTS public.payment_events (
+   provider VARCHAR(50) NOT NULL,
+   event_id VARCHAR(255) NOT NULL,
+   event_type VARCHAR(50) NOT NULL,
+   order_id UUID,
+   processed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (order_id) REFERENCES public.orders(id) ON DELETE SET NULL,
+   PRIMARY KEY(provider, event_id)
+);
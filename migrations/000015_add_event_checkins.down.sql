@@ -0,0 +1,3 @@
+ALTER TABLE public.event_attendees
+   DROP COLUMN IF EXISTS checked_in_by,
+   DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,4 @@
+-- attendees are checked in at the door by the organizer, at most once per occurrence.
+ALTER TABLE public.event_attendees
+   ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMPTZ,
+   ADD COLUMN IF NOT EXISTS checked_in_by UUID REFERENCES public.users(id) ON DELETE SET NULL;
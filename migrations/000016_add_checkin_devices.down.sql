@@ -0,0 +1 @@
+ALTER TABLE public.event_attendees DROP COLUMN IF EXISTS checked_in_device;
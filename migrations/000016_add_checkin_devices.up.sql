@@ -0,0 +1,2 @@
+-- the device which checked in an attendee while offline, allowing repeated syncs of the same scan to be recognised.
+ALTER TABLE public.event_attendees ADD COLUMN IF NOT EXISTS checked_in_device VARCHAR(100);
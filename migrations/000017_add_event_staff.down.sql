@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.event_staff;
+DROP TYPE IF EXISTS event_staff_role;
@@ -0,0 +1,20 @@
+DROP TYPE IF EXISTS event_staff_role;
+CREATE TYPE event_staff_role AS ENUM ('co_organizer', 'checkin_staff', 'moderator');
+
+-- users helping the organizer to run an event, who are invited by the organizer and must accept before their role takes effect.
+CREATE TABLE IF NOT EXISTS public.event_staff (
+   event_id UUID NOT NULL,
+   user_id UUID NOT NULL,
+   role event_staff_role NOT NULL,
+   invited_by UUID,
+   accepted_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE,
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE,
+   FOREIGN KEY (invited_by) REFERENCES public.users(id) ON DELETE SET NULL,
+   PRIMARY KEY(event_id, user_id)
+);
+
+-- used to list the pending invitations of a user.
+CREATE INDEX IF NOT EXISTS event_staff_user_id_idx ON public.event_staff (user_id);
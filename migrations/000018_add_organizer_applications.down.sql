@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.organizer_applications;
+DROP TYPE IF EXISTS organizer_application_status;
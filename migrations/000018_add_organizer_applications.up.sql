@@ -0,0 +1,26 @@
+DROP TYPE IF EXISTS organizer_application_status;
+CREATE TYPE organizer_application_status AS ENUM ('pending', 'approved', 'rejected');
+
+-- applications of users to become organizers, which are reviewed by admins.
+-- users may apply again once their previous application was rejected.
+CREATE TABLE IF NOT EXISTS public.organizer_applications (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   user_id UUID NOT NULL,
+   reason VARCHAR(2000) NOT NULL,
+   links TEXT[] NOT NULL DEFAULT '{}',
+   contact_email VARCHAR(255) NOT NULL,
+   contact_phone VARCHAR(50),
+   status organizer_application_status NOT NULL DEFAULT 'pending',
+   reviewed_by UUID,
+   review_comment VARCHAR(1000),
+   reviewed_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE,
+   FOREIGN KEY (reviewed_by) REFERENCES public.users(id) ON DELETE SET NULL
+);
+
+-- a user may only have a single application awaiting review.
+CREATE UNIQUE INDEX IF NOT EXISTS organizer_applications_user_id_pending_key ON public.organizer_applications (user_id) WHERE status = 'pending';
+-- used to list the review queue, oldest applications first.
+CREATE INDEX IF NOT EXISTS organizer_applications_status_created_at_idx ON public.organizer_applications (status, created_at);
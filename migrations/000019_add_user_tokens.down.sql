@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.user_tokens;
+DROP TYPE IF EXISTS user_token_purpose;
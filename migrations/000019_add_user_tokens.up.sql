@@ -0,0 +1,22 @@
+DROP TYPE IF EXISTS user_token_purpose;
+CREATE TYPE user_token_purpose AS ENUM ('email_verification');
+
+-- single-use tokens sent to users, such as within the link verifying their email address.
+-- only the sha256 hash of each token is stored, so a leaked table does not reveal usable tokens.
+CREATE TABLE IF NOT EXISTS public.user_tokens (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   user_id UUID NOT NULL,
+   purpose user_token_purpose NOT NULL,
+   token_hash VARCHAR(64) NOT NULL,
+   expires_at TIMESTAMPTZ NOT NULL,
+   used_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE,
+   CONSTRAINT user_tokens_token_hash_key UNIQUE (token_hash)
+);
+
+-- used to throttle how often tokens are sent to a user.
+CREATE INDEX IF NOT EXISTS user_tokens_user_id_purpose_created_at_idx ON public.user_tokens (user_id, purpose, created_at);
+
+-- organizers and admins were vetted before email verification existed, so they keep creating events.
+UPDATE public.users SET verified = TRUE WHERE role IN ('organizer', 'admin');
@@ -0,0 +1,6 @@
+-- values can not be removed from an enum, so the type is recreated without it.
+DELETE FROM public.user_tokens WHERE purpose = 'password_reset';
+ALTER TYPE user_token_purpose RENAME TO user_token_purpose_old;
+CREATE TYPE user_token_purpose AS ENUM ('email_verification');
+ALTER TABLE public.user_tokens ALTER COLUMN purpose TYPE user_token_purpose USING purpose::text::user_token_purpose;
+DROP TYPE user_token_purpose_old;
@@ -0,0 +1,2 @@
+-- the value is not used within this migration, as an enum value can not be used within the transaction adding it.
+ALTER TYPE user_token_purpose ADD VALUE IF NOT EXISTS 'password_reset';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.mail_queue;
+DROP TYPE IF EXISTS mail_status;
@@ -0,0 +1,21 @@
+DROP TYPE IF EXISTS mail_status;
+CREATE TYPE mail_status AS ENUM ('pending', 'sent', 'failed');
+
+-- emails awaiting delivery, they are rendered when queued so that each retry sends the same message.
+CREATE TABLE IF NOT EXISTS public.mail_queue (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   recipient VARCHAR(255) NOT NULL,
+   subject VARCHAR(255) NOT NULL,
+   text_body TEXT NOT NULL,
+   html_body TEXT NOT NULL,
+   status mail_status NOT NULL DEFAULT 'pending',
+   attempts INT NOT NULL DEFAULT 0,
+   last_error TEXT,
+   next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   sent_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- used to find the pending emails which are due to be sent.
+CREATE INDEX IF NOT EXISTS mail_queue_pending_next_attempt_at_idx ON public.mail_queue (next_attempt_at) WHERE status = 'pending';
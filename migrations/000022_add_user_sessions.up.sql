@@ -0,0 +1,26 @@
+-- sessions of users signed in on a device, kept alive by refreshing their access token with a refresh token.
+CREATE TABLE IF NOT EXISTS public.user_sessions (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   user_id UUID NOT NULL,
+   user_agent TEXT NOT NULL DEFAULT '',
+   ip_address VARCHAR(45) NOT NULL DEFAULT '',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   last_used_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   expires_at TIMESTAMPTZ NOT NULL,
+   revoked_at TIMESTAMPTZ,
+   FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_sessions_user_id_idx ON public.user_sessions (user_id);
+
+-- refresh tokens issued for a session, identified by the jti claim of the token.
+-- each token may only be used once, refreshing replaces it with a new token of the same session.
+CREATE TABLE IF NOT EXISTS public.refresh_tokens (
+   id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+   session_id UUID NOT NULL,
+   used_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   FOREIGN KEY (session_id) REFERENCES public.user_sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_session_id_idx ON public.refresh_tokens (session_id);
@@ -1,12 +1,19 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/persist"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
 )
 
 // Configuration .
@@ -15,6 +22,15 @@ type Configuration struct {
 	Port     int
 	Security SecurityConfiguration
 	Database persist.DatabaseConfiguration
+	Events   service.EventLifecycleConfiguration
+	Orders   service.OrderConfiguration
+	Payments service.PaymentConfiguration
+	CheckIns service.CheckInConfiguration
+	// BaseURL the url the api is publicly reachable at, used within links sent to users.
+	BaseURL           string
+	EmailVerification service.EmailVerificationConfiguration
+	PasswordReset     service.PasswordResetConfiguration
+	Mail              service.MailConfiguration
 }
 
 type SecurityConfiguration struct {
@@ -49,15 +65,127 @@ func NewEnvironmentConfiguration() Configuration {
 		panic(errors.New("invalid environment configuration: REFRESH_TOKEN_SECRET is required"))
 	}
 
+	checkInTokenSecret, ok := os.LookupEnv("CHECKIN_TOKEN_SECRET")
+
+	if !ok || checkInTokenSecret == "" {
+		if GoEnv(env).IsProduction() {
+			panic(errors.New("invalid environment configuration: CHECKIN_TOKEN_SECRET is required in production"))
+		}
+		// outside of production a secret is generated on each start, so check-ins work without configuring it.
+		checkInTokenSecret, err = utils.GenerateToken(32)
+		if err != nil {
+			panic(fmt.Errorf("invalid environment configuration: unable to generate CHECKIN_TOKEN_SECRET: %w", err))
+		}
+	}
+
 	gClientId := os.Getenv("GOOGLE_CLIENT_ID")
 
+	eventCompletionInterval, err := time.ParseDuration(os.Getenv("EVENT_COMPLETION_INTERVAL"))
+
+	if err != nil || eventCompletionInterval <= 0 {
+		eventCompletionInterval = 5 * time.Minute
+	}
+
+	orderHoldDuration, err := time.ParseDuration(os.Getenv("ORDER_HOLD_DURATION"))
+
+	if err != nil || orderHoldDuration <= 0 {
+		orderHoldDuration = 15 * time.Minute
+	}
+
+	orderExpiryInterval, err := time.ParseDuration(os.Getenv("ORDER_EXPIRY_INTERVAL"))
+
+	if err != nil || orderExpiryInterval <= 0 {
+		orderExpiryInterval = time.Minute
+	}
+
+	paymentProvider, ok := os.LookupEnv("PAYMENT_PROVIDER")
+
+	if !ok || paymentProvider == "" {
+		paymentProvider = service.FakePaymentProviderName
+	}
+
+	baseURL, ok := os.LookupEnv("APP_BASE_URL")
+
+	if !ok || baseURL == "" {
+		baseURL = fmt.Sprintf("http://localhost:%d", port)
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	verificationTokenLifetime, err := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_TOKEN_LIFETIME"))
+
+	if err != nil || verificationTokenLifetime <= 0 {
+		verificationTokenLifetime = 24 * time.Hour
+	}
+
+	verificationResendInterval, err := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_RESEND_INTERVAL"))
+
+	if err != nil || verificationResendInterval <= 0 {
+		verificationResendInterval = time.Minute
+	}
+
+	passwordResetURL, ok := os.LookupEnv("PASSWORD_RESET_URL")
+
+	if !ok || passwordResetURL == "" {
+		passwordResetURL = baseURL + "/reset-password"
+	}
+
+	passwordResetTokenLifetime, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TOKEN_LIFETIME"))
+
+	if err != nil || passwordResetTokenLifetime <= 0 {
+		passwordResetTokenLifetime = time.Hour
+	}
+
+	mailTransport, ok := os.LookupEnv("MAIL_TRANSPORT")
+
+	if !ok || mailTransport == "" {
+		mailTransport = service.LogMailTransport
+	}
+
+	mailFrom, ok := os.LookupEnv("MAIL_FROM")
+
+	if !ok || mailFrom == "" {
+		mailFrom = "Event Management <no-reply@localhost>"
+	}
+
+	mailDirectory, ok := os.LookupEnv("MAIL_DIRECTORY")
+
+	if !ok || mailDirectory == "" {
+		mailDirectory = "./mail"
+	}
+
+	smtpPort, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+
+	if err != nil {
+		smtpPort = 587
+	}
+
+	mailQueueInterval, err := time.ParseDuration(os.Getenv("MAIL_QUEUE_INTERVAL"))
+
+	if err != nil || mailQueueInterval <= 0 {
+		mailQueueInterval = 30 * time.Second
+	}
+
+	mailMaxAttempts, err := strconv.Atoi(os.Getenv("MAIL_MAX_ATTEMPTS"))
+
+	if err != nil || mailMaxAttempts <= 0 {
+		mailMaxAttempts = 5
+	}
+
+	mailRetryDelay, err := time.ParseDuration(os.Getenv("MAIL_RETRY_DELAY"))
+
+	if err != nil || mailRetryDelay <= 0 {
+		mailRetryDelay = time.Minute
+	}
+
 	return Configuration{
-		Port: port,
-		Env:  ValidateEnv(GoEnv(env)),
+		Port:    port,
+		Env:     ValidateEnv(GoEnv(env)),
+		BaseURL: baseURL,
 		Security: SecurityConfiguration{
 			JsonWebToken: service.JsonWebTokenConfiguration{
 				AccessTokenSecret:  accessTokenSecret,
 				RefreshTokenSecret: refreshTokenSecret,
+				CheckInTokenSecret: deriveSecret(checkInTokenSecret, "check-in tokens"),
 			},
 			Google: service.GoogleAuthenticationConfiguration{
 				ClientId: gClientId,
@@ -71,5 +199,54 @@ func NewEnvironmentConfiguration() Configuration {
 			Password: os.Getenv("DATABASE_PASSWORD"),
 			SSLMode:  os.Getenv("DATABASE_SSL_MODE"),
 		},
+		Events: service.EventLifecycleConfiguration{
+			CompletionInterval: eventCompletionInterval,
+		},
+		Orders: service.OrderConfiguration{
+			HoldDuration:   orderHoldDuration,
+			ExpiryInterval: orderExpiryInterval,
+		},
+		Payments: service.PaymentConfiguration{
+			Provider:      paymentProvider,
+			WebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+			IsProduction:  GoEnv(env).IsProduction(),
+		},
+		CheckIns: service.CheckInConfiguration{
+			CodeSecret: deriveSecret(checkInTokenSecret, "offline check-in codes"),
+		},
+		EmailVerification: service.EmailVerificationConfiguration{
+			BaseURL:        baseURL,
+			TokenLifetime:  verificationTokenLifetime,
+			ResendInterval: verificationResendInterval,
+			MaxSendsPerDay: 10,
+		},
+		PasswordReset: service.PasswordResetConfiguration{
+			ResetURL:       passwordResetURL,
+			TokenLifetime:  passwordResetTokenLifetime,
+			ResendInterval: time.Minute,
+			MaxSendsPerDay: 5,
+		},
+		Mail: service.MailConfiguration{
+			Transport: mailTransport,
+			From:      mailFrom,
+			SMTP: service.SMTPConfiguration{
+				Host:     os.Getenv("SMTP_HOST"),
+				Port:     smtpPort,
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				Timeout:  30 * time.Second,
+			},
+			Directory:     mailDirectory,
+			QueueInterval: mailQueueInterval,
+			MaxAttempts:   mailMaxAttempts,
+			RetryDelay:    mailRetryDelay,
+		},
 	}
 }
+
+// deriveSecret derives a secret for a single purpose from the configured secret, so that a value signed for one purpose is never accepted for another.
+func deriveSecret(secret string, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,48 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/config"
+)
+
+func TestConfig_CheckInSecret(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_SECRET", "access")
+	t.Setenv("REFRESH_TOKEN_SECRET", "refresh")
+
+	t.Run("generates a secret outside of production", func(t *testing.T) {
+		t.Setenv("ENV", string(config.Dev))
+		t.Setenv("CHECKIN_TOKEN_SECRET", "")
+
+		c := config.NewEnvironmentConfiguration()
+		if len(c.Security.JsonWebToken.CheckInTokenSecret) < 1 || len(c.CheckIns.CodeSecret) < 1 {
+			t.Error("expected check-in secrets to be generated")
+		}
+	})
+
+	t.Run("requires a secret in production", func(t *testing.T) {
+		t.Setenv("ENV", string(config.Prod))
+		t.Setenv("CHECKIN_TOKEN_SECRET", "")
+
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a missing check-in secret to be refused in production")
+			}
+		}()
+		config.NewEnvironmentConfiguration()
+	})
+
+	t.Run("signs tokens and codes with separate secrets", func(t *testing.T) {
+		t.Setenv("ENV", string(config.Dev))
+		t.Setenv("CHECKIN_TOKEN_SECRET", "checkin")
+
+		c := config.NewEnvironmentConfiguration()
+		tokenSecret, codeSecret := c.Security.JsonWebToken.CheckInTokenSecret, c.CheckIns.CodeSecret
+		if tokenSecret == codeSecret || tokenSecret == "checkin" || codeSecret == "checkin" {
+			t.Error("expected a separate secret to be derived for check-in tokens and offline check-in codes")
+		}
+		if again := config.NewEnvironmentConfiguration(); again.CheckIns.CodeSecret != codeSecret {
+			t.Error("expected the derived secrets to be the same for the same configured secret")
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AttendeeModel represents a user attending an event, stored within the event_attendees table.
+type AttendeeModel struct {
+	UserID    string         `db:"attendee_id" json:"user_id"`
+	Username  string         `db:"username" json:"username"`
+	FirstName sql.NullString `db:"first_name" json:"first_name"`
+	LastName  sql.NullString `db:"last_name" json:"last_name"`
+	AvatarUrl sql.NullString `db:"avatar_url" json:"avatar_url"`
+	// OccurrenceStart the time the attended occurrence was originally scheduled to start.
+	OccurrenceStart time.Time    `db:"occurrence_start" json:"occurrence_start"`
+	CheckedInAt     sql.NullTime `db:"checked_in_at" json:"checked_in_at"`
+	// CheckedInDevice the device which checked in the attendee while offline, null for attendees checked in at the door.
+	CheckedInDevice sql.NullString `db:"checked_in_device" json:"checked_in_device"`
+	CreatedAt       time.Time      `db:"created_at" json:"created_at"`
+}
+
+// WaitlistEntryModel represents a user waiting for a place at an event which is full, stored within the event_waitlist table.
+type WaitlistEntryModel struct {
+	UserID    string         `db:"user_id" json:"user_id"`
+	Username  string         `db:"username" json:"username"`
+	FirstName sql.NullString `db:"first_name" json:"first_name"`
+	LastName  sql.NullString `db:"last_name" json:"last_name"`
+	AvatarUrl sql.NullString `db:"avatar_url" json:"avatar_url"`
+	// OccurrenceStart the time the occurrence was originally scheduled to start, positions are within the waitlist of each occurrence.
+	OccurrenceStart time.Time `db:"occurrence_start" json:"occurrence_start"`
+	Position        int       `json:"position"`
+	QueuedAt        time.Time `db:"queued_at" json:"queued_at"`
+}
@@ -0,0 +1,239 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/rrule"
+)
+
+// EventModel represents the event data stored in the database.
+type EventModel struct {
+	Model
+	Name        string            `db:"name" json:"name"`
+	OrganizerID string            `db:"organizer_id" json:"organizer_id"`
+	Description sql.NullString    `db:"description" json:"description"`
+	StartDate   time.Time         `db:"start_date" json:"start_date"`
+	EndDate     time.Time         `db:"end_date" json:"end_date"`
+	TimeZone    string            `db:"time_zone" json:"time_zone"`
+	IsPaid      bool              `db:"is_paid" json:"is_paid"`
+	EventType   types.EventType   `db:"event_type" json:"event_type"`
+	Country     sql.NullString    `db:"country" json:"country"`
+	City        sql.NullString    `db:"city" json:"city"`
+	Slug        string            `db:"slug" json:"slug"`
+	Likes       int               `db:"likes" json:"likes"`
+	Follows     int               `db:"follows" json:"follows"`
+	Attendees   int               `db:"attendees" json:"attendees"`
+	Capacity    sql.NullInt32     `db:"capacity" json:"capacity"`
+	Status      types.EventStatus `db:"status" json:"status"`
+	// CancellationReason is only set for cancelled events.
+	CancellationReason sql.NullString `db:"cancellation_reason" json:"cancellation_reason"`
+	// RecurrenceRule repeats the event, its start and end dates are those of the first occurrence.
+	RecurrenceRule sql.NullString `db:"recurrence_rule" json:"recurrence_rule"`
+	// LastEndDate the end of the events last occurrence, which is not set for recurring events without an end.
+	LastEndDate sql.NullTime `db:"last_end_date" json:"last_end_date"`
+	// Occurrences are only set when listing events within a date window.
+	Occurrences []*OccurrenceModel `json:"occurrences,omitempty"`
+	// LikedByMe and FollowedByMe are only set for authenticated requests.
+	LikedByMe    *bool `json:"liked_by_me,omitempty"`
+	FollowedByMe *bool `json:"followed_by_me,omitempty"`
+}
+
+// MarshalJSON encodes the event with its start and end dates in UTC, alongside the local dates within the events time zone.
+func (m EventModel) MarshalJSON() ([]byte, error) {
+	type event EventModel // event has no methods, preventing MarshalJSON from being called recursively.
+	location := m.Location()
+	return json.Marshal(struct {
+		event
+		StartDate      time.Time `json:"start_date"`
+		EndDate        time.Time `json:"end_date"`
+		LocalStartDate time.Time `json:"local_start_date"`
+		LocalEndDate   time.Time `json:"local_end_date"`
+	}{
+		event:          event(m),
+		StartDate:      m.StartDate.UTC(),
+		EndDate:        m.EndDate.UTC(),
+		LocalStartDate: m.StartDate.In(location),
+		LocalEndDate:   m.EndDate.In(location),
+	})
+}
+
+// BeforeCreate overrides model lifecycle hook, defaulting the event type, time zone and status.
+// The events slug is generated by the repository, as it must be unique.
+func (m *EventModel) BeforeCreate() error {
+	if len(m.EventType) < 1 {
+		m.EventType = types.OnlineEventType
+	}
+	if len(m.TimeZone) < 1 {
+		m.TimeZone = time.UTC.String()
+	}
+	if len(m.Status) < 1 {
+		m.Status = types.DraftEventStatus
+	}
+	return m.updateLastEndDate()
+}
+
+// BeforeUpdate overrides model lifecycle hook, updating the updated_at and last_end_date times.
+func (m *EventModel) BeforeUpdate() error {
+	m.UpdatedAt = time.Now()
+	return m.updateLastEndDate()
+}
+
+// updateLastEndDate sets the end of the events last occurrence from its dates and recurrence rule.
+func (m *EventModel) updateLastEndDate() error {
+	m.LastEndDate = sql.NullTime{Time: m.EndDate, Valid: true}
+	if !m.IsRecurring() {
+		return nil
+	}
+
+	rule, err := m.Recurrence()
+	if err != nil {
+		return err
+	}
+	last, ok := rule.Last(m.localStartDate())
+	m.LastEndDate = sql.NullTime{Time: last.Add(m.Duration()), Valid: ok}
+	return nil
+}
+
+// IsOrganizedBy returns true if the user with the provided id is the organizer of the event.
+func (m *EventModel) IsOrganizedBy(userId string) bool {
+	return m.OrganizerID == userId
+}
+
+// Permits returns true if the user may perform the action on the event, provided their staff membership of the event if any.
+// The events organizer and users whose role grants managing any event may perform every action,
+// while staff are limited to those permitted by their role once they have accepted their invitation.
+func (m *EventModel) Permits(user *UserModel, staff *EventStaffModel, permission types.EventPermission) bool {
+	if user.Role.Grants(types.ManageAnyEventPermission) || m.IsOrganizedBy(user.ID) {
+		return true
+	}
+	return staff != nil && staff.UserID == user.ID && staff.EventID == m.ID && staff.IsActive() && staff.Role.Permits(permission)
+}
+
+// HasEnded returns true if the event ended before the provided time, for recurring events this is the end of the last occurrence.
+func (m *EventModel) HasEnded(now time.Time) bool {
+	if m.IsRecurring() {
+		return m.LastEndDate.Valid && m.LastEndDate.Time.Before(now)
+	}
+	return m.EndDate.Before(now)
+}
+
+// IsRecurring returns true if the event has a recurrence rule.
+func (m *EventModel) IsRecurring() bool {
+	return m.RecurrenceRule.Valid && len(m.RecurrenceRule.String) > 0
+}
+
+// Recurrence parses the events recurrence rule.
+func (m *EventModel) Recurrence() (*rrule.Rule, error) {
+	return rrule.Parse(m.RecurrenceRule.String)
+}
+
+// Location returns the events time zone, events with an unknown time zone are in UTC.
+func (m *EventModel) Location() *time.Location {
+	location, err := utils.LoadTimeZone(m.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// localStartDate returns the start of the first occurrence within the events time zone.
+// Recurrences are expanded from the local start, so that occurrences keep the same local time when daylight saving time begins or ends.
+func (m *EventModel) localStartDate() time.Time {
+	return m.StartDate.In(m.Location())
+}
+
+// Duration returns the length of each of the events occurrences.
+func (m *EventModel) Duration() time.Duration {
+	return m.EndDate.Sub(m.StartDate)
+}
+
+// IsFull returns true if the event has a capacity which its attendees have reached.
+func (m *EventModel) IsFull() bool {
+	return m.Capacity.Valid && m.Attendees >= int(m.Capacity.Int32)
+}
+
+// IsDraft returns true if the event has not yet been published, drafts are only visible to their organizer and admins.
+func (m *EventModel) IsDraft() bool {
+	return m.Status == types.DraftEventStatus
+}
+
+// CanBeAttended returns true if users can currently attend the event, which must be published.
+func (m *EventModel) CanBeAttended() bool {
+	return m.Status == types.PublishedEventStatus
+}
+
+// CanBeReviewed returns true if the event can be reviewed by its attendees at the provided time.
+// Published events which have ended can be reviewed before they are marked as completed.
+func (m *EventModel) CanBeReviewed(now time.Time) bool {
+	return m.Status == types.CompletedEventStatus || (m.Status == types.PublishedEventStatus && m.HasEnded(now))
+}
+
+// ChangeStatus moves the event to the provided status, only cancelled events keep a cancellation reason.
+func (m *EventModel) ChangeStatus(payload dtos.ChangeEventStatus) {
+	m.Status = payload.Status
+	m.CancellationReason = sql.NullString{
+		String: payload.CancellationReason,
+		Valid:  payload.Status == types.CancelledEventStatus,
+	}
+}
+
+func (m *EventModel) UpdateFrom(payload dtos.CreateOrUpdateEvent) {
+	if len(payload.Name) > 0 {
+		m.Name = payload.Name
+	}
+	if len(payload.Description) > 0 {
+		m.Description = sql.NullString{
+			String: payload.Description,
+			Valid:  true,
+		}
+	}
+	if payload.StartDate != nil {
+		m.StartDate = *payload.StartDate
+	}
+	if payload.EndDate != nil {
+		m.EndDate = *payload.EndDate
+	}
+	if payload.IsPaid != nil {
+		m.IsPaid = *payload.IsPaid
+	}
+	if len(payload.EventType) > 0 {
+		m.EventType = payload.EventType
+	}
+	if payload.Capacity != nil {
+		// a capacity of zero removes the events attendee limit.
+		m.Capacity = sql.NullInt32{
+			Int32: int32(*payload.Capacity),
+			Valid: *payload.Capacity > 0,
+		}
+	}
+	if len(payload.Country) > 0 {
+		m.Country = sql.NullString{
+			String: payload.Country,
+			Valid:  true,
+		}
+	}
+	if len(payload.City) > 0 {
+		m.City = sql.NullString{
+			String: payload.City,
+			Valid:  true,
+		}
+	}
+	if len(payload.TimeZone) > 0 {
+		m.TimeZone = payload.TimeZone
+	}
+	if payload.RecurrenceRule != nil {
+		// the rule is stored in its normalized form, an empty rule removes the recurrence.
+		m.RecurrenceRule = sql.NullString{}
+		if rule, err := rrule.Parse(*payload.RecurrenceRule); err == nil {
+			m.RecurrenceRule = sql.NullString{
+				String: rule.String(),
+				Valid:  true,
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package models_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestEventModel_Lifecycle(t *testing.T) {
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Hour)
+	upcoming := now.Add(time.Hour)
+
+	testcases := []struct {
+		name             string
+		status           types.EventStatus
+		endDate          time.Time
+		expectAttendable bool
+		expectReviewable bool
+	}{
+		{name: "draft", status: types.DraftEventStatus, endDate: ended, expectAttendable: false, expectReviewable: false},
+		{name: "published upcoming", status: types.PublishedEventStatus, endDate: upcoming, expectAttendable: true, expectReviewable: false},
+		{name: "published ended", status: types.PublishedEventStatus, endDate: ended, expectAttendable: true, expectReviewable: true},
+		{name: "cancelled", status: types.CancelledEventStatus, endDate: ended, expectAttendable: false, expectReviewable: false},
+		{name: "completed", status: types.CompletedEventStatus, endDate: ended, expectAttendable: false, expectReviewable: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			event := models.EventModel{Status: testcase.status, EndDate: testcase.endDate}
+			if event.CanBeAttended() != testcase.expectAttendable {
+				t.Errorf("expected CanBeAttended to return %v", testcase.expectAttendable)
+			}
+			if event.CanBeReviewed(now) != testcase.expectReviewable {
+				t.Errorf("expected CanBeReviewed to return %v", testcase.expectReviewable)
+			}
+		})
+	}
+}
+
+func TestEventModel_ChangeStatus(t *testing.T) {
+	t.Run("cancelling keeps the reason", func(t *testing.T) {
+		event := models.EventModel{Status: types.PublishedEventStatus}
+		event.ChangeStatus(dtos.ChangeEventStatus{Status: types.CancelledEventStatus, CancellationReason: "Venue unavailable"})
+		if event.Status != types.CancelledEventStatus {
+			t.Errorf("expected status to be cancelled but was %s", event.Status)
+		}
+		if !event.CancellationReason.Valid || event.CancellationReason.String != "Venue unavailable" {
+			t.Errorf("expected cancellation reason to be set but was %v", event.CancellationReason)
+		}
+	})
+	t.Run("publishing has no reason", func(t *testing.T) {
+		event := models.EventModel{Status: types.DraftEventStatus}
+		event.ChangeStatus(dtos.ChangeEventStatus{Status: types.PublishedEventStatus})
+		if event.CancellationReason.Valid {
+			t.Error("expected cancellation reason to not be set")
+		}
+	})
+	t.Run("allowed transitions", func(t *testing.T) {
+		if !types.DraftEventStatus.CanTransitionTo(types.PublishedEventStatus) {
+			t.Error("expected drafts to be publishable")
+		}
+		if types.DraftEventStatus.CanTransitionTo(types.CompletedEventStatus) {
+			t.Error("expected drafts to not be completable")
+		}
+		if types.CancelledEventStatus.CanTransitionTo(types.PublishedEventStatus) {
+			t.Error("expected cancelled events to not be republished")
+		}
+	})
+}
+
+func TestEventModel_LastEndDate(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name          string
+		rule          string
+		expectedValid bool
+		expectedEnd   time.Time
+	}{
+		{name: "single occurrence", expectedValid: true, expectedEnd: start.Add(2 * time.Hour)},
+		{name: "bounded series", rule: "FREQ=WEEKLY;COUNT=3", expectedValid: true, expectedEnd: start.AddDate(0, 0, 14).Add(2 * time.Hour)},
+		{name: "unbounded series", rule: "FREQ=DAILY", expectedValid: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			end := start.Add(2 * time.Hour)
+			event := models.EventModel{}
+			event.UpdateFrom(dtos.CreateOrUpdateEvent{StartDate: &start, EndDate: &end, RecurrenceRule: &testcase.rule})
+			event.BeforeCreate()
+
+			if event.LastEndDate.Valid != testcase.expectedValid {
+				t.Fatalf("expected last end date to be valid %v", testcase.expectedValid)
+			}
+			if testcase.expectedValid && !event.LastEndDate.Time.Equal(testcase.expectedEnd) {
+				t.Errorf("expected last end date %v but got %v", testcase.expectedEnd, event.LastEndDate.Time)
+			}
+		})
+	}
+}
+
+func TestEventModel_ExpandOccurrences(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	rule := "FREQ=WEEKLY;COUNT=4"
+	event := models.EventModel{}
+	event.UpdateFrom(dtos.CreateOrUpdateEvent{StartDate: &start, EndDate: &end, RecurrenceRule: &rule})
+
+	second, third, fourth := start.AddDate(0, 0, 7), start.AddDate(0, 0, 14), start.AddDate(0, 0, 21)
+	exceptions := []*models.OccurrenceExceptionModel{
+		{OccurrenceStart: second, Cancelled: true},
+		// the fourth occurrence is moved into the window.
+		{
+			OccurrenceStart: fourth,
+			StartDate:       sql.NullTime{Time: third.Add(4 * time.Hour), Valid: true},
+			EndDate:         sql.NullTime{Time: third.Add(6 * time.Hour), Valid: true},
+		},
+	}
+
+	occurrences := event.ExpandOccurrences(start.Add(time.Hour), third.AddDate(0, 0, 1), exceptions)
+	if len(occurrences) != 4 {
+		t.Fatalf("expected 4 occurrences but got %d", len(occurrences))
+	}
+
+	expected := []struct {
+		occurrenceStart time.Time
+		start           time.Time
+		cancelled       bool
+		rescheduled     bool
+	}{
+		{occurrenceStart: start, start: start},
+		{occurrenceStart: second, start: second, cancelled: true},
+		{occurrenceStart: third, start: third},
+		{occurrenceStart: fourth, start: third.Add(4 * time.Hour), rescheduled: true},
+	}
+	for i, occurrence := range occurrences {
+		if !occurrence.OccurrenceStart.Equal(expected[i].occurrenceStart) || !occurrence.StartDate.Equal(expected[i].start) {
+			t.Errorf("expected occurrence %d originally at %v starting at %v but got %v at %v", i, expected[i].occurrenceStart, expected[i].start, occurrence.OccurrenceStart, occurrence.StartDate)
+		}
+		if occurrence.Cancelled != expected[i].cancelled || occurrence.Rescheduled != expected[i].rescheduled {
+			t.Errorf("expected occurrence %d to have cancelled %v and rescheduled %v", i, expected[i].cancelled, expected[i].rescheduled)
+		}
+	}
+
+	if _, ok := event.Occurrence(start.AddDate(0, 0, 1), exceptions); ok {
+		t.Error("expected no occurrence the day after the first occurrence")
+	}
+	if occurrence, ok := event.Occurrence(second, exceptions); !ok || occurrence.ID != "20240610T180000Z" {
+		t.Errorf("expected the second occurrence to be found by its original start")
+	}
+}
+
+func TestEventModel_SplitAt(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	rule := "FREQ=WEEKLY;COUNT=6"
+	event := models.EventModel{Name: "Weekly meetup", OrganizerID: "organizer", Status: types.PublishedEventStatus}
+	event.UpdateFrom(dtos.CreateOrUpdateEvent{StartDate: &start, EndDate: &end, RecurrenceRule: &rule})
+
+	split := start.AddDate(0, 0, 14)
+	later := split.Add(time.Hour)
+	next, err := event.SplitAt(split, dtos.CreateOrUpdateEvent{Name: "Weekly meetup, later", StartDate: &later})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if event.RecurrenceRule.String != "FREQ=WEEKLY;UNTIL=20240617T175959Z" {
+		t.Errorf("expected series to end before the split but has rule %s", event.RecurrenceRule.String)
+	}
+	if next.RecurrenceRule.String != "FREQ=WEEKLY;COUNT=4" {
+		t.Errorf("expected next series to have the remaining occurrences but has rule %s", next.RecurrenceRule.String)
+	}
+	if next.Name != "Weekly meetup, later" || next.OrganizerID != "organizer" || next.Status != types.PublishedEventStatus {
+		t.Error("expected next event to be copied from the event with the changes applied")
+	}
+	if !next.StartDate.Equal(later) || !next.EndDate.Equal(split.Add(2*time.Hour)) {
+		t.Errorf("expected next event to start at %v but starts at %v and ends at %v", later, next.StartDate, next.EndDate)
+	}
+}
+
+func TestEventModel_TimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is unavailable")
+	}
+
+	// 7pm in berlin, the day before daylight saving time begins.
+	start := time.Date(2024, time.March, 30, 19, 0, 0, 0, berlin)
+	end := start.Add(2 * time.Hour)
+	rule := "FREQ=DAILY;COUNT=2"
+	event := models.EventModel{}
+	event.UpdateFrom(dtos.CreateOrUpdateEvent{StartDate: &start, EndDate: &end, TimeZone: "Europe/Berlin", RecurrenceRule: &rule})
+
+	occurrences := event.ExpandOccurrences(start, start.AddDate(0, 0, 7), nil)
+	if len(occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences but got %d", len(occurrences))
+	}
+	if local := occurrences[1].LocalStartDate; local.Hour() != 19 || local.Location().String() != "Europe/Berlin" {
+		t.Errorf("expected second occurrence to start at 7pm in berlin but starts at %v", local)
+	}
+	if utc := occurrences[1].StartDate; utc.Hour() != 17 || utc.Location() != time.UTC {
+		t.Errorf("expected second occurrence to start at 5pm UTC but starts at %v", utc)
+	}
+
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for _, expected := range []string{`"start_date":"2024-03-30T18:00:00Z"`, `"local_start_date":"2024-03-30T19:00:00+01:00"`, `"time_zone":"Europe/Berlin"`} {
+		if !strings.Contains(string(encoded), expected) {
+			t.Errorf("expected encoded event to contain %s: %s", expected, encoded)
+		}
+	}
+}
+
+func TestEventModel_Permits(t *testing.T) {
+	event := models.EventModel{Model: models.Model{ID: "event"}, OrganizerID: "organizer"}
+	accepted := sql.NullTime{Time: time.Now(), Valid: true}
+
+	staff := func(role types.StaffRole, acceptedAt sql.NullTime) *models.EventStaffModel {
+		return &models.EventStaffModel{EventID: "event", UserID: "staff", Role: role, AcceptedAt: acceptedAt}
+	}
+
+	testcases := []struct {
+		name       string
+		user       models.UserModel
+		staff      *models.EventStaffModel
+		permission types.EventPermission
+		expected   bool
+	}{
+		{name: "organizer may delete", user: models.UserModel{Model: models.Model{ID: "organizer"}, Role: types.OrganizerRole}, permission: types.DeleteEventPermission, expected: true},
+		{name: "admin may manage staff", user: models.UserModel{Model: models.Model{ID: "admin"}, Role: types.AdminRole}, permission: types.ManageStaffPermission, expected: true},
+		{name: "other organizer may not manage", user: models.UserModel{Model: models.Model{ID: "other"}, Role: types.OrganizerRole}, permission: types.ManageEventPermission, expected: false},
+		{name: "co-organizer may manage", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.CoOrganizerStaffRole, accepted), permission: types.ManageEventPermission, expected: true},
+		{name: "co-organizer may not delete", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.CoOrganizerStaffRole, accepted), permission: types.DeleteEventPermission, expected: false},
+		{name: "co-organizer may not manage staff", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.CoOrganizerStaffRole, accepted), permission: types.ManageStaffPermission, expected: false},
+		{name: "check-in staff may check in", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.CheckInStaffRole, accepted), permission: types.CheckInPermission, expected: true},
+		{name: "check-in staff may not manage orders", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.CheckInStaffRole, accepted), permission: types.ManageOrdersPermission, expected: false},
+		{name: "moderator may moderate reviews", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.ModeratorStaffRole, accepted), permission: types.ModerateReviewsPermission, expected: true},
+		{name: "moderator may not check in", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.ModeratorStaffRole, accepted), permission: types.CheckInPermission, expected: false},
+		{name: "pending invitation has no effect", user: models.UserModel{Model: models.Model{ID: "staff"}, Role: types.UserRole}, staff: staff(types.CoOrganizerStaffRole, sql.NullTime{}), permission: types.ViewDraftPermission, expected: false},
+		{name: "staff of another user has no effect", user: models.UserModel{Model: models.Model{ID: "other"}, Role: types.UserRole}, staff: staff(types.CoOrganizerStaffRole, accepted), permission: types.ViewDraftPermission, expected: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if permitted := event.Permits(&testcase.user, testcase.staff, testcase.permission); permitted != testcase.expected {
+				t.Errorf("expected Permits(%s) to return %v but was %v", testcase.permission, testcase.expected, permitted)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+// MailModel represents an email awaiting delivery, stored within the mail_queue table.
+type MailModel struct {
+	Model
+	Recipient string           `db:"recipient" json:"recipient"`
+	Subject   string           `db:"subject" json:"subject"`
+	TextBody  string           `db:"text_body" json:"text_body"`
+	HTMLBody  string           `db:"html_body" json:"html_body"`
+	Status    types.MailStatus `db:"status" json:"status"`
+	// Attempts the number of times sending the email was attempted, LastError describes why the latest attempt failed.
+	Attempts      int            `db:"attempts" json:"attempts"`
+	LastError     sql.NullString `db:"last_error" json:"last_error"`
+	NextAttemptAt time.Time      `db:"next_attempt_at" json:"next_attempt_at"`
+	SentAt        sql.NullTime   `db:"sent_at" json:"sent_at"`
+}
+
+// NewMail creates the pending email of the rendered content to the recipient.
+func NewMail(recipient string, content *mail.Content) *MailModel {
+	return &MailModel{
+		Recipient: recipient,
+		Subject:   content.Subject,
+		TextBody:  content.Text,
+		HTMLBody:  content.HTML,
+		Status:    types.PendingMailStatus,
+	}
+}
+
+// RecordSent records the latest attempt to send the email as successful.
+func (m *MailModel) RecordSent(now time.Time) {
+	m.Status = types.SentMailStatus
+	m.SentAt = sql.NullTime{Time: now, Valid: true}
+	m.LastError = sql.NullString{}
+	m.UpdatedAt = now
+}
+
+// RecordFailure records the latest attempt to send the email as failed with the error.
+// The email is retried after the retry delay, which doubles with each attempt, until it has been attempted the maximum number of times.
+func (m *MailModel) RecordFailure(err error, now time.Time, maxAttempts int, retryDelay time.Duration) {
+	m.LastError = sql.NullString{String: err.Error(), Valid: true}
+	m.UpdatedAt = now
+
+	if m.Attempts >= maxAttempts {
+		m.Status = types.FailedMailStatus
+		return
+	}
+
+	m.NextAttemptAt = now.Add(retryDelay << max(m.Attempts-1, 0))
+}
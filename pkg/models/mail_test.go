@@ -0,0 +1,42 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestMailModel_RecordFailure(t *testing.T) {
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name              string
+		attempts          int
+		expectStatus      types.MailStatus
+		expectNextAttempt time.Time
+	}{
+		{name: "retries after the delay", attempts: 1, expectStatus: types.PendingMailStatus, expectNextAttempt: now.Add(time.Minute)},
+		{name: "doubles the delay with each attempt", attempts: 3, expectStatus: types.PendingMailStatus, expectNextAttempt: now.Add(4 * time.Minute)},
+		{name: "gives up after the last attempt", attempts: 5, expectStatus: types.FailedMailStatus},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mail := &models.MailModel{Status: types.PendingMailStatus, Attempts: tc.attempts}
+			mail.RecordFailure(errors.New("connection refused"), now, 5, time.Minute)
+
+			if mail.Status != tc.expectStatus {
+				t.Errorf("expected status %s but was %s", tc.expectStatus, mail.Status)
+			}
+			if mail.LastError.String != "connection refused" {
+				t.Errorf("expected the error to be recorded but was %q", mail.LastError.String)
+			}
+			if tc.expectStatus == types.PendingMailStatus && !mail.NextAttemptAt.Equal(tc.expectNextAttempt) {
+				t.Errorf("expected next attempt at %v but was %v", tc.expectNextAttempt, mail.NextAttemptAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package models
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+// occurrenceIDFormat the format of occurrence ids, the UTC time the occurrence was originally scheduled to start.
+const occurrenceIDFormat = "20060102T150405Z"
+
+// FormatOccurrenceID returns the id of the occurrence originally scheduled to start at the provided time.
+func FormatOccurrenceID(start time.Time) string {
+	return start.UTC().Format(occurrenceIDFormat)
+}
+
+// ParseOccurrenceID returns the time the occurrence was originally scheduled to start, RFC 3339 date times are also accepted.
+func ParseOccurrenceID(id string) (time.Time, error) {
+	if start, err := time.Parse(occurrenceIDFormat, id); err == nil {
+		return start, nil
+	}
+	return time.Parse(time.RFC3339, id)
+}
+
+// OccurrenceModel represents a single occurrence of an event, which is not stored but expanded from the events recurrence rule.
+type OccurrenceModel struct {
+	ID              string    `json:"id"`
+	EventID         string    `json:"event_id"`
+	OccurrenceStart time.Time `json:"occurrence_start"` // OccurrenceStart the time the occurrence was originally scheduled to start.
+	StartDate       time.Time `json:"start_date"`
+	EndDate         time.Time `json:"end_date"`
+	LocalStartDate  time.Time `json:"local_start_date"` // LocalStartDate the start date within the events time zone.
+	LocalEndDate    time.Time `json:"local_end_date"`   // LocalEndDate the end date within the events time zone.
+	Cancelled       bool      `json:"cancelled"`
+	Rescheduled     bool      `json:"rescheduled"`
+}
+
+// HasEnded returns true if the occurrence ended before the provided time.
+func (o *OccurrenceModel) HasEnded(now time.Time) bool {
+	return o.EndDate.Before(now)
+}
+
+// overlaps returns true if the occurrence takes place at any time between from and to.
+func (o *OccurrenceModel) overlaps(from time.Time, to time.Time) bool {
+	return o.EndDate.After(from) && o.StartDate.Before(to)
+}
+
+// OccurrenceExceptionModel represents a cancelled or rescheduled occurrence of a recurring event, stored within the event_occurrence_exceptions table.
+type OccurrenceExceptionModel struct {
+	EventID         string       `db:"event_id" json:"event_id"`
+	OccurrenceStart time.Time    `db:"occurrence_start" json:"occurrence_start"`
+	Cancelled       bool         `db:"cancelled" json:"cancelled"`
+	StartDate       sql.NullTime `db:"start_date" json:"start_date"`
+	EndDate         sql.NullTime `db:"end_date" json:"end_date"`
+	UpdatedAt       time.Time    `db:"updated_at" json:"updated_at"`
+}
+
+// NewOccurrenceException creates an exception to the occurrence, which keeps any existing changes to the occurrence.
+func NewOccurrenceException(occurrence *OccurrenceModel) *OccurrenceExceptionModel {
+	exception := &OccurrenceExceptionModel{
+		EventID:         occurrence.EventID,
+		OccurrenceStart: occurrence.OccurrenceStart,
+		Cancelled:       occurrence.Cancelled,
+	}
+	if occurrence.Rescheduled {
+		exception.StartDate = sql.NullTime{Time: occurrence.StartDate, Valid: true}
+		exception.EndDate = sql.NullTime{Time: occurrence.EndDate, Valid: true}
+	}
+	return exception
+}
+
+// IsRescheduled returns true if the exception moves the occurrence.
+func (m *OccurrenceExceptionModel) IsRescheduled() bool {
+	return m.StartDate.Valid && m.EndDate.Valid
+}
+
+func (m *OccurrenceExceptionModel) UpdateFrom(payload dtos.UpdateOccurrence) {
+	if payload.StartDate != nil && payload.EndDate != nil {
+		m.StartDate = sql.NullTime{Time: *payload.StartDate, Valid: true}
+		m.EndDate = sql.NullTime{Time: *payload.EndDate, Valid: true}
+	}
+	if payload.Cancelled != nil {
+		m.Cancelled = *payload.Cancelled
+	}
+}
+
+// findException returns the exception for the occurrence originally scheduled to start at the provided time, or nil.
+func findException(exceptions []*OccurrenceExceptionModel, start time.Time) *OccurrenceExceptionModel {
+	for _, exception := range exceptions {
+		if exception.OccurrenceStart.Equal(start) {
+			return exception
+		}
+	}
+	return nil
+}
+
+// occurrence returns the occurrence of the event originally scheduled to start at the provided time, with the exception applied.
+func (m *EventModel) occurrence(start time.Time, exception *OccurrenceExceptionModel) *OccurrenceModel {
+	occurrence := &OccurrenceModel{
+		ID:              FormatOccurrenceID(start),
+		EventID:         m.ID,
+		OccurrenceStart: start.UTC(),
+		StartDate:       start.UTC(),
+		EndDate:         start.Add(m.Duration()).UTC(),
+	}
+	if exception != nil {
+		occurrence.Cancelled = exception.Cancelled
+		if exception.IsRescheduled() {
+			occurrence.StartDate = exception.StartDate.Time.UTC()
+			occurrence.EndDate = exception.EndDate.Time.UTC()
+			occurrence.Rescheduled = true
+		}
+	}
+	location := m.Location()
+	occurrence.LocalStartDate = occurrence.StartDate.In(location)
+	occurrence.LocalEndDate = occurrence.EndDate.In(location)
+	return occurrence
+}
+
+// Occurrence returns the occurrence of the event originally scheduled to start at the provided time, with any exception applied.
+// Returns false if the event has no such occurrence, events without a recurrence rule only have a single occurrence at their start date.
+func (m *EventModel) Occurrence(start time.Time, exceptions []*OccurrenceExceptionModel) (*OccurrenceModel, bool) {
+	if !m.IsRecurring() {
+		if !start.Equal(m.StartDate) {
+			return nil, false
+		}
+		return m.occurrence(m.StartDate, nil), true
+	}
+
+	rule, err := m.Recurrence()
+	if err != nil || !rule.Includes(m.localStartDate(), start) {
+		return nil, false
+	}
+	return m.occurrence(start, findException(exceptions, start)), true
+}
+
+// ExpandOccurrences returns the occurrences of the event which take place at any time between from and to, ordered by their start date.
+// Cancelled occurrences are included, and rescheduled occurrences are included if they take place within the window once moved.
+func (m *EventModel) ExpandOccurrences(from time.Time, to time.Time, exceptions []*OccurrenceExceptionModel) []*OccurrenceModel {
+	occurrences := []*OccurrenceModel{}
+	if !m.IsRecurring() {
+		if occurrence := m.occurrence(m.StartDate, nil); occurrence.overlaps(from, to) {
+			occurrences = append(occurrences, occurrence)
+		}
+		return occurrences
+	}
+
+	rule, err := m.Recurrence()
+	if err != nil {
+		return occurrences
+	}
+
+	// occurrences which started before the window may not have ended.
+	expanded := map[int64]bool{}
+	for _, start := range rule.Between(m.localStartDate(), from.Add(-m.Duration()), to) {
+		expanded[start.UnixNano()] = true
+		if occurrence := m.occurrence(start, findException(exceptions, start)); occurrence.overlaps(from, to) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	// occurrences scheduled outside of the window may have been rescheduled into it.
+	for _, exception := range exceptions {
+		if expanded[exception.OccurrenceStart.UnixNano()] || !exception.IsRescheduled() {
+			continue
+		}
+		occurrence := m.occurrence(exception.OccurrenceStart, exception)
+		if occurrence.overlaps(from, to) && rule.Includes(m.localStartDate(), exception.OccurrenceStart) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartDate.Before(occurrences[j].StartDate)
+	})
+	return occurrences
+}
+
+// SplitAt ends the events recurrence before the provided occurrence, returning a new event which continues the series from that occurrence with the changes in the payload applied.
+// The new event has not been stored, and starts at the occurrences originally scheduled time unless changed by the payload.
+func (m *EventModel) SplitAt(start time.Time, payload dtos.CreateOrUpdateEvent) (*EventModel, error) {
+	rule, err := m.Recurrence()
+	if err != nil {
+		return nil, err
+	}
+
+	// a series limited by count continues with the occurrences which remain.
+	remaining := *rule
+	if rule.Count > 0 {
+		remaining.Count = rule.Count - len(rule.Between(m.localStartDate(), m.StartDate, start))
+	}
+
+	next := &EventModel{
+		Name:        m.Name,
+		OrganizerID: m.OrganizerID,
+		Description: m.Description,
+		StartDate:   start,
+		EndDate:     start.Add(m.Duration()),
+		TimeZone:    m.TimeZone,
+		IsPaid:      m.IsPaid,
+		EventType:   m.EventType,
+		Country:     m.Country,
+		City:        m.City,
+		Capacity:    m.Capacity,
+		Status:      m.Status,
+		RecurrenceRule: sql.NullString{
+			String: remaining.String(),
+			Valid:  true,
+		},
+	}
+	next.UpdateFrom(payload)
+
+	m.RecurrenceRule = sql.NullString{
+		String: rule.Truncated(start).String(),
+		Valid:  true,
+	}
+	return next, nil
+}
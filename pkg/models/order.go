@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// OrderModel represents a users order of tickets for an occurrence of a paid event, stored within the orders table.
+type OrderModel struct {
+	Model
+	EventID string `db:"event_id" json:"event_id"`
+	UserID  string `db:"user_id" json:"user_id"`
+	// OccurrenceStart the time the ordered occurrence was originally scheduled to start.
+	OccurrenceStart time.Time         `db:"occurrence_start" json:"occurrence_start"`
+	Status          types.OrderStatus `db:"status" json:"status"`
+	Total           int64             `db:"total" json:"total"`       // Total the price of all items in the minor unit of the currency, such as cents.
+	Currency        string            `db:"currency" json:"currency"` // Currency the ISO 4217 code of the currency, such as "EUR".
+	// ExpiresAt the time a pending order releases its tickets, after which it can no longer be paid.
+	ExpiresAt time.Time    `db:"expires_at" json:"expires_at"`
+	PaidAt    sql.NullTime `db:"paid_at" json:"paid_at"`
+	// PaymentProvider and PaymentIntentID identify the payment the order is being paid through, if any.
+	PaymentProvider sql.NullString    `db:"payment_provider" json:"payment_provider"`
+	PaymentIntentID sql.NullString    `db:"payment_intent_id" json:"payment_intent_id"`
+	Items           []*OrderItemModel `json:"items"`
+}
+
+// OrderItemModel represents the tickets of a single type within an order, stored within the order_items table.
+type OrderItemModel struct {
+	TicketTypeID string `db:"ticket_type_id" json:"ticket_type_id"`
+	Name         string `db:"name" json:"name"`
+	Quantity     int    `db:"quantity" json:"quantity"`
+	UnitPrice    int64  `db:"unit_price" json:"unit_price"` // UnitPrice the price of each ticket when it was ordered.
+}
+
+// NewOrder creates a pending order of the ticket types for the occurrence, which holds its tickets for the provided duration.
+// Each ordered ticket type must be one of the provided ticket types of the event, be on sale and share a currency with the others.
+func NewOrder(userId string, occurrence *OccurrenceModel, ticketTypes []*TicketTypeModel, payload dtos.CreateOrder, now time.Time, hold time.Duration) (*OrderModel, error) {
+	available := map[string]*TicketTypeModel{}
+	for _, ticketType := range ticketTypes {
+		available[ticketType.ID] = ticketType
+	}
+
+	order := &OrderModel{
+		EventID:         occurrence.EventID,
+		UserID:          userId,
+		OccurrenceStart: occurrence.OccurrenceStart,
+		Status:          types.PendingOrderStatus,
+		ExpiresAt:       now.Add(hold),
+		Items:           make([]*OrderItemModel, 0, len(payload.Items)),
+	}
+
+	for _, item := range payload.Items {
+		ticketType, ok := available[item.TicketTypeID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTicketType, item.TicketTypeID)
+		}
+		if !ticketType.IsOnSale(now) {
+			return nil, fmt.Errorf("%w: %s", ErrTicketTypeNotOnSale, ticketType.Name)
+		}
+		if len(order.Currency) < 1 {
+			order.Currency = ticketType.Currency
+		} else if order.Currency != ticketType.Currency {
+			return nil, ErrMixedCurrencies
+		}
+
+		order.Total += ticketType.Price * int64(item.Quantity)
+		order.Items = append(order.Items, &OrderItemModel{
+			TicketTypeID: ticketType.ID,
+			Name:         ticketType.Name,
+			Quantity:     item.Quantity,
+			UnitPrice:    ticketType.Price,
+		})
+	}
+
+	return order, nil
+}
+
+// BeforeUpdate overrides model lifecycle hook, updating the updated_at time.
+func (m *OrderModel) BeforeUpdate() error {
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsPlacedBy returns true if the user with the provided id placed the order.
+func (m *OrderModel) IsPlacedBy(userId string) bool {
+	return m.UserID == userId
+}
+
+// IsExpired returns true if the order is pending and its hold on its tickets ended before the provided time.
+func (m *OrderModel) IsExpired(now time.Time) bool {
+	return m.Status == types.PendingOrderStatus && !now.Before(m.ExpiresAt)
+}
+
+// SetPaymentIntent records the payment intent the order is being paid through, replacing any earlier intent.
+func (m *OrderModel) SetPaymentIntent(provider string, intentId string) {
+	m.PaymentProvider = sql.NullString{String: provider, Valid: true}
+	m.PaymentIntentID = sql.NullString{String: intentId, Valid: true}
+}
+
+// ChangeStatus moves the order to the provided status, recording when it was paid.
+func (m *OrderModel) ChangeStatus(status types.OrderStatus, now time.Time) {
+	m.Status = status
+	if status == types.PaidOrderStatus {
+		m.PaidAt = sql.NullTime{Time: now, Valid: true}
+	}
+}
+
+var (
+	ErrUnknownTicketType   = errors.New("ticket type is not sold for the event")      // ErrUnknownTicketType is returned when ordering a ticket type which does not belong to the event.
+	ErrTicketTypeNotOnSale = errors.New("ticket type is not on sale")                 // ErrTicketTypeNotOnSale is returned when ordering a ticket type outside of its sales window.
+	ErrMixedCurrencies     = errors.New("ordered ticket types must share a currency") // ErrMixedCurrencies is returned when ordering ticket types which are priced in different currencies.
+)
@@ -0,0 +1,139 @@
+package models_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestTicketTypeModel_IsOnSale(t *testing.T) {
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name       string
+		salesStart sql.NullTime
+		salesEnd   sql.NullTime
+		expected   bool
+	}{
+		{name: "no sales window", expected: true},
+		{name: "sales not yet started", salesStart: sql.NullTime{Time: now.Add(time.Hour), Valid: true}, expected: false},
+		{name: "sales started", salesStart: sql.NullTime{Time: now, Valid: true}, expected: true},
+		{name: "sales ended", salesEnd: sql.NullTime{Time: now, Valid: true}, expected: false},
+		{name: "within sales window", salesStart: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}, salesEnd: sql.NullTime{Time: now.Add(time.Hour), Valid: true}, expected: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ticketType := models.TicketTypeModel{SalesStart: testcase.salesStart, SalesEnd: testcase.salesEnd}
+			if ticketType.IsOnSale(now) != testcase.expected {
+				t.Errorf("expected IsOnSale to return %v", testcase.expected)
+			}
+		})
+	}
+}
+
+func TestNewOrder(t *testing.T) {
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	occurrence := &models.OccurrenceModel{EventID: "event", OccurrenceStart: now.AddDate(0, 1, 0)}
+	ticketTypes := []*models.TicketTypeModel{
+		{Model: models.Model{ID: "general"}, Name: "General", Price: 2500, Currency: "EUR"},
+		{Model: models.Model{ID: "vip"}, Name: "VIP", Price: 9900, Currency: "EUR"},
+		{Model: models.Model{ID: "dollars"}, Name: "Dollars", Price: 3000, Currency: "USD"},
+		{Model: models.Model{ID: "closed"}, Name: "Closed", Price: 1000, Currency: "EUR", SalesEnd: sql.NullTime{Time: now, Valid: true}},
+	}
+
+	t.Run("totals the items and holds the tickets", func(t *testing.T) {
+		order, err := models.NewOrder("user", occurrence, ticketTypes, dtos.CreateOrder{
+			Items: []dtos.OrderItem{{TicketTypeID: "general", Quantity: 2}, {TicketTypeID: "vip", Quantity: 1}},
+		}, now, 15*time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != types.PendingOrderStatus {
+			t.Errorf("expected order to be pending but was %s", order.Status)
+		}
+		if order.Total != 2*2500+9900 || order.Currency != "EUR" {
+			t.Errorf("expected total of 14900 EUR but was %d %s", order.Total, order.Currency)
+		}
+		if !order.ExpiresAt.Equal(now.Add(15 * time.Minute)) {
+			t.Errorf("expected order to expire after its hold but expires at %v", order.ExpiresAt)
+		}
+		if order.EventID != "event" || !order.OccurrenceStart.Equal(occurrence.OccurrenceStart) {
+			t.Errorf("expected order to be for the occurrence but was %s at %v", order.EventID, order.OccurrenceStart)
+		}
+		if len(order.Items) != 2 || order.Items[1].UnitPrice != 9900 || order.Items[1].Name != "VIP" {
+			t.Errorf("expected items to copy their ticket types but were %+v", order.Items)
+		}
+	})
+
+	testcases := []struct {
+		name     string
+		items    []dtos.OrderItem
+		expected error
+	}{
+		{name: "unknown ticket type", items: []dtos.OrderItem{{TicketTypeID: "missing", Quantity: 1}}, expected: models.ErrUnknownTicketType},
+		{name: "ticket type not on sale", items: []dtos.OrderItem{{TicketTypeID: "closed", Quantity: 1}}, expected: models.ErrTicketTypeNotOnSale},
+		{name: "mixed currencies", items: []dtos.OrderItem{{TicketTypeID: "general", Quantity: 1}, {TicketTypeID: "dollars", Quantity: 1}}, expected: models.ErrMixedCurrencies},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := models.NewOrder("user", occurrence, ticketTypes, dtos.CreateOrder{Items: testcase.items}, now, 15*time.Minute)
+			if !errors.Is(err, testcase.expected) {
+				t.Errorf("expected error %v but got %v", testcase.expected, err)
+			}
+		})
+	}
+}
+
+func TestOrderModel_Lifecycle(t *testing.T) {
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+
+	t.Run("pending orders expire", func(t *testing.T) {
+		order := models.OrderModel{Status: types.PendingOrderStatus, ExpiresAt: now}
+		if order.IsExpired(now.Add(-time.Second)) {
+			t.Error("expected order to not be expired before its expiry")
+		}
+		if !order.IsExpired(now) {
+			t.Error("expected order to be expired at its expiry")
+		}
+	})
+	t.Run("paid orders do not expire", func(t *testing.T) {
+		order := models.OrderModel{Status: types.PaidOrderStatus, ExpiresAt: now}
+		if order.IsExpired(now.Add(time.Hour)) {
+			t.Error("expected paid order to not expire")
+		}
+	})
+	t.Run("paying records the time", func(t *testing.T) {
+		order := models.OrderModel{Status: types.PendingOrderStatus}
+		order.ChangeStatus(types.PaidOrderStatus, now)
+		if order.Status != types.PaidOrderStatus || !order.PaidAt.Valid || !order.PaidAt.Time.Equal(now) {
+			t.Errorf("expected order to be paid at %v but was %s at %v", now, order.Status, order.PaidAt)
+		}
+	})
+	t.Run("status transitions", func(t *testing.T) {
+		allowed := map[types.OrderStatus][]types.OrderStatus{
+			types.PendingOrderStatus:   {types.PaidOrderStatus, types.CancelledOrderStatus},
+			types.PaidOrderStatus:      {types.RefundedOrderStatus},
+			types.CancelledOrderStatus: {},
+			types.RefundedOrderStatus:  {},
+		}
+		statuses := []types.OrderStatus{types.PendingOrderStatus, types.PaidOrderStatus, types.CancelledOrderStatus, types.RefundedOrderStatus}
+		for from, to := range allowed {
+			for _, next := range statuses {
+				expected := false
+				for _, status := range to {
+					expected = expected || status == next
+				}
+				if from.CanTransitionTo(next) != expected {
+					t.Errorf("expected transition from %s to %s to be allowed: %v", from, next, expected)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// OrganizerApplicationModel represents the application of a user to become an organizer, stored within the organizer_applications table.
+// Applications await review by an admin, approving an application changes the role of the applicant to organizer.
+type OrganizerApplicationModel struct {
+	Model
+	UserID       string                           `db:"user_id" json:"user_id"`
+	Reason       string                           `db:"reason" json:"reason"`
+	Links        []string                         `db:"links" json:"links"`
+	ContactEmail string                           `db:"contact_email" json:"contact_email"`
+	ContactPhone sql.NullString                   `db:"contact_phone" json:"contact_phone"`
+	Status       types.OrganizerApplicationStatus `db:"status" json:"status"`
+	// ReviewedBy, ReviewComment and ReviewedAt describe the decision of the admin who reviewed the application.
+	ReviewedBy    sql.NullString `db:"reviewed_by" json:"reviewed_by"`
+	ReviewComment sql.NullString `db:"review_comment" json:"review_comment"`
+	ReviewedAt    sql.NullTime   `db:"reviewed_at" json:"reviewed_at"`
+	// Username describes the applicant when listed for review, it is not stored.
+	Username string `json:"username,omitempty"`
+}
+
+// NewOrganizerApplication creates the pending application of the user from the payload.
+func NewOrganizerApplication(userId string, payload dtos.ApplyForOrganizer) *OrganizerApplicationModel {
+	application := &OrganizerApplicationModel{
+		UserID:       userId,
+		Reason:       strings.TrimSpace(payload.Reason),
+		Links:        payload.Links,
+		ContactEmail: payload.ContactEmail,
+		Status:       types.PendingApplicationStatus,
+	}
+	if application.Links == nil {
+		application.Links = []string{}
+	}
+	if len(payload.ContactPhone) > 0 {
+		application.ContactPhone = sql.NullString{String: payload.ContactPhone, Valid: true}
+	}
+	return application
+}
+
+// IsApproved returns true if an admin approved the application.
+func (m *OrganizerApplicationModel) IsApproved() bool {
+	return m.Status == types.ApprovedApplicationStatus
+}
+
+// Review records the decision of the admin on the application.
+func (m *OrganizerApplicationModel) Review(reviewerId string, payload dtos.ReviewOrganizerApplication, now time.Time) {
+	m.Status = payload.Status
+	m.ReviewedBy = sql.NullString{String: reviewerId, Valid: true}
+	m.ReviewComment = sql.NullString{String: payload.Comment, Valid: len(payload.Comment) > 0}
+	m.ReviewedAt = sql.NullTime{Time: now, Valid: true}
+	m.UpdatedAt = now
+}
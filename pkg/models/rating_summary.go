@@ -0,0 +1,29 @@
+package models
+
+import "math"
+
+// RatingSummary represents the aggregated star ratings of one or more events.
+type RatingSummary struct {
+	Count     int         `json:"count"`
+	Average   float64     `json:"average"`
+	Histogram map[int]int `json:"histogram"` // Histogram the number of ratings given for each number of stars, from 1 to 5.
+}
+
+// NewRatingSummary creates a summary from the number of ratings given for each number of stars, from one to five.
+func NewRatingSummary(oneStar, twoStar, threeStar, fourStar, fiveStar int) *RatingSummary {
+	summary := &RatingSummary{
+		Histogram: map[int]int{1: oneStar, 2: twoStar, 3: threeStar, 4: fourStar, 5: fiveStar},
+	}
+
+	total := 0
+	for stars, count := range summary.Histogram {
+		summary.Count += count
+		total += stars * count
+	}
+	if summary.Count > 0 {
+		// round the average to two decimal places.
+		summary.Average = math.Round(float64(total)/float64(summary.Count)*100) / 100
+	}
+
+	return summary
+}
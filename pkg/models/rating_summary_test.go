@@ -0,0 +1,39 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+func TestRatingSummary_NewRatingSummary(t *testing.T) {
+	t.Run("no ratings", func(t *testing.T) {
+		summary := models.NewRatingSummary(0, 0, 0, 0, 0)
+		if summary.Count != 0 {
+			t.Errorf("expected count to be 0 but was %d", summary.Count)
+		}
+		if summary.Average != 0 {
+			t.Errorf("expected average to be 0 but was %v", summary.Average)
+		}
+	})
+
+	t.Run("mixed ratings", func(t *testing.T) {
+		summary := models.NewRatingSummary(1, 0, 1, 0, 1)
+		if summary.Count != 3 {
+			t.Errorf("expected count to be 3 but was %d", summary.Count)
+		}
+		if summary.Average != 3 {
+			t.Errorf("expected average to be 3 but was %v", summary.Average)
+		}
+		if summary.Histogram[5] != 1 {
+			t.Errorf("expected 1 five star rating but was %d", summary.Histogram[5])
+		}
+	})
+
+	t.Run("average is rounded", func(t *testing.T) {
+		summary := models.NewRatingSummary(0, 0, 0, 1, 2)
+		if summary.Average != 4.67 {
+			t.Errorf("expected average to be 4.67 but was %v", summary.Average)
+		}
+	})
+}
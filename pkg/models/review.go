@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+// ReviewModel represents the review data stored in the database.
+type ReviewModel struct {
+	Model
+	Title    string        `db:"title" json:"title"`
+	Body     string        `db:"body" json:"body"`
+	Rating   sql.NullInt16 `db:"rating" json:"rating"`
+	EventID  string        `db:"event_id" json:"event_id"`
+	AuthorID string        `db:"author_id" json:"author_id"`
+}
+
+// BeforeUpdate overrides model lifecycle hook, updating the updated_at time.
+func (m *ReviewModel) BeforeUpdate() error {
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsAuthoredBy returns true if the user with the provided id wrote the review.
+func (m *ReviewModel) IsAuthoredBy(userId string) bool {
+	return m.AuthorID == userId
+}
+
+func (m *ReviewModel) UpdateFrom(payload dtos.CreateOrUpdateReview) {
+	if len(payload.Title) > 0 {
+		m.Title = payload.Title
+	}
+	if len(payload.Body) > 0 {
+		m.Body = payload.Body
+	}
+	if payload.Rating != nil {
+		m.Rating = sql.NullInt16{
+			Int16: int16(*payload.Rating),
+			Valid: true,
+		}
+	}
+}
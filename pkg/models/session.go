@@ -0,0 +1,29 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SessionModel represents a user signed in on a device, stored within the user_sessions table.
+// The session is kept alive by refreshing it with its latest refresh token until it expires or is revoked.
+type SessionModel struct {
+	ID         string       `db:"id" json:"id"`
+	UserID     string       `db:"user_id" json:"user_id"`
+	UserAgent  string       `db:"user_agent" json:"user_agent"`
+	IPAddress  string       `db:"ip_address" json:"ip_address"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+	LastUsedAt time.Time    `db:"last_used_at" json:"last_used_at"`
+	ExpiresAt  time.Time    `db:"expires_at" json:"expires_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at" json:"-"`
+}
+
+// NewSession creates the session of the user signing in from the device, which expires after the lifetime unless it is refreshed.
+func NewSession(userId string, userAgent string, ipAddress string, lifetime time.Duration) *SessionModel {
+	return &SessionModel{
+		UserID:    userId,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+		ExpiresAt: time.Now().Add(lifetime),
+	}
+}
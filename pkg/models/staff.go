@@ -0,0 +1,28 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// EventStaffModel represents a user helping to run an event in a staff role, stored within the event_staff table.
+// Staff are invited by the events organizer, and their role only takes effect once they accept the invitation.
+type EventStaffModel struct {
+	EventID    string          `db:"event_id" json:"event_id"`
+	UserID     string          `db:"user_id" json:"user_id"`
+	Role       types.StaffRole `db:"role" json:"role"`
+	InvitedBy  sql.NullString  `db:"invited_by" json:"invited_by"`
+	AcceptedAt sql.NullTime    `db:"accepted_at" json:"accepted_at"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time       `db:"updated_at" json:"updated_at"`
+	// Username and EventName describe the staff member and their event when listed, they are not stored.
+	Username  string `json:"username,omitempty"`
+	EventName string `json:"event_name,omitempty"`
+}
+
+// IsActive returns true if the staff member accepted their invitation.
+func (m *EventStaffModel) IsActive() bool {
+	return m.AcceptedAt.Valid
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+// TagModel represents a free-form tag which organizers attach to events, stored within the tags table.
+type TagModel struct {
+	ID     string `db:"id" json:"id"`
+	Name   string `db:"name" json:"name"`
+	Events int    `json:"events"` // number of events the tag is attached to.
+}
+
+// NewTag creates a tag from the provided payload.
+func NewTag(payload dtos.CreateTag) *TagModel {
+	return &TagModel{Name: strings.TrimSpace(payload.Name)}
+}
+
+// CategoryModel represents an admin managed category which events can be placed within, stored within the categories table.
+type CategoryModel struct {
+	ID   string `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
+
+func (m *CategoryModel) UpdateFrom(payload dtos.CreateOrUpdateCategory) {
+	if name := strings.TrimSpace(payload.Name); len(name) > 0 {
+		m.Name = name
+	}
+}
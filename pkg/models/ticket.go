@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+// TicketTypeModel represents a kind of ticket which can be ordered for a paid event, stored within the ticket_types table.
+type TicketTypeModel struct {
+	Model
+	EventID     string         `db:"event_id" json:"event_id"`
+	Name        string         `db:"name" json:"name"`
+	Description sql.NullString `db:"description" json:"description"`
+	Price       int64          `db:"price" json:"price"`       // Price in the minor unit of the currency, such as cents.
+	Currency    string         `db:"currency" json:"currency"` // Currency the ISO 4217 code of the currency, such as "EUR".
+	Quantity    int            `db:"quantity" json:"quantity"` // Quantity the number of tickets available for each occurrence of the event.
+	SalesStart  sql.NullTime   `db:"sales_start" json:"sales_start"`
+	SalesEnd    sql.NullTime   `db:"sales_end" json:"sales_end"`
+	// Available the number of tickets which are neither paid for nor held by a pending order, only known when listing the ticket types of an occurrence.
+	Available *int `json:"available,omitempty"`
+}
+
+// NewTicketType creates a ticket type of the event from the provided payload.
+func NewTicketType(eventId string, payload dtos.CreateOrUpdateTicketType) *TicketTypeModel {
+	ticketType := &TicketTypeModel{EventID: eventId}
+	ticketType.UpdateFrom(payload)
+	return ticketType
+}
+
+// BeforeUpdate overrides model lifecycle hook, updating the updated_at time.
+func (m *TicketTypeModel) BeforeUpdate() error {
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsOnSale returns true if the ticket type can be ordered at the provided time.
+func (m *TicketTypeModel) IsOnSale(now time.Time) bool {
+	if m.SalesStart.Valid && now.Before(m.SalesStart.Time) {
+		return false
+	}
+	return !m.SalesEnd.Valid || now.Before(m.SalesEnd.Time)
+}
+
+func (m *TicketTypeModel) UpdateFrom(payload dtos.CreateOrUpdateTicketType) {
+	if name := strings.TrimSpace(payload.Name); len(name) > 0 {
+		m.Name = name
+	}
+	if len(payload.Description) > 0 {
+		m.Description = sql.NullString{
+			String: payload.Description,
+			Valid:  true,
+		}
+	}
+	if payload.Price != nil {
+		m.Price = *payload.Price
+	}
+	if len(payload.Currency) > 0 {
+		m.Currency = strings.ToUpper(payload.Currency)
+	}
+	if payload.Quantity != nil {
+		m.Quantity = *payload.Quantity
+	}
+	if payload.SalesStart != nil {
+		m.SalesStart = sql.NullTime{Time: *payload.SalesStart, Valid: true}
+	}
+	if payload.SalesEnd != nil {
+		m.SalesEnd = sql.NullTime{Time: *payload.SalesEnd, Valid: true}
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// UserTokenModel represents a single-use token sent to a user, stored within the user_tokens table.
+// The token itself is only known to the user, the table stores its hash.
+type UserTokenModel struct {
+	ID        string                 `db:"id" json:"id"`
+	UserID    string                 `db:"user_id" json:"user_id"`
+	Purpose   types.UserTokenPurpose `db:"purpose" json:"purpose"`
+	TokenHash string                 `db:"token_hash" json:"-"`
+	ExpiresAt time.Time              `db:"expires_at" json:"expires_at"`
+	UsedAt    sql.NullTime           `db:"used_at" json:"used_at"`
+	CreatedAt time.Time              `db:"created_at" json:"created_at"`
+}
+
+// NewUserToken creates the token of the user for the purpose, which expires after the lifetime.
+func NewUserToken(userId string, purpose types.UserTokenPurpose, token string, lifetime time.Duration) *UserTokenModel {
+	return &UserTokenModel{
+		UserID:    userId,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(lifetime),
+	}
+}
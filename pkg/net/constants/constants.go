@@ -16,6 +16,9 @@ type errorCodes struct {
 	NotFound                 string
 	AuthNoRefreshTokenCookie string
 	AuthInvalidRefreshToken  string
+	PaymentFailed            string
+	AuthUnverifiedAccount    string
+	TooManyRequests          string
 }
 
 var (
@@ -29,5 +32,8 @@ var (
 		AuthInvalidAuthToken:     "AUTH_INVALID_TOKEN",
 		AuthNoRefreshTokenCookie: "NO_REFRESH_TOKEN_COOKIE",
 		AuthInvalidRefreshToken:  "AUTH_INVALID_REFRESH_TOKEN",
+		PaymentFailed:            "PAYMENT_FAILED",
+		AuthUnverifiedAccount:    "AUTH_UNVERIFIED_ACCOUNT",
+		TooManyRequests:          "TOO_MANY_REQUESTS",
 	}
 )
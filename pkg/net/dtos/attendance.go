@@ -0,0 +1,9 @@
+package dtos
+
+import "github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+
+// Attendance the outcome of a request to attend an event.
+type Attendance struct {
+	Status     types.AttendanceStatus `json:"status"`
+	Occurrence string                 `json:"occurrence"` // Occurrence the id of the attended occurrence.
+}
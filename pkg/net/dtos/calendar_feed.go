@@ -0,0 +1,6 @@
+package dtos
+
+// CalendarFeed the secret url of a users calendar subscription feed, only returned when the feed is created.
+type CalendarFeed struct {
+	URL string `json:"url"`
+}
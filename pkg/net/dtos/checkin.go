@@ -0,0 +1,78 @@
+package dtos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// CheckInToken the signed token admitting an attendee to an occurrence of an event, presented at the door as a QR code.
+type CheckInToken struct {
+	Token      string    `json:"token"`
+	Code       string    `json:"code"`       // Code the shorter check-in code of the attendee, which door staff can validate while offline.
+	Occurrence string    `json:"occurrence"` // Occurrence the id of the attended occurrence.
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type CheckIn struct {
+	DTO
+	Token string `json:"token"`
+}
+
+// Validate implements validatable returns any validation errors.
+func (dto *CheckIn) Validate() (errs []string) {
+	if len(dto.Token) < 1 {
+		errs = append(errs, "token is required")
+	}
+	return errs
+}
+
+// MaxCheckInScans the maximum number of scans which can be synchronized at once.
+const MaxCheckInScans = 500
+
+// CheckInScan a check-in code scanned by a device while offline.
+type CheckInScan struct {
+	Code      string     `json:"code"`
+	ScannedAt *time.Time `json:"scanned_at"` // ScannedAt the time the code was scanned, according to the device.
+	DeviceID  string     `json:"device_id"`
+}
+
+type SyncCheckIns struct {
+	DTO
+	Scans []CheckInScan `json:"scans"`
+}
+
+// Validate implements validatable returns any validation errors.
+func (dto *SyncCheckIns) Validate() (errs []string) {
+	if len(dto.Scans) < 1 {
+		errs = append(errs, "scans are required")
+	}
+	if len(dto.Scans) > MaxCheckInScans {
+		errs = append(errs, fmt.Sprintf("at most %d scans can be synchronized at once", MaxCheckInScans))
+	}
+	for i, scan := range dto.Scans {
+		if len(scan.Code) < 1 {
+			errs = append(errs, fmt.Sprintf("scans[%d].code is required", i))
+		}
+		if scan.ScannedAt == nil {
+			errs = append(errs, fmt.Sprintf("scans[%d].scanned_at is required", i))
+		}
+		if !utils.StringLengthInBounds(scan.DeviceID, 1, 100) {
+			errs = append(errs, fmt.Sprintf("scans[%d].device_id must contain between 1 and 100 characters", i))
+		}
+	}
+	return errs
+}
+
+// CheckInSyncResult the outcome of applying a scan, in the order the scans were provided.
+type CheckInSyncResult struct {
+	Code            string                  `json:"code"`
+	Status          types.CheckInSyncStatus `json:"status"`
+	UserID          string                  `json:"user_id,omitempty"`
+	Occurrence      string                  `json:"occurrence,omitempty"` // Occurrence the id of the occurrence the code admits to.
+	CheckedInAt     *time.Time              `json:"checked_in_at,omitempty"`
+	CheckedInDevice string                  `json:"checked_in_device,omitempty"`
+	Message         string                  `json:"message,omitempty"` // Message describes why the scan was not applied.
+}
@@ -0,0 +1,54 @@
+package dtos_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+func TestSyncCheckIns_Validation(t *testing.T) {
+	scannedAt := time.Date(2024, time.June, 1, 18, 45, 0, 0, time.UTC)
+	scan := dtos.CheckInScan{Code: "code", ScannedAt: &scannedAt, DeviceID: "door-1"}
+
+	testcases := []struct {
+		name string
+		dtos.SyncCheckIns
+		expectedErrs int
+	}{
+		{
+			name:         "empty sync dto",
+			SyncCheckIns: dtos.SyncCheckIns{},
+			expectedErrs: 1,
+		},
+		{
+			name:         "valid sync dto",
+			SyncCheckIns: dtos.SyncCheckIns{Scans: []dtos.CheckInScan{scan, scan}},
+			expectedErrs: 0,
+		},
+		{
+			name:         "scan missing all fields",
+			SyncCheckIns: dtos.SyncCheckIns{Scans: []dtos.CheckInScan{{}}},
+			expectedErrs: 3,
+		},
+		{
+			name:         "device id too long",
+			SyncCheckIns: dtos.SyncCheckIns{Scans: []dtos.CheckInScan{{Code: "code", ScannedAt: &scannedAt, DeviceID: strings.Repeat("a", 101)}}},
+			expectedErrs: 1,
+		},
+		{
+			name:         "too many scans",
+			SyncCheckIns: dtos.SyncCheckIns{Scans: make([]dtos.CheckInScan, dtos.MaxCheckInScans+1)},
+			expectedErrs: 1 + 3*(dtos.MaxCheckInScans+1),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := tc.SyncCheckIns.Validate(); len(errs) != tc.expectedErrs {
+				t.Errorf("expected %d errors but got %d: %v", tc.expectedErrs, len(errs), errs)
+			}
+		})
+	}
+}
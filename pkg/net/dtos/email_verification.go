@@ -0,0 +1,15 @@
+package dtos
+
+// VerifyEmail the token within the verification link sent to a user.
+type VerifyEmail struct {
+	DTO
+	Token string `json:"token"`
+}
+
+// Validate implements validatable returns any validation errors
+func (v *VerifyEmail) Validate() (errs []string) {
+	if len(v.Token) < 1 {
+		errs = append(errs, "token is required")
+	}
+	return errs
+}
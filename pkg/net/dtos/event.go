@@ -0,0 +1,121 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/rrule"
+)
+
+type CreateOrUpdateEvent struct {
+	DTO
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	StartDate   *time.Time      `json:"start_date"`
+	EndDate     *time.Time      `json:"end_date"`
+	IsPaid      *bool           `json:"is_paid,omitempty"`
+	EventType   types.EventType `json:"event_type"`
+	Capacity    *int            `json:"capacity,omitempty"`
+	Country     string          `json:"country"`
+	City        string          `json:"city"`
+	// TimeZone the IANA time zone the event takes place in, such as "Europe/Berlin".
+	TimeZone string `json:"time_zone"`
+	// RecurrenceRule repeats the event, such as "FREQ=WEEKLY;BYDAY=MO", an empty rule removes the recurrence.
+	RecurrenceRule *string `json:"recurrence_rule,omitempty"`
+}
+
+// Validate implements validatable returns any validation errors.
+// All required fields must be present, use ValidatePartial when validating updates.
+func (dto *CreateOrUpdateEvent) Validate() (errs []string) {
+	if len(dto.Name) < 1 {
+		errs = append(errs, "name is required")
+	}
+	if dto.StartDate == nil {
+		errs = append(errs, "start_date is required")
+	}
+	if dto.EndDate == nil {
+		errs = append(errs, "end_date is required")
+	}
+	return append(errs, dto.ValidatePartial()...)
+}
+
+// ValidatePartial returns any validation errors for the fields that are present in the dto.
+func (dto *CreateOrUpdateEvent) ValidatePartial() (errs []string) {
+	if len(dto.Name) > 0 && !utils.StringLengthInBounds(dto.Name, 1, 500) {
+		errs = append(errs, "name must contain between 1 and 500 characters")
+	}
+	if len(dto.Description) > 1000 {
+		errs = append(errs, "description must contain at most 1000 characters")
+	}
+	if dto.StartDate != nil && dto.EndDate != nil && dto.EndDate.Before(*dto.StartDate) {
+		errs = append(errs, "end_date must not be before start_date")
+	}
+	if len(dto.EventType) > 0 && !dto.EventType.IsValid() {
+		errs = append(errs, "event_type must be one of 'offline', 'online' or 'both'")
+	}
+	if dto.Capacity != nil && *dto.Capacity < 0 {
+		errs = append(errs, "capacity must not be negative, use 0 for no limit")
+	}
+	if len(dto.Country) > 5 {
+		errs = append(errs, "country must contain at most 5 characters")
+	}
+	if len(dto.City) > 50 {
+		errs = append(errs, "city must contain at most 50 characters")
+	}
+	if len(dto.TimeZone) > 0 && (len(dto.TimeZone) > 64 || !utils.IsTimeZone(dto.TimeZone)) {
+		errs = append(errs, "time_zone must be an IANA time zone such as 'Europe/Berlin'")
+	}
+	if dto.RecurrenceRule != nil && len(*dto.RecurrenceRule) > 0 {
+		if len(*dto.RecurrenceRule) > 255 {
+			errs = append(errs, "recurrence_rule must contain at most 255 characters")
+		} else if _, err := rrule.Parse(*dto.RecurrenceRule); err != nil {
+			errs = append(errs, "recurrence_rule is invalid, "+err.Error())
+		}
+	}
+	return errs
+}
+
+// ChangeEventStatus represents the payload accepted when moving an event through its lifecycle.
+type ChangeEventStatus struct {
+	DTO
+	Status             types.EventStatus `json:"status"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *ChangeEventStatus) Validate() (errs []string) {
+	if !dto.Status.IsValid() {
+		errs = append(errs, "status must be one of 'draft', 'published', 'cancelled' or 'completed'")
+	}
+	if dto.Status == types.CancelledEventStatus {
+		if !utils.StringLengthInBounds(dto.CancellationReason, 1, 500) {
+			errs = append(errs, "cancellation_reason must contain between 1 and 500 characters")
+		}
+	} else if len(dto.CancellationReason) > 0 {
+		errs = append(errs, "cancellation_reason can only be provided when cancelling an event")
+	}
+	return errs
+}
+
+// UpdateOccurrence represents the payload accepted when changing a single occurrence of a recurring event.
+type UpdateOccurrence struct {
+	DTO
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Cancelled *bool      `json:"cancelled,omitempty"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *UpdateOccurrence) Validate() (errs []string) {
+	if dto.StartDate == nil && dto.EndDate == nil && dto.Cancelled == nil {
+		errs = append(errs, "at least one of start_date, end_date or cancelled is required")
+	}
+	if (dto.StartDate == nil) != (dto.EndDate == nil) {
+		errs = append(errs, "start_date and end_date must be provided together")
+	}
+	if dto.StartDate != nil && dto.EndDate != nil && !dto.EndDate.After(*dto.StartDate) {
+		errs = append(errs, "end_date must be after start_date")
+	}
+	return errs
+}
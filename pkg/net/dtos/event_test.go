@@ -0,0 +1,224 @@
+package dtos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+type eventDtoTestCase struct {
+	name string
+	dtos.CreateOrUpdateEvent
+	expectedErrs        int
+	expectedPartialErrs int
+}
+
+func TestCreateOrUpdateEvent_Validation(t *testing.T) {
+	start := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 24)
+	negativeCapacity := -1
+	weekly := "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+	yearly := "FREQ=YEARLY"
+	noRecurrence := ""
+
+	testcases := []eventDtoTestCase{
+		{
+			name:                "empty event dto",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{},
+			expectedErrs:        3,
+			expectedPartialErrs: 0,
+		},
+		{
+			name: "valid event dto",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Name:      "Tomorrowland 2024",
+				StartDate: &start,
+				EndDate:   &end,
+				EventType: "offline",
+				Country:   "BE",
+				City:      "Boom",
+			},
+			expectedErrs:        0,
+			expectedPartialErrs: 0,
+		},
+		{
+			name: "end date before start date",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Name:      "Tomorrowland 2024",
+				StartDate: &end,
+				EndDate:   &start,
+			},
+			expectedErrs:        1,
+			expectedPartialErrs: 1,
+		},
+		{
+			name: "invalid event type",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Name:      "Tomorrowland 2024",
+				StartDate: &start,
+				EndDate:   &end,
+				EventType: "hybrid",
+			},
+			expectedErrs:        1,
+			expectedPartialErrs: 1,
+		},
+		{
+			name: "negative capacity",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Name:      "Tomorrowland 2024",
+				StartDate: &start,
+				EndDate:   &end,
+				Capacity:  &negativeCapacity,
+			},
+			expectedErrs:        1,
+			expectedPartialErrs: 1,
+		},
+		{
+			name: "recurrence rule",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Name:           "Weekly meetup",
+				StartDate:      &start,
+				EndDate:        &end,
+				RecurrenceRule: &weekly,
+			},
+			expectedErrs:        0,
+			expectedPartialErrs: 0,
+		},
+		{
+			name: "removing recurrence rule",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				RecurrenceRule: &noRecurrence,
+			},
+			expectedErrs:        3,
+			expectedPartialErrs: 0,
+		},
+		{
+			name: "unsupported recurrence rule",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Name:           "Yearly meetup",
+				StartDate:      &start,
+				EndDate:        &end,
+				RecurrenceRule: &yearly,
+			},
+			expectedErrs:        1,
+			expectedPartialErrs: 1,
+		},
+		{
+			name: "country code too long",
+			CreateOrUpdateEvent: dtos.CreateOrUpdateEvent{
+				Country: "Belgium",
+			},
+			expectedErrs:        4,
+			expectedPartialErrs: 1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+			if errs := testcase.ValidatePartial(); len(errs) != testcase.expectedPartialErrs {
+				t.Errorf("expected %v partial errors but got %v", testcase.expectedPartialErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestChangeEventStatus_Validation(t *testing.T) {
+	testcases := []struct {
+		name string
+		dtos.ChangeEventStatus
+		expectedErrs int
+	}{
+		{
+			name:              "empty status dto",
+			ChangeEventStatus: dtos.ChangeEventStatus{},
+			expectedErrs:      1,
+		},
+		{
+			name:              "publish",
+			ChangeEventStatus: dtos.ChangeEventStatus{Status: "published"},
+			expectedErrs:      0,
+		},
+		{
+			name:              "cancel with reason",
+			ChangeEventStatus: dtos.ChangeEventStatus{Status: "cancelled", CancellationReason: "Venue unavailable"},
+			expectedErrs:      0,
+		},
+		{
+			name:              "cancel without reason",
+			ChangeEventStatus: dtos.ChangeEventStatus{Status: "cancelled"},
+			expectedErrs:      1,
+		},
+		{
+			name:              "reason without cancelling",
+			ChangeEventStatus: dtos.ChangeEventStatus{Status: "published", CancellationReason: "Venue unavailable"},
+			expectedErrs:      1,
+		},
+		{
+			name:              "unknown status",
+			ChangeEventStatus: dtos.ChangeEventStatus{Status: "archived"},
+			expectedErrs:      1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.ChangeEventStatus.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestUpdateOccurrence_Validation(t *testing.T) {
+	start := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	cancelled := true
+
+	testcases := []struct {
+		name string
+		dtos.UpdateOccurrence
+		expectedErrs int
+	}{
+		{
+			name:             "empty occurrence dto",
+			UpdateOccurrence: dtos.UpdateOccurrence{},
+			expectedErrs:     1,
+		},
+		{
+			name:             "cancel",
+			UpdateOccurrence: dtos.UpdateOccurrence{Cancelled: &cancelled},
+			expectedErrs:     0,
+		},
+		{
+			name:             "reschedule",
+			UpdateOccurrence: dtos.UpdateOccurrence{StartDate: &start, EndDate: &end},
+			expectedErrs:     0,
+		},
+		{
+			name:             "start date only",
+			UpdateOccurrence: dtos.UpdateOccurrence{StartDate: &start},
+			expectedErrs:     1,
+		},
+		{
+			name:             "end date before start date",
+			UpdateOccurrence: dtos.UpdateOccurrence{StartDate: &end, EndDate: &start},
+			expectedErrs:     1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.UpdateOccurrence.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package dtos
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// ListEvents represents the query parameters accepted when listing events.
+type ListEvents struct {
+	DTO
+	From      *time.Time
+	To        *time.Time
+	EventType types.EventType
+	IsPaid    *bool
+	Country   string
+	City      string
+	Tag       string
+	Category  string
+	Status    types.EventStatus
+	Sort      types.EventSort
+	Order     types.SortOrder
+	Cursor    string
+	Limit     int
+}
+
+// ReadQuery populates the dto from url query parameters, returning any errors for values that could not be parsed.
+func (dto *ListEvents) ReadQuery(query url.Values) (errs []string) {
+	// times without an offset are interpreted within the callers time zone, which defaults to UTC.
+	location := time.UTC
+	if tz := query.Get("tz"); len(tz) > 0 {
+		l, err := utils.LoadTimeZone(tz)
+		if err != nil {
+			errs = append(errs, "tz must be an IANA time zone such as 'Europe/Berlin'")
+		} else {
+			location = l
+		}
+	}
+	if from := query.Get("from"); len(from) > 0 {
+		t, err := parseQueryTime(from, location, false)
+		if err != nil {
+			errs = append(errs, "from must be a RFC 3339 date time, or a local date time or date such as 2024-06-08")
+		} else {
+			dto.From = &t
+		}
+	}
+	if to := query.Get("to"); len(to) > 0 {
+		t, err := parseQueryTime(to, location, true)
+		if err != nil {
+			errs = append(errs, "to must be a RFC 3339 date time, or a local date time or date such as 2024-06-09")
+		} else {
+			dto.To = &t
+		}
+	}
+	if isPaid := query.Get("is_paid"); len(isPaid) > 0 {
+		b, err := strconv.ParseBool(isPaid)
+		if err != nil {
+			errs = append(errs, "is_paid must be either true or false")
+		} else {
+			dto.IsPaid = &b
+		}
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			errs = append(errs, "limit must be a number")
+		} else {
+			dto.Limit = n
+		}
+	}
+	dto.EventType = types.EventType(query.Get("event_type"))
+	dto.Country = query.Get("country")
+	dto.City = query.Get("city")
+	dto.Tag = query.Get("tag")
+	dto.Category = query.Get("category")
+	dto.Status = types.EventStatus(query.Get("status"))
+	dto.Sort = types.EventSort(query.Get("sort"))
+	dto.Order = types.SortOrder(query.Get("order"))
+	dto.Cursor = query.Get("cursor")
+	return errs
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *ListEvents) Validate() (errs []string) {
+	if dto.From != nil && dto.To != nil && dto.To.Before(*dto.From) {
+		errs = append(errs, "to must not be before from")
+	}
+	if len(dto.EventType) > 0 && !dto.EventType.IsValid() {
+		errs = append(errs, "event_type must be one of 'offline', 'online' or 'both'")
+	}
+	if len(dto.Status) > 0 && !dto.Status.IsValid() {
+		errs = append(errs, "status must be one of 'draft', 'published', 'cancelled' or 'completed'")
+	}
+	if len(dto.Sort) > 0 && !dto.Sort.IsValid() {
+		errs = append(errs, "sort must be one of 'start_date', 'likes' or 'attendees'")
+	}
+	if len(dto.Order) > 0 && !dto.Order.IsValid() {
+		errs = append(errs, "order must be either 'asc' or 'desc'")
+	}
+	if dto.Limit < 0 || dto.Limit > 100 {
+		errs = append(errs, "limit must be between 1 and 100")
+	}
+	return errs
+}
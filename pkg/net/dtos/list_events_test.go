@@ -0,0 +1,89 @@
+package dtos_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+func TestListEvents_ReadQueryAndValidate(t *testing.T) {
+	testcases := []struct {
+		name              string
+		query             string
+		expectedParseErrs int
+		expectedErrs      int
+	}{
+		{
+			name:              "empty query",
+			query:             "",
+			expectedParseErrs: 0,
+			expectedErrs:      0,
+		},
+		{
+			name:              "valid query",
+			query:             "from=2024-06-01T00:00:00Z&to=2024-06-30T00:00:00Z&event_type=offline&is_paid=false&country=BE&tag=music&status=completed&sort=likes&order=desc&limit=10",
+			expectedParseErrs: 0,
+			expectedErrs:      0,
+		},
+		{
+			name:              "local dates in caller time zone",
+			query:             "from=2024-06-08&to=2024-06-09T18:00&tz=Europe/Berlin",
+			expectedParseErrs: 0,
+			expectedErrs:      0,
+		},
+		{
+			name:              "unknown time zone",
+			query:             "from=2024-06-08&tz=Mars/Olympus_Mons",
+			expectedParseErrs: 1,
+			expectedErrs:      0,
+		},
+		{
+			name:              "malformed values",
+			query:             "from=yesterday&is_paid=maybe&limit=ten",
+			expectedParseErrs: 3,
+			expectedErrs:      0,
+		},
+		{
+			name:              "invalid values",
+			query:             "from=2024-06-30T00:00:00Z&to=2024-06-01T00:00:00Z&event_type=hybrid&status=archived&sort=name&order=up&limit=1000",
+			expectedParseErrs: 0,
+			expectedErrs:      6,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			values, err := url.ParseQuery(testcase.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dto := dtos.ListEvents{}
+			if errs := dto.ReadQuery(values); len(errs) != testcase.expectedParseErrs {
+				t.Errorf("expected %v parse errors but got %v", testcase.expectedParseErrs, len(errs))
+				t.Log(errs)
+			}
+			if errs := dto.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestListEvents_ReadQueryTimeZone(t *testing.T) {
+	values, _ := url.ParseQuery("from=2024-06-08&to=2024-06-09&tz=Europe/Berlin")
+	dto := dtos.ListEvents{}
+	if errs := dto.ReadQuery(values); len(errs) > 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+
+	// berlin is two hours ahead of UTC in june.
+	if expected := time.Date(2024, time.June, 7, 22, 0, 0, 0, time.UTC); !dto.From.Equal(expected) {
+		t.Errorf("expected from to be %v but was %v", expected, dto.From.UTC())
+	}
+	if expected := time.Date(2024, time.June, 9, 21, 59, 59, 999999000, time.UTC); !dto.To.Equal(expected) {
+		t.Errorf("expected to to be the end of the day %v but was %v", expected, dto.To.UTC())
+	}
+}
@@ -0,0 +1,79 @@
+package dtos
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+const (
+	MaxOccurrenceWindow     = 366 * 24 * time.Hour // MaxOccurrenceWindow the longest period which occurrences of an event can be listed for at once.
+	DefaultOccurrenceWindow = 90 * 24 * time.Hour  // DefaultOccurrenceWindow the period listed when to is not provided.
+)
+
+// ListOccurrences represents the query parameters accepted when listing the occurrences of an event.
+type ListOccurrences struct {
+	DTO
+	From *time.Time
+	To   *time.Time
+}
+
+// ReadQuery populates the dto from url query parameters, returning any errors for values that could not be parsed.
+func (dto *ListOccurrences) ReadQuery(query url.Values) (errs []string) {
+	// times without an offset are interpreted within the callers time zone, which defaults to UTC.
+	location := time.UTC
+	if tz := query.Get("tz"); len(tz) > 0 {
+		l, err := utils.LoadTimeZone(tz)
+		if err != nil {
+			errs = append(errs, "tz must be an IANA time zone such as 'Europe/Berlin'")
+		} else {
+			location = l
+		}
+	}
+	if from := query.Get("from"); len(from) > 0 {
+		t, err := parseQueryTime(from, location, false)
+		if err != nil {
+			errs = append(errs, "from must be a RFC 3339 date time, or a local date time or date such as 2024-06-08")
+		} else {
+			dto.From = &t
+		}
+	}
+	if to := query.Get("to"); len(to) > 0 {
+		t, err := parseQueryTime(to, location, true)
+		if err != nil {
+			errs = append(errs, "to must be a RFC 3339 date time, or a local date time or date such as 2024-06-09")
+		} else {
+			dto.To = &t
+		}
+	}
+	return errs
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *ListOccurrences) Validate() (errs []string) {
+	if dto.From != nil && dto.To != nil {
+		if dto.To.Before(*dto.From) {
+			errs = append(errs, "to must not be before from")
+		} else if dto.To.Sub(*dto.From) > MaxOccurrenceWindow {
+			errs = append(errs, "from and to must be at most 366 days apart")
+		}
+	}
+	return errs
+}
+
+// Window returns the period to list occurrences for, starting now and lasting DefaultOccurrenceWindow unless provided.
+// Periods longer than MaxOccurrenceWindow are shortened.
+func (dto *ListOccurrences) Window(now time.Time) (time.Time, time.Time) {
+	from, to := now, now.Add(DefaultOccurrenceWindow)
+	if dto.From != nil {
+		from, to = *dto.From, dto.From.Add(DefaultOccurrenceWindow)
+	}
+	if dto.To != nil {
+		to = *dto.To
+	}
+	if to.Sub(from) > MaxOccurrenceWindow {
+		to = from.Add(MaxOccurrenceWindow)
+	}
+	return from, to
+}
@@ -0,0 +1,87 @@
+package dtos
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// MaxApplicationLinks the maximum number of links which can be provided with an organizer application.
+const MaxApplicationLinks = 5
+
+// ApplyForOrganizer represents the payload accepted when a user applies to become an organizer.
+type ApplyForOrganizer struct {
+	DTO
+	Reason       string   `json:"reason"`        // Reason describes the events the user intends to organize.
+	Links        []string `json:"links"`         // Links to websites or profiles of the user, such as previous events they organized.
+	ContactEmail string   `json:"contact_email"` // ContactEmail the email address admins may contact the user at while reviewing the application.
+	ContactPhone string   `json:"contact_phone"`
+}
+
+// Validate implements validatable returns any validation errors.
+func (dto *ApplyForOrganizer) Validate() (errs []string) {
+	if !utils.StringLengthInBounds(dto.Reason, 20, 2000) {
+		errs = append(errs, "reason must contain between 20 and 2000 characters")
+	}
+	if len(dto.Links) > MaxApplicationLinks {
+		errs = append(errs, fmt.Sprintf("at most %d links can be provided", MaxApplicationLinks))
+	}
+	for i, link := range dto.Links {
+		if !utils.IsWebURL(link) || len(link) > 500 {
+			errs = append(errs, fmt.Sprintf("links[%d] must be a http or https url of at most 500 characters", i))
+		}
+	}
+	if !utils.IsEmail(dto.ContactEmail) {
+		errs = append(errs, "contact_email must be a valid email address")
+	}
+	if len(dto.ContactPhone) > 50 {
+		errs = append(errs, "contact_phone must contain at most 50 characters")
+	}
+	return errs
+}
+
+// ReviewOrganizerApplication represents the payload accepted when an admin approves or rejects an organizer application.
+type ReviewOrganizerApplication struct {
+	DTO
+	Status  types.OrganizerApplicationStatus `json:"status"`
+	Comment string                           `json:"comment"` // Comment explains the decision to the applicant, which is required when rejecting.
+}
+
+// Validate implements validatable returns any validation errors.
+func (dto *ReviewOrganizerApplication) Validate() (errs []string) {
+	if !dto.Status.IsReviewed() {
+		errs = append(errs, "status must be one of approved or rejected")
+	}
+	if dto.Status == types.RejectedApplicationStatus && len(dto.Comment) < 1 {
+		errs = append(errs, "comment is required when rejecting an application")
+	}
+	if len(dto.Comment) > 1000 {
+		errs = append(errs, "comment must contain at most 1000 characters")
+	}
+	return errs
+}
+
+// ListOrganizerApplications represents the query parameters accepted when listing organizer applications for review.
+type ListOrganizerApplications struct {
+	DTO
+	Status types.OrganizerApplicationStatus // Status limits the listing to applications with the status, defaulting to pending applications.
+}
+
+// ReadQuery populates the dto from url query parameters, returning any errors for values that could not be parsed.
+func (dto *ListOrganizerApplications) ReadQuery(query url.Values) (errs []string) {
+	dto.Status = types.PendingApplicationStatus
+	if status := query.Get("status"); len(status) > 0 {
+		dto.Status = types.OrganizerApplicationStatus(status)
+	}
+	return errs
+}
+
+// Validate implements validatable returns any validation errors.
+func (dto *ListOrganizerApplications) Validate() (errs []string) {
+	if !dto.Status.IsValid() {
+		errs = append(errs, "status must be one of pending, approved or rejected")
+	}
+	return errs
+}
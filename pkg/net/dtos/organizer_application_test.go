@@ -0,0 +1,126 @@
+package dtos_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestApplyForOrganizer_Validation(t *testing.T) {
+	reason := "I run a monthly meetup for local developers."
+
+	testcases := []struct {
+		name string
+		dtos.ApplyForOrganizer
+		expectedErrs int
+	}{
+		{
+			name:              "empty application",
+			ApplyForOrganizer: dtos.ApplyForOrganizer{},
+			expectedErrs:      2,
+		},
+		{
+			name:              "valid application",
+			ApplyForOrganizer: dtos.ApplyForOrganizer{Reason: reason, Links: []string{"https://meetup.example.com"}, ContactEmail: "organizer@example.com", ContactPhone: "+44 20 7946 0000"},
+			expectedErrs:      0,
+		},
+		{
+			name:              "reason too short",
+			ApplyForOrganizer: dtos.ApplyForOrganizer{Reason: "Please", ContactEmail: "organizer@example.com"},
+			expectedErrs:      1,
+		},
+		{
+			name:              "invalid links",
+			ApplyForOrganizer: dtos.ApplyForOrganizer{Reason: reason, Links: []string{"meetup.example.com", "javascript:alert(1)"}, ContactEmail: "organizer@example.com"},
+			expectedErrs:      2,
+		},
+		{
+			name:              "too many links",
+			ApplyForOrganizer: dtos.ApplyForOrganizer{Reason: reason, Links: strings.Split(strings.Repeat("https://example.com ", dtos.MaxApplicationLinks+1), " ")[:dtos.MaxApplicationLinks+1], ContactEmail: "organizer@example.com"},
+			expectedErrs:      1,
+		},
+		{
+			name:              "phone too long",
+			ApplyForOrganizer: dtos.ApplyForOrganizer{Reason: reason, ContactEmail: "organizer@example.com", ContactPhone: strings.Repeat("1", 51)},
+			expectedErrs:      1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := tc.ApplyForOrganizer.Validate(); len(errs) != tc.expectedErrs {
+				t.Errorf("expected %d errors but got %d: %v", tc.expectedErrs, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestReviewOrganizerApplication_Validation(t *testing.T) {
+	testcases := []struct {
+		name string
+		dtos.ReviewOrganizerApplication
+		expectedErrs int
+	}{
+		{
+			name:                       "approval without comment",
+			ReviewOrganizerApplication: dtos.ReviewOrganizerApplication{Status: types.ApprovedApplicationStatus},
+			expectedErrs:               0,
+		},
+		{
+			name:                       "rejection with comment",
+			ReviewOrganizerApplication: dtos.ReviewOrganizerApplication{Status: types.RejectedApplicationStatus, Comment: "Please link to a previous event."},
+			expectedErrs:               0,
+		},
+		{
+			name:                       "rejection without comment",
+			ReviewOrganizerApplication: dtos.ReviewOrganizerApplication{Status: types.RejectedApplicationStatus},
+			expectedErrs:               1,
+		},
+		{
+			name:                       "pending is not a decision",
+			ReviewOrganizerApplication: dtos.ReviewOrganizerApplication{Status: types.PendingApplicationStatus},
+			expectedErrs:               1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := tc.ReviewOrganizerApplication.Validate(); len(errs) != tc.expectedErrs {
+				t.Errorf("expected %d errors but got %d: %v", tc.expectedErrs, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestListOrganizerApplications_ReadQuery(t *testing.T) {
+	testcases := []struct {
+		query          string
+		expectedStatus types.OrganizerApplicationStatus
+		expectedErrs   int
+	}{
+		{query: "", expectedStatus: types.PendingApplicationStatus},
+		{query: "status=rejected", expectedStatus: types.RejectedApplicationStatus},
+		{query: "status=unknown", expectedStatus: "unknown", expectedErrs: 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dto := dtos.ListOrganizerApplications{}
+			errs := append(dto.ReadQuery(values), dto.Validate()...)
+			if len(errs) != tc.expectedErrs {
+				t.Errorf("expected %d errors but got %d: %v", tc.expectedErrs, len(errs), errs)
+			}
+			if dto.Status != tc.expectedStatus {
+				t.Errorf("expected status %s but was %s", tc.expectedStatus, dto.Status)
+			}
+		})
+	}
+}
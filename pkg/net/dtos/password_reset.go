@@ -0,0 +1,32 @@
+package dtos
+
+import "github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+
+// ForgotPassword the email address of the account whose password should be reset.
+type ForgotPassword struct {
+	DTO
+	Email string `json:"email"`
+}
+
+// Validate implements validatable returns any validation errors
+func (f *ForgotPassword) Validate() (errs []string) {
+	if !utils.IsEmail(f.Email) {
+		errs = append(errs, "email must be a valid email address")
+	}
+	return errs
+}
+
+// ResetPassword the token within the password reset link sent to a user, along with their new password.
+type ResetPassword struct {
+	DTO
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// Validate implements validatable returns any validation errors
+func (r *ResetPassword) Validate() (errs []string) {
+	if len(r.Token) < 1 {
+		errs = append(errs, "token is required")
+	}
+	return append(errs, validatePassword(r.Password)...)
+}
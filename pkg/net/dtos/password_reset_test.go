@@ -0,0 +1,44 @@
+package dtos_test
+
+import (
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+func TestResetPassword_Validation(t *testing.T) {
+	testcases := []struct {
+		name string
+		dtos.ResetPassword
+		expectedErrs int
+	}{
+		{
+			name:          "empty reset",
+			ResetPassword: dtos.ResetPassword{},
+			expectedErrs:  3,
+		},
+		{
+			name:          "valid reset",
+			ResetPassword: dtos.ResetPassword{Token: "token", Password: "Pa22W0rd123"},
+			expectedErrs:  0,
+		},
+		{
+			name:          "password without numbers",
+			ResetPassword: dtos.ResetPassword{Token: "token", Password: "Password"},
+			expectedErrs:  1,
+		},
+		{
+			name:          "password too short",
+			ResetPassword: dtos.ResetPassword{Token: "token", Password: "Pa22"},
+			expectedErrs:  1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := tc.ResetPassword.Validate(); len(errs) != tc.expectedErrs {
+				t.Errorf("expected %d errors but got %d: %v", tc.expectedErrs, len(errs), errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package dtos
+
+import (
+	"time"
+)
+
+// localQueryTimeFormats the formats accepted for query times without an offset, which are interpreted within the callers time zone.
+var localQueryTimeFormats = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// queryDateFormat the format accepted for query dates, which cover the whole day within the callers time zone.
+const queryDateFormat = "2006-01-02"
+
+// parseQueryTime parses a RFC 3339 date time, or a date time or date without an offset which is interpreted within the provided location.
+// A date is the start of that day, or the last moment of that day when endOfDay is set, so that a window ending on a date includes the whole day.
+func parseQueryTime(value string, location *time.Location, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, format := range localQueryTimeFormats {
+		if t, err := time.ParseInLocation(format, value, location); err == nil {
+			return t, nil
+		}
+	}
+	date, err := time.ParseInLocation(queryDateFormat, value, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		// the database stores microseconds, so the last microsecond of the day is the last moment of the day.
+		return date.AddDate(0, 0, 1).Add(-time.Microsecond), nil
+	}
+	return date, nil
+}
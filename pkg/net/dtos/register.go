@@ -22,14 +22,7 @@ func (reg *Register) Validate() (errs []string) {
 		errs = append(errs, "'%s' is not a valid email address", reg.Email)
 	}
 	// Validate password.
-	// Ensure password is alphanumberic.
-	if !utils.ContainsAlphabeticCharacters(reg.Password) || !utils.ContainsNumbericCharacters(reg.Password) {
-		errs = append(errs, "password must contain both alphanumberic characters")
-	}
-	// Ensure password length is inbounds
-	if !utils.StringLengthInBounds(reg.Password, 6, 50) {
-		errs = append(errs, "password must contain between 6 and 50 characters")
-	}
+	errs = append(errs, validatePassword(reg.Password)...)
 	// Validate firstname
 	if utils.ContainsNoneAlphabeticCharacters(reg.FirstName) {
 		errs = append(errs, "firstname must contain only alphabetic characters without numbers, symbols or spaces")
@@ -54,3 +47,16 @@ func (reg *Register) Validate() (errs []string) {
 
 	return errs
 }
+
+// validatePassword returns any errors for a password which breaks the password rules, shared by every dto choosing a password.
+func validatePassword(password string) (errs []string) {
+	// Ensure password is alphanumberic.
+	if !utils.ContainsAlphabeticCharacters(password) || !utils.ContainsNumbericCharacters(password) {
+		errs = append(errs, "password must contain both alphanumberic characters")
+	}
+	// Ensure password length is inbounds
+	if !utils.StringLengthInBounds(password, 6, 50) {
+		errs = append(errs, "password must contain between 6 and 50 characters")
+	}
+	return errs
+}
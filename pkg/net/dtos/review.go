@@ -0,0 +1,41 @@
+package dtos
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type CreateOrUpdateReview struct {
+	DTO
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Rating *int   `json:"rating,omitempty"`
+}
+
+// Validate implements validatable returns any validation errors.
+// All required fields must be present, use ValidatePartial when validating updates.
+func (dto *CreateOrUpdateReview) Validate() (errs []string) {
+	if len(dto.Title) < 1 {
+		errs = append(errs, "title is required")
+	}
+	if len(dto.Body) < 1 {
+		errs = append(errs, "body is required")
+	}
+	if dto.Rating == nil {
+		errs = append(errs, "rating is required")
+	}
+	return append(errs, dto.ValidatePartial()...)
+}
+
+// ValidatePartial returns any validation errors for the fields that are present in the dto.
+func (dto *CreateOrUpdateReview) ValidatePartial() (errs []string) {
+	if len(dto.Title) > 0 && !utils.StringLengthInBounds(dto.Title, 1, 500) {
+		errs = append(errs, "title must contain between 1 and 500 characters")
+	}
+	if len(dto.Body) > 0 && !utils.StringLengthInBounds(dto.Body, 1, 2000) {
+		errs = append(errs, "body must contain between 1 and 2000 characters")
+	}
+	if dto.Rating != nil && (*dto.Rating < 1 || *dto.Rating > 5) {
+		errs = append(errs, "rating must be between 1 and 5")
+	}
+	return errs
+}
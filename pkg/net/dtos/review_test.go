@@ -0,0 +1,69 @@
+package dtos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+func TestCreateOrUpdateReview_Validation(t *testing.T) {
+	rating, outOfRange := 4, 6
+
+	testcases := []struct {
+		name string
+		dtos.CreateOrUpdateReview
+		expectedErrs        int
+		expectedPartialErrs int
+	}{
+		{
+			name:                 "empty review dto",
+			CreateOrUpdateReview: dtos.CreateOrUpdateReview{},
+			expectedErrs:         3,
+			expectedPartialErrs:  0,
+		},
+		{
+			name: "valid review dto",
+			CreateOrUpdateReview: dtos.CreateOrUpdateReview{
+				Title:  "Great event",
+				Body:   "Would attend again.",
+				Rating: &rating,
+			},
+			expectedErrs:        0,
+			expectedPartialErrs: 0,
+		},
+		{
+			name: "rating out of range",
+			CreateOrUpdateReview: dtos.CreateOrUpdateReview{
+				Title:  "Great event",
+				Body:   "Would attend again.",
+				Rating: &outOfRange,
+			},
+			expectedErrs:        1,
+			expectedPartialErrs: 1,
+		},
+		{
+			name: "body too long",
+			CreateOrUpdateReview: dtos.CreateOrUpdateReview{
+				Title:  "Great event",
+				Body:   strings.Repeat("a", 2001),
+				Rating: &rating,
+			},
+			expectedErrs:        1,
+			expectedPartialErrs: 1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+			if errs := testcase.ValidatePartial(); len(errs) != testcase.expectedPartialErrs {
+				t.Errorf("expected %v partial errors but got %v", testcase.expectedPartialErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
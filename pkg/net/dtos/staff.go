@@ -0,0 +1,38 @@
+package dtos
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// InviteStaff represents the payload accepted when inviting a user to the staff of an event.
+type InviteStaff struct {
+	DTO
+	Email string          `json:"email"` // Email the email address of the invited user, who must already be registered.
+	Role  types.StaffRole `json:"role"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *InviteStaff) Validate() (errs []string) {
+	if !utils.IsEmail(dto.Email) {
+		errs = append(errs, "email must be a valid email address")
+	}
+	if !dto.Role.IsValid() {
+		errs = append(errs, "role must be one of co_organizer, checkin_staff or moderator")
+	}
+	return errs
+}
+
+// ChangeStaffRole represents the payload accepted when changing the role of a staff member.
+type ChangeStaffRole struct {
+	DTO
+	Role types.StaffRole `json:"role"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *ChangeStaffRole) Validate() (errs []string) {
+	if !dto.Role.IsValid() {
+		errs = append(errs, "role must be one of co_organizer, checkin_staff or moderator")
+	}
+	return errs
+}
@@ -0,0 +1,69 @@
+package dtos
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// CreateTag represents the payload accepted when creating a tag.
+type CreateTag struct {
+	DTO
+	Name string `json:"name"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *CreateTag) Validate() (errs []string) {
+	if !utils.StringLengthInBounds(strings.TrimSpace(dto.Name), 1, 50) {
+		errs = append(errs, "name must contain between 1 and 50 characters")
+	}
+	return errs
+}
+
+// CreateOrUpdateCategory represents the payload accepted when creating or renaming a category.
+type CreateOrUpdateCategory struct {
+	DTO
+	Name string `json:"name"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *CreateOrUpdateCategory) Validate() (errs []string) {
+	if !utils.StringLengthInBounds(strings.TrimSpace(dto.Name), 1, 50) {
+		errs = append(errs, "name must contain between 1 and 50 characters")
+	}
+	return errs
+}
+
+// ListTags represents the query parameters accepted when searching or listing popular tags.
+type ListTags struct {
+	DTO
+	Query string
+	Limit int
+}
+
+// ReadQuery populates the dto from url query parameters, returning any errors for values that could not be parsed.
+func (dto *ListTags) ReadQuery(query url.Values) (errs []string) {
+	if limit := query.Get("limit"); len(limit) > 0 {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			errs = append(errs, "limit must be a number")
+		} else {
+			dto.Limit = n
+		}
+	}
+	dto.Query = strings.TrimSpace(query.Get("q"))
+	return errs
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *ListTags) Validate() (errs []string) {
+	if len(dto.Query) > 50 {
+		errs = append(errs, "q must contain at most 50 characters")
+	}
+	if dto.Limit < 0 || dto.Limit > 50 {
+		errs = append(errs, "limit must be between 1 and 50")
+	}
+	return errs
+}
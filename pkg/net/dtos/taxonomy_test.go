@@ -0,0 +1,132 @@
+package dtos_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+)
+
+func TestCreateTag_Validation(t *testing.T) {
+	testcases := []struct {
+		name string
+		dtos.CreateTag
+		expectedErrs int
+	}{
+		{
+			name:         "empty tag dto",
+			CreateTag:    dtos.CreateTag{},
+			expectedErrs: 1,
+		},
+		{
+			name:         "blank name",
+			CreateTag:    dtos.CreateTag{Name: "   "},
+			expectedErrs: 1,
+		},
+		{
+			name:         "valid tag dto",
+			CreateTag:    dtos.CreateTag{Name: "Live Music"},
+			expectedErrs: 0,
+		},
+		{
+			name:         "name too long",
+			CreateTag:    dtos.CreateTag{Name: strings.Repeat("a", 51)},
+			expectedErrs: 1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.CreateTag.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateCategory_Validation(t *testing.T) {
+	testcases := []struct {
+		name string
+		dtos.CreateOrUpdateCategory
+		expectedErrs int
+	}{
+		{
+			name:                   "empty category dto",
+			CreateOrUpdateCategory: dtos.CreateOrUpdateCategory{},
+			expectedErrs:           1,
+		},
+		{
+			name:                   "valid category dto",
+			CreateOrUpdateCategory: dtos.CreateOrUpdateCategory{Name: "Technology"},
+			expectedErrs:           0,
+		},
+		{
+			name:                   "name too long",
+			CreateOrUpdateCategory: dtos.CreateOrUpdateCategory{Name: strings.Repeat("a", 51)},
+			expectedErrs:           1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.CreateOrUpdateCategory.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestListTags_ReadQueryAndValidate(t *testing.T) {
+	testcases := []struct {
+		name              string
+		query             string
+		expectedParseErrs int
+		expectedErrs      int
+	}{
+		{
+			name:              "empty query",
+			query:             "",
+			expectedParseErrs: 0,
+			expectedErrs:      0,
+		},
+		{
+			name:              "valid query",
+			query:             "q=mus&limit=10",
+			expectedParseErrs: 0,
+			expectedErrs:      0,
+		},
+		{
+			name:              "malformed limit",
+			query:             "limit=ten",
+			expectedParseErrs: 1,
+			expectedErrs:      0,
+		},
+		{
+			name:              "invalid values",
+			query:             "q=" + strings.Repeat("a", 51) + "&limit=100",
+			expectedParseErrs: 0,
+			expectedErrs:      2,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			values, err := url.ParseQuery(testcase.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dto := dtos.ListTags{}
+			if errs := dto.ReadQuery(values); len(errs) != testcase.expectedParseErrs {
+				t.Errorf("expected %v parse errors but got %v", testcase.expectedParseErrs, len(errs))
+				t.Log(errs)
+			}
+			if errs := dto.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
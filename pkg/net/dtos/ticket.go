@@ -0,0 +1,120 @@
+package dtos
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+const (
+	MaxTicketPrice      = 100_000_000 // MaxTicketPrice the highest price of a ticket, in the minor unit of its currency.
+	MaxTicketQuantity   = 100_000     // MaxTicketQuantity the most tickets of a type which can be sold for each occurrence of an event.
+	MaxOrderItems       = 10          // MaxOrderItems the most ticket types which can be ordered at once.
+	MaxOrderItemTickets = 20          // MaxOrderItemTickets the most tickets of a single type which can be ordered at once.
+)
+
+// CreateOrUpdateTicketType represents the payload accepted when creating or updating a ticket type of a paid event.
+type CreateOrUpdateTicketType struct {
+	DTO
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Price in the minor unit of the currency, such as cents.
+	Price *int64 `json:"price,omitempty"`
+	// Currency the ISO 4217 code of the currency, such as "EUR".
+	Currency   string     `json:"currency"`
+	Quantity   *int       `json:"quantity,omitempty"`
+	SalesStart *time.Time `json:"sales_start,omitempty"`
+	SalesEnd   *time.Time `json:"sales_end,omitempty"`
+}
+
+// Validate implements validatable returns any validation errors.
+// All required fields must be present, use ValidatePartial when validating updates.
+func (dto *CreateOrUpdateTicketType) Validate() (errs []string) {
+	if len(strings.TrimSpace(dto.Name)) < 1 {
+		errs = append(errs, "name is required")
+	}
+	if dto.Price == nil {
+		errs = append(errs, "price is required")
+	}
+	if len(dto.Currency) < 1 {
+		errs = append(errs, "currency is required")
+	}
+	if dto.Quantity == nil {
+		errs = append(errs, "quantity is required")
+	}
+	return append(errs, dto.ValidatePartial()...)
+}
+
+// ValidatePartial returns any validation errors for the fields that are present in the dto.
+func (dto *CreateOrUpdateTicketType) ValidatePartial() (errs []string) {
+	if len(dto.Name) > 0 && !utils.StringLengthInBounds(strings.TrimSpace(dto.Name), 1, 100) {
+		errs = append(errs, "name must contain between 1 and 100 characters")
+	}
+	if len(dto.Description) > 500 {
+		errs = append(errs, "description must contain at most 500 characters")
+	}
+	if dto.Price != nil && (*dto.Price < 0 || *dto.Price > MaxTicketPrice) {
+		errs = append(errs, fmt.Sprintf("price must be between 0 and %d", MaxTicketPrice))
+	}
+	if len(dto.Currency) > 0 && !utils.IsCurrencyCode(dto.Currency) {
+		errs = append(errs, "currency must be a three letter ISO 4217 code such as 'EUR'")
+	}
+	if dto.Quantity != nil && (*dto.Quantity < 1 || *dto.Quantity > MaxTicketQuantity) {
+		errs = append(errs, fmt.Sprintf("quantity must be between 1 and %d", MaxTicketQuantity))
+	}
+	if dto.SalesStart != nil && dto.SalesEnd != nil && !dto.SalesEnd.After(*dto.SalesStart) {
+		errs = append(errs, "sales_end must be after sales_start")
+	}
+	return errs
+}
+
+// OrderItem represents the tickets of a single type within an order.
+type OrderItem struct {
+	TicketTypeID string `json:"ticket_type_id"`
+	Quantity     int    `json:"quantity"`
+}
+
+// CreateOrder represents the payload accepted when ordering tickets for a paid event.
+type CreateOrder struct {
+	DTO
+	// Occurrence the id of the occurrence to order tickets for, which is required for recurring events.
+	Occurrence string      `json:"occurrence,omitempty"`
+	Items      []OrderItem `json:"items"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *CreateOrder) Validate() (errs []string) {
+	if len(dto.Items) < 1 || len(dto.Items) > MaxOrderItems {
+		errs = append(errs, fmt.Sprintf("items must contain between 1 and %d ticket types", MaxOrderItems))
+	}
+	seen := map[string]bool{}
+	for i, item := range dto.Items {
+		if len(item.TicketTypeID) < 1 {
+			errs = append(errs, fmt.Sprintf("items[%d].ticket_type_id is required", i))
+		} else if seen[item.TicketTypeID] {
+			errs = append(errs, fmt.Sprintf("items[%d].ticket_type_id must not be repeated", i))
+		}
+		seen[item.TicketTypeID] = true
+		if item.Quantity < 1 || item.Quantity > MaxOrderItemTickets {
+			errs = append(errs, fmt.Sprintf("items[%d].quantity must be between 1 and %d", i, MaxOrderItemTickets))
+		}
+	}
+	return errs
+}
+
+// ChangeOrderStatus represents the payload accepted when moving an order through its lifecycle.
+type ChangeOrderStatus struct {
+	DTO
+	Status types.OrderStatus `json:"status"`
+}
+
+// Validate implements validatable returns any validation errors
+func (dto *ChangeOrderStatus) Validate() (errs []string) {
+	if !dto.Status.IsValid() {
+		errs = append(errs, "status must be one of 'pending', 'paid', 'cancelled' or 'refunded'")
+	}
+	return errs
+}
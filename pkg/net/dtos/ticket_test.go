@@ -0,0 +1,157 @@
+package dtos_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestCreateOrUpdateTicketType_Validation(t *testing.T) {
+	price := int64(2500)
+	negativePrice := int64(-1)
+	quantity := 100
+	noQuantity := 0
+	salesStart := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	salesEnd := salesStart.AddDate(0, 1, 0)
+
+	testcases := []struct {
+		name string
+		dtos.CreateOrUpdateTicketType
+		expectedErrs        int
+		expectedPartialErrs int
+	}{
+		{
+			name:                     "empty ticket type dto",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{},
+			expectedErrs:             4,
+			expectedPartialErrs:      0,
+		},
+		{
+			name:                     "valid ticket type dto",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{Name: "Early bird", Price: &price, Currency: "EUR", Quantity: &quantity, SalesStart: &salesStart, SalesEnd: &salesEnd},
+			expectedErrs:             0,
+			expectedPartialErrs:      0,
+		},
+		{
+			name:                     "name too long",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{Name: strings.Repeat("a", 101), Price: &price, Currency: "EUR", Quantity: &quantity},
+			expectedErrs:             1,
+			expectedPartialErrs:      1,
+		},
+		{
+			name:                     "negative price",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{Name: "General", Price: &negativePrice, Currency: "EUR", Quantity: &quantity},
+			expectedErrs:             1,
+			expectedPartialErrs:      1,
+		},
+		{
+			name:                     "invalid currency",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{Name: "General", Price: &price, Currency: "EURO", Quantity: &quantity},
+			expectedErrs:             1,
+			expectedPartialErrs:      1,
+		},
+		{
+			name:                     "no quantity",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{Name: "General", Price: &price, Currency: "EUR", Quantity: &noQuantity},
+			expectedErrs:             1,
+			expectedPartialErrs:      1,
+		},
+		{
+			name:                     "sales end before start",
+			CreateOrUpdateTicketType: dtos.CreateOrUpdateTicketType{SalesStart: &salesEnd, SalesEnd: &salesStart},
+			expectedErrs:             5,
+			expectedPartialErrs:      1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.CreateOrUpdateTicketType.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+			if errs := testcase.CreateOrUpdateTicketType.ValidatePartial(); len(errs) != testcase.expectedPartialErrs {
+				t.Errorf("expected %v partial errors but got %v", testcase.expectedPartialErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestCreateOrder_Validation(t *testing.T) {
+	tooMany := make([]dtos.OrderItem, dtos.MaxOrderItems+1)
+	for i := range tooMany {
+		tooMany[i] = dtos.OrderItem{TicketTypeID: strings.Repeat("a", i+1), Quantity: 1}
+	}
+
+	testcases := []struct {
+		name string
+		dtos.CreateOrder
+		expectedErrs int
+	}{
+		{
+			name:         "empty order dto",
+			CreateOrder:  dtos.CreateOrder{},
+			expectedErrs: 1,
+		},
+		{
+			name:         "valid order dto",
+			CreateOrder:  dtos.CreateOrder{Items: []dtos.OrderItem{{TicketTypeID: "general", Quantity: 2}, {TicketTypeID: "vip", Quantity: 1}}},
+			expectedErrs: 0,
+		},
+		{
+			name:         "too many items",
+			CreateOrder:  dtos.CreateOrder{Items: tooMany},
+			expectedErrs: 1,
+		},
+		{
+			name:         "missing ticket type",
+			CreateOrder:  dtos.CreateOrder{Items: []dtos.OrderItem{{Quantity: 1}}},
+			expectedErrs: 1,
+		},
+		{
+			name:         "repeated ticket type",
+			CreateOrder:  dtos.CreateOrder{Items: []dtos.OrderItem{{TicketTypeID: "general", Quantity: 1}, {TicketTypeID: "general", Quantity: 1}}},
+			expectedErrs: 1,
+		},
+		{
+			name:         "quantity out of bounds",
+			CreateOrder:  dtos.CreateOrder{Items: []dtos.OrderItem{{TicketTypeID: "general", Quantity: 0}, {TicketTypeID: "vip", Quantity: dtos.MaxOrderItemTickets + 1}}},
+			expectedErrs: 2,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if errs := testcase.CreateOrder.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
+
+func TestChangeOrderStatus_Validation(t *testing.T) {
+	testcases := []struct {
+		name         string
+		status       types.OrderStatus
+		expectedErrs int
+	}{
+		{name: "paid", status: types.PaidOrderStatus, expectedErrs: 0},
+		{name: "refunded", status: types.RefundedOrderStatus, expectedErrs: 0},
+		{name: "unknown status", status: "shipped", expectedErrs: 1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			dto := dtos.ChangeOrderStatus{Status: testcase.status}
+			if errs := dto.Validate(); len(errs) != testcase.expectedErrs {
+				t.Errorf("expected %v errors but got %v", testcase.expectedErrs, len(errs))
+				t.Log(errs)
+			}
+		})
+	}
+}
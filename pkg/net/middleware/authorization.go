@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 type JWTBearerMiddleware struct {
 	JWTService service.JsonWebTokenService
 	Logger     logging.Logger
+	Optional   bool // Optional allows requests without an authorization header through without a user context.
 }
 
 func (jwtmw JWTBearerMiddleware) BeforeNext(next http.Handler) http.Handler {
@@ -23,6 +25,12 @@ func (jwtmw JWTBearerMiddleware) BeforeNext(next http.Handler) http.Handler {
 		//extract auth header
 		authorization := r.Header.Get("Authorization")
 
+		//continue without a user context if authorization is optional
+		if authorization == "" && jwtmw.Optional {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		//return 401 if no authorization header
 		if authorization == "" {
 			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidAuthHeader, http.StatusUnauthorized, nil)
@@ -36,9 +44,15 @@ func (jwtmw JWTBearerMiddleware) BeforeNext(next http.Handler) http.Handler {
 			return
 		}
 
-		//validate token
+		//validate token, tokens of sessions which have ended are rejected when the service checks sessions
 		payload, err := jwtmw.JWTService.ParseAccessToken(parts[1])
+		if errors.Is(err, service.ErrSessionLookup) {
+			jwtmw.Logger.Error(err, "failed to check the session of access token")
+			utils.WriteInternalErrorJsonResponse(w)
+			return
+		}
 		if err != nil {
+			jwtmw.Logger.Infof("rejected access token: %s", err)
 			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidAuthToken, http.StatusUnauthorized, nil)
 			return
 		}
@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+func TestJWTBearerMiddleware_Sessions(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	jwtService := service.NewJsonWebTokenService(&service.JsonWebTokenConfiguration{AccessTokenSecret: "access"}, lw)
+
+	testcases := []struct {
+		name         string
+		active       bool
+		activeErr    error
+		expectStatus int
+		expectCode   string
+	}{
+		{name: "active session", active: true, expectStatus: http.StatusOK},
+		{name: "ended session", expectStatus: http.StatusUnauthorized, expectCode: constants.ErrorCodes.AuthInvalidAuthToken},
+		{name: "session repository unavailable", activeErr: errors.New("connection refused"), expectStatus: http.StatusInternalServerError, expectCode: constants.ErrorCodes.InternalServerError},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			sessions := mock.SessionRepository{
+				IsSessionActiveFn: func(userId string, sessionId string) (bool, error) {
+					return testcase.active, testcase.activeErr
+				},
+			}
+			protectMiddleware := middleware.JWTBearerMiddleware{
+				Logger:     logging.NewContextLogger(lw, "JWTBearerMiddleware"),
+				JWTService: service.NewSessionJsonWebTokenService(jwtService, sessions),
+			}
+			handler := protectMiddleware.BeforeNext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			token, err := jwtService.SignAccessToken(service.JwtPayload{Id: "user", Role: types.UserRole, SessionId: "1df76107-4585-49f0-b772-85e830b437af"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", "Bearer "+*token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != testcase.expectStatus {
+				t.Fatalf("expected status %d but was %d", testcase.expectStatus, w.Code)
+			}
+			if len(testcase.expectCode) > 0 {
+				response := utils.ServerResponse{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatal(err)
+				}
+				if response.Code != testcase.expectCode {
+					t.Errorf("expected error code %s but was %s", testcase.expectCode, response.Code)
+				}
+			}
+		})
+	}
+}
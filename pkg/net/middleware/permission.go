@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// PermissionMiddleware protects routes which require the requesting user to be granted permissions through their role.
+// It must be placed after the JWTBearerMiddleware, which provides the user context.
+type PermissionMiddleware struct {
+	Logger         logging.Logger
+	UserRepository repository.UserRepository
+	Permissions    []types.Permission // Permissions the user must be granted all of.
+	Verified       bool               // Verified whether the user must have verified their email address.
+}
+
+// Require returns a copy of the middleware requiring the permissions, declaring the permissions of a single route.
+func (pmw PermissionMiddleware) Require(permissions ...types.Permission) PermissionMiddleware {
+	pmw.Permissions = permissions
+	return pmw
+}
+
+// RequireVerified returns a copy of the middleware requiring the permissions and a verified email address, declaring the permissions of a single route.
+func (pmw PermissionMiddleware) RequireVerified(permissions ...types.Permission) PermissionMiddleware {
+	pmw.Permissions = permissions
+	pmw.Verified = true
+	return pmw
+}
+
+func (pmw PermissionMiddleware) BeforeNext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, ok := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+		if !ok || len(payload.Id) < 1 {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{"no user context provided"})
+			return
+		}
+
+		// the role is loaded rather than read from the token, so changes to it take effect immediately.
+		user, err := pmw.UserRepository.GetUserByID(payload.Id)
+		if err != nil {
+			pmw.Logger.Error(err, "failed to load user from context")
+			if errors.Is(err, repository.ErrRepoConnErr) {
+				utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
+				return
+			}
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
+			return
+		}
+
+		if err := CheckPermissions(user.Role, pmw.Permissions...); err != nil {
+			pmw.Logger.Infof("user %s was denied access to %s %s: %s", user.ID, r.Method, r.URL.Path, err)
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
+			return
+		}
+
+		if pmw.Verified && !user.Verified {
+			pmw.Logger.Infof("unverified user %s was denied access to %s %s", user.ID, r.Method, r.URL.Path)
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthUnverifiedAccount, http.StatusForbidden, []string{ErrUnverifiedAccount.Error()})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CheckPermissions returns an error wrapping ErrMissingPermission unless the role grants every one of the permissions.
+func CheckPermissions(role types.Role, permissions ...types.Permission) error {
+	for _, permission := range permissions {
+		if !role.Grants(permission) {
+			return fmt.Errorf("%w '%v'", ErrMissingPermission, permission)
+		}
+	}
+	return nil
+}
+
+var (
+	ErrMissingPermission = errors.New("user is missing the required permission")          // ErrMissingPermission is returned when the role of the requesting user does not grant a permission required by the route.
+	ErrUnverifiedAccount = errors.New("user must verify their email address to continue") // ErrUnverifiedAccount is returned when a user who has not verified their email address requests a route requiring a verified account.
+)
@@ -0,0 +1,141 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+func TestPermissionMiddleware(t *testing.T) {
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger: logging.NewContextLogger(logging.NewTextLogWriter(os.Stdout, logging.DEBUG), "PermissionMiddleware"),
+		UserRepository: mock.UserRepository{
+			GetUserByIDFn: func(id string) (*models.UserModel, error) {
+				switch id {
+				case "user":
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.UserRole}, nil
+				case "organizer":
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.OrganizerRole}, nil
+				case "admin":
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.AdminRole}, nil
+				case "offline":
+					return nil, repository.ErrRepoConnErr
+				}
+				return nil, repository.ErrUserNotFound
+			},
+		},
+	}
+
+	testcases := []struct {
+		name         string
+		id           string
+		permissions  []types.Permission
+		expectStatus int
+		expectCode   string
+	}{
+		{name: "organizer may create events", id: "organizer", permissions: []types.Permission{types.CreateEventPermission}, expectStatus: http.StatusOK},
+		{name: "admin may create events", id: "admin", permissions: []types.Permission{types.CreateEventPermission}, expectStatus: http.StatusOK},
+		{name: "user may not create events", id: "user", permissions: []types.Permission{types.CreateEventPermission}, expectStatus: http.StatusUnauthorized, expectCode: constants.ErrorCodes.AuthInvalidScope},
+		{name: "organizer may not manage categories", id: "organizer", permissions: []types.Permission{types.CreateTagPermission, types.ManageCategoriesPermission}, expectStatus: http.StatusUnauthorized, expectCode: constants.ErrorCodes.AuthInvalidScope},
+		{name: "unknown user", id: "deleted", permissions: []types.Permission{types.CreateTagPermission}, expectStatus: http.StatusUnauthorized, expectCode: constants.ErrorCodes.AuthInvalidScope},
+		{name: "no user context", permissions: []types.Permission{types.CreateTagPermission}, expectStatus: http.StatusUnauthorized, expectCode: constants.ErrorCodes.AuthInvalidScope},
+		{name: "repository unavailable", id: "offline", permissions: []types.Permission{types.CreateTagPermission}, expectStatus: http.StatusInternalServerError, expectCode: constants.ErrorCodes.InternalServerError},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			handler := permissionMiddleware.Require(testcase.permissions...).BeforeNext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest("POST", "/", nil)
+			if len(testcase.id) > 0 {
+				r = r.WithContext(context.WithValue(r.Context(), service.USER_CONTEXT_KEY, &service.JwtPayload{Id: testcase.id}))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != testcase.expectStatus {
+				t.Fatalf("expected status %d but was %d", testcase.expectStatus, w.Code)
+			}
+			if len(testcase.expectCode) > 0 {
+				response := utils.ServerResponse{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatal(err)
+				}
+				if response.Code != testcase.expectCode {
+					t.Errorf("expected error code %s but was %s", testcase.expectCode, response.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionMiddleware_RequireVerified(t *testing.T) {
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger: logging.NewContextLogger(logging.NewTextLogWriter(os.Stdout, logging.DEBUG), "PermissionMiddleware"),
+		UserRepository: mock.UserRepository{
+			GetUserByIDFn: func(id string) (*models.UserModel, error) {
+				switch id {
+				case "verified":
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.OrganizerRole, Verified: true}, nil
+				case "unverified":
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.OrganizerRole}, nil
+				case "verified-user":
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.UserRole, Verified: true}, nil
+				}
+				return nil, repository.ErrUserNotFound
+			},
+		},
+	}
+
+	testcases := []struct {
+		name         string
+		id           string
+		expectStatus int
+		expectCode   string
+	}{
+		{name: "verified organizer may create events", id: "verified", expectStatus: http.StatusOK},
+		{name: "unverified organizer may not create events", id: "unverified", expectStatus: http.StatusForbidden, expectCode: constants.ErrorCodes.AuthUnverifiedAccount},
+		{name: "permissions are checked before verification", id: "verified-user", expectStatus: http.StatusUnauthorized, expectCode: constants.ErrorCodes.AuthInvalidScope},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			handler := permissionMiddleware.RequireVerified(types.CreateEventPermission).BeforeNext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest("POST", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), service.USER_CONTEXT_KEY, &service.JwtPayload{Id: testcase.id}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != testcase.expectStatus {
+				t.Fatalf("expected status %d but was %d", testcase.expectStatus, w.Code)
+			}
+			if len(testcase.expectCode) > 0 {
+				response := utils.ServerResponse{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatal(err)
+				}
+				if response.Code != testcase.expectCode {
+					t.Errorf("expected error code %s but was %s", testcase.expectCode, response.Code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtAttendanceRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	attendanceRepository   repository.AttendanceRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenAttendanceRoutes creates routes using AttendanceRepository, EventRepository, OccurrenceRepository and JsonWebTokenService then mounts them to the provided router.
+// Attendance is of a single occurrence, identified by the occurrence query parameter which is required for recurring events.
+func NewJsonWebTokenAttendanceRoutes(router net.AppRouter, attendanceRepository repository.AttendanceRepository, eventRepository repository.EventRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtAttendanceRoutes {
+	routes := jwtAttendanceRoutes{
+		/* inject dependencies */
+		attendanceRepository: attendanceRepository,
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		occurrenceRepository: occurrenceRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "AttendanceRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "AttendanceRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Post(
+		"/api/events/{id}/attendance",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleAttendEvent)),
+	)
+	router.Delete(
+		"/api/events/{id}/attendance",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCancelAttendance)),
+	)
+	router.Get(
+		"/api/events/{id}/attendees",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListAttendees)),
+	)
+	router.Get(
+		"/api/events/{id}/waitlist",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListWaitlist)),
+	)
+	router.Get(
+		"/api/me/events",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListMyEvents)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/attendance", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/attendees", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/waitlist", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/events", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+func (a jwtAttendanceRoutes) HandleAttendEvent(w http.ResponseWriter, r *http.Request) {
+	user, err := a.LoadUserFromContext(r)
+	if err != nil {
+		a.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	event, ok := loadVisibleEvent(w, r, a.UserContextHelpers, a.eventRepository, a.staffRepository, a.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	if !event.CanBeAttended() {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{fmt.Sprintf("unable to attend a %s event", event.Status)})
+		return
+	}
+
+	// the attendees of paid events are those who paid for their tickets.
+	if event.IsPaid {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"attending a paid event requires ordering a ticket"})
+		return
+	}
+
+	occurrence, ok := requestOccurrence(w, r, a.occurrenceRepository, a.logger, event)
+	if !ok {
+		return
+	}
+
+	if occurrence.Cancelled {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to attend a cancelled occurrence"})
+		return
+	}
+
+	if occurrence.HasEnded(time.Now()) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to attend an event that has already ended"})
+		return
+	}
+
+	status, err := a.attendanceRepository.AddAttendee(event.ID, occurrence.OccurrenceStart, user.ID)
+	if err != nil {
+		a.logger.Errorf(err, "unable to add attendee %s to event %s", user.ID, event.ID)
+		switch {
+		case errors.Is(err, repository.ErrAlreadyAttending), errors.Is(err, repository.ErrAlreadyWaitlisted):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+		case errors.Is(err, repository.ErrEventNotOpen):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+		case errors.Is(err, repository.ErrEventNotFound):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, dtos.Attendance{Status: status, Occurrence: occurrence.ID})
+}
+
+func (a jwtAttendanceRoutes) HandleCancelAttendance(w http.ResponseWriter, r *http.Request) {
+	user, err := a.LoadUserFromContext(r)
+	if err != nil {
+		a.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	event, err := a.eventRepository.GetEventByID(id)
+	if err != nil {
+		a.logger.Errorf(err, "unable to find event with id: %s", id)
+		writeEventLookupError(w, err)
+		return
+	}
+
+	// the attendees of paid events hold a ticket, which is released by cancelling or refunding their order rather than their attendance.
+	if event.IsPaid {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"cancelling attendance of a paid event requires cancelling or refunding the order of its ticket"})
+		return
+	}
+
+	occurrence, ok := requestOccurrence(w, r, a.occurrenceRepository, a.logger, event)
+	if !ok {
+		return
+	}
+
+	// attendance of past events is kept as a record of who attended.
+	if occurrence.HasEnded(time.Now()) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to cancel attendance of an event that has already ended"})
+		return
+	}
+
+	removal, err := a.attendanceRepository.RemoveAttendee(event.ID, occurrence.OccurrenceStart, user.ID)
+	if err != nil {
+		a.logger.Errorf(err, "unable to remove attendee %s from event %s", user.ID, event.ID)
+		if errors.Is(err, repository.ErrNotAttending) || errors.Is(err, repository.ErrEventNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrEventNotOpen) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	for _, promoted := range removal.Promoted {
+		a.logger.Infof("promoted waitlisted user %s to attendee of event %s", promoted, event.ID)
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+func (a jwtAttendanceRoutes) HandleListAttendees(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	// Load the event and ensure the requesting user is permitted to view its attendees.
+	event, ok := loadEventForStaff(w, r, a.UserContextHelpers, a.eventRepository, a.staffRepository, a.logger, id, types.ViewAttendeesPermission)
+	if !ok {
+		return
+	}
+
+	occurrence, ok := a.filterOccurrence(w, r, event)
+	if !ok {
+		return
+	}
+
+	attendees, err := a.attendanceRepository.ListAttendees(event.ID, occurrence)
+	if err != nil {
+		a.logger.Errorf(err, "unable to list attendees of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, attendees)
+}
+
+func (a jwtAttendanceRoutes) HandleListWaitlist(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	// Load the event and ensure the requesting user is permitted to view its attendees.
+	event, ok := loadEventForStaff(w, r, a.UserContextHelpers, a.eventRepository, a.staffRepository, a.logger, id, types.ViewAttendeesPermission)
+	if !ok {
+		return
+	}
+
+	occurrence, ok := a.filterOccurrence(w, r, event)
+	if !ok {
+		return
+	}
+
+	waitlist, err := a.attendanceRepository.ListWaitlist(event.ID, occurrence)
+	if err != nil {
+		a.logger.Errorf(err, "unable to list waitlist of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, waitlist)
+}
+
+func (a jwtAttendanceRoutes) HandleListMyEvents(w http.ResponseWriter, r *http.Request) {
+	user, err := a.LoadUserFromContext(r)
+	if err != nil {
+		a.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	events, err := a.attendanceRepository.ListUpcomingEventsForUser(user.ID)
+	if err != nil {
+		a.logger.Errorf(err, "unable to list upcoming events for user %s", user.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, events)
+}
+
+// filterOccurrence returns the original start of the occurrence identified by the optional occurrence query parameter, or nil for all occurrences.
+// Writes an error response and returns false when the occurrence could not be resolved.
+func (a jwtAttendanceRoutes) filterOccurrence(w http.ResponseWriter, r *http.Request, event *models.EventModel) (*time.Time, bool) {
+	if len(r.URL.Query().Get("occurrence")) < 1 {
+		return nil, true
+	}
+
+	occurrence, ok := requestOccurrence(w, r, a.occurrenceRepository, a.logger, event)
+	if !ok {
+		return nil, false
+	}
+	return &occurrence.OccurrenceStart, true
+}
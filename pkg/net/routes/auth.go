@@ -10,20 +10,25 @@ import (
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
 )
 
 type jwtAuthRoutes struct {
-	authService service.AuthenticationService
-	logger      logging.Logger
+	authService          service.AuthenticationService
+	verificationService  service.EmailVerificationService
+	passwordResetService service.PasswordResetService
+	logger               logging.Logger
 }
 
-// NewJsonWebTokenAuthenticationRoutes creates routes using AuthenticationService and JsonWebTokenService then mounts them to the provided router.
-func NewJsonWebTokenAuthenticationRoutes(router net.AppRouter, authService service.AuthenticationService, jwtService *service.JsonWebTokenService, lw logging.LogWriter) *jwtAuthRoutes {
+// NewJsonWebTokenAuthenticationRoutes creates routes using AuthenticationService, EmailVerificationService, PasswordResetService and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenAuthenticationRoutes(router net.AppRouter, authService service.AuthenticationService, verificationService service.EmailVerificationService, passwordResetService service.PasswordResetService, jwtService *service.JsonWebTokenService, lw logging.LogWriter) *jwtAuthRoutes {
 	routes := &jwtAuthRoutes{
-		authService: authService,
-		logger:      logging.NewContextLogger(lw, "AuthRoutes"),
+		authService:          authService,
+		verificationService:  verificationService,
+		passwordResetService: passwordResetService,
+		logger:               logging.NewContextLogger(lw, "AuthRoutes"),
 	}
 
 	protectMiddleware := middleware.JWTBearerMiddleware{
@@ -35,6 +40,15 @@ func NewJsonWebTokenAuthenticationRoutes(router net.AppRouter, authService servi
 	router.Post("/api/auth/register", http.HandlerFunc(routes.HandleSignUp))
 	router.Get("/api/auth/check", protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCheck)))
 	router.Get("/api/auth/refresh", http.HandlerFunc(routes.HandleRefresh))
+	// the verification link sent to users is followed with a GET request, clients may instead POST the token within it.
+	router.Get("/api/auth/verify", http.HandlerFunc(routes.HandleVerifyEmailLink))
+	router.Post("/api/auth/verify", http.HandlerFunc(routes.HandleVerifyEmail))
+	router.Post("/api/auth/verify/resend", protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleResendVerification)))
+	router.Post("/api/auth/forgot-password", http.HandlerFunc(routes.HandleForgotPassword))
+	router.Post("/api/auth/reset-password", http.HandlerFunc(routes.HandleResetPassword))
+	// the refresh token cookie is only sent to the refresh endpoint, so the session to end is the one the access token was issued for.
+	router.Post("/api/auth/logout", protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleLogout)))
+	router.Post("/api/auth/logout/all", protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleLogoutAll)))
 
 	// Add basic preflight handlers
 	router.Options("/api/auth/login", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +64,24 @@ func NewJsonWebTokenAuthenticationRoutes(router net.AppRouter, authService servi
 	router.Options("/api/auth/refresh", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
+	router.Options("/api/auth/verify", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/auth/verify/resend", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/auth/forgot-password", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/auth/reset-password", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/auth/logout", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/auth/logout/all", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
 
 	return routes
 }
@@ -82,11 +114,11 @@ func (authRouter *jwtAuthRoutes) HandleLogin(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	//attach refresh token cookie to response
-	err = authRouter.authService.AttachRefreshTokenCookie(w, data.User.ID)
+	//start a session, attaching its refresh token cookie to the response
+	err = authRouter.authService.StartSession(w, r, data)
 
 	if err != nil {
-		authRouter.logger.Error(err, "error attaching refresh token cookie")
+		authRouter.logger.Error(err, "error starting session")
 		utils.WriteInternalErrorJsonResponse(w)
 		return
 	}
@@ -148,7 +180,7 @@ func (authRouter *jwtAuthRoutes) HandleCheck(w http.ResponseWriter, r *http.Requ
 	utils.WriteSuccessJsonResponse(w, http.StatusOK, result)
 }
 
-// Handles validating refresh token & providing new access token
+// Handles validating refresh token & providing new access token, the refresh token cookie is replaced by a new refresh token
 func (authRouter *jwtAuthRoutes) HandleRefresh(w http.ResponseWriter, r *http.Request) {
 
 	cookie, err := r.Cookie(constants.REFRESH_TOKEN_COOKIE)
@@ -165,7 +197,7 @@ func (authRouter *jwtAuthRoutes) HandleRefresh(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	accessToken, err := authRouter.authService.ValidateRefresh(cookie.Value)
+	accessToken, err := authRouter.authService.ValidateRefresh(w, r, cookie.Value)
 
 	if err != nil {
 		switch {
@@ -184,3 +216,138 @@ func (authRouter *jwtAuthRoutes) HandleRefresh(w http.ResponseWriter, r *http.Re
 	utils.WriteSuccessJsonResponse(w, http.StatusOK, accessToken)
 
 }
+
+// HandleVerifyEmailLink verifies the email address of a user with the token within the query of the verification link.
+func (authRouter *jwtAuthRoutes) HandleVerifyEmailLink(w http.ResponseWriter, r *http.Request) {
+	authRouter.verifyEmail(w, dtos.VerifyEmail{Token: r.URL.Query().Get("token")})
+}
+
+// HandleVerifyEmail verifies the email address of a user with the token within the request body.
+func (authRouter *jwtAuthRoutes) HandleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	payload := dtos.VerifyEmail{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	authRouter.verifyEmail(w, payload)
+}
+
+// HandleResendVerification sends another verification link to the user within the request context.
+func (authRouter *jwtAuthRoutes) HandleResendVerification(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+
+	if err := authRouter.verificationService.ResendVerification(user.Id); err != nil {
+		authRouter.logger.Errorf(err, "unable to resend verification email to user %s", user.Id)
+		switch {
+		case errors.Is(err, service.ErrAlreadyVerified):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+		case errors.Is(err, service.ErrVerificationThrottled):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.TooManyRequests, http.StatusTooManyRequests, []string{err.Error()})
+		case errors.Is(err, repository.ErrUserNotFound):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{fmt.Sprintf("user with id %s does not exist", user.Id)})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, "verification email sent")
+}
+
+// HandleForgotPassword sends a password reset link to the email address within the request body.
+// The response is the same whether or not an account exists with the email address, so that it does not reveal which do.
+func (authRouter *jwtAuthRoutes) HandleForgotPassword(w http.ResponseWriter, r *http.Request) {
+	payload := dtos.ForgotPassword{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	// failures are only logged, as they could otherwise reveal that an account exists.
+	if err := authRouter.passwordResetService.RequestReset(payload.Email); err != nil {
+		authRouter.logger.Error(err, "unable to send password reset email")
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, "if an account exists with the email address, a password reset link was sent to it")
+}
+
+// HandleResetPassword replaces the password of a user with the password within the request body, using the token from their password reset link.
+func (authRouter *jwtAuthRoutes) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	payload := dtos.ResetPassword{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	if err := authRouter.passwordResetService.ResetPassword(payload.Token, payload.Password); err != nil {
+		if errors.Is(err, service.ErrInvalidPasswordResetToken) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+			return
+		}
+		authRouter.logger.Error(err, "unable to reset password")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, "password reset")
+}
+
+// HandleLogout ends the session of the user within the request context, clearing the refresh token cookie.
+// Logging out of a session which already ended succeeds, so that clients can always clear their cookie.
+func (authRouter *jwtAuthRoutes) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+
+	if err := authRouter.authService.EndSession(w, user, user.SessionId); err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+		authRouter.logger.Errorf(err, "unable to end session %s of user %s", user.SessionId, user.Id)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, "logged out")
+}
+
+// HandleLogoutAll ends every session of the user within the request context, logging them out of all devices.
+func (authRouter *jwtAuthRoutes) HandleLogoutAll(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+
+	if _, err := authRouter.authService.EndAllSessions(w, user, false); err != nil {
+		authRouter.logger.Errorf(err, "unable to end sessions of user %s", user.Id)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, "logged out of all devices")
+}
+
+func (authRouter *jwtAuthRoutes) verifyEmail(w http.ResponseWriter, payload dtos.VerifyEmail) {
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	if _, err := authRouter.verificationService.Verify(payload.Token); err != nil {
+		if errors.Is(err, service.ErrInvalidVerificationToken) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+			return
+		}
+		authRouter.logger.Error(err, "unable to verify email")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, "email address verified")
+}
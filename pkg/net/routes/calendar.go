@@ -0,0 +1,307 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/ical"
+)
+
+// calendarUIDDomain is appended to event ids to form their calendar UIDs, it must never change as calendar apps use UIDs to track events.
+const calendarUIDDomain = "event-management-core"
+
+// calendarFeedTokenSize the number of random bytes within a calendar feed token.
+const calendarFeedTokenSize = 32
+
+type jwtCalendarRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	calendarFeedRepository repository.CalendarFeedRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenCalendarRoutes creates routes using CalendarFeedRepository, EventRepository, OccurrenceRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenCalendarRoutes(router net.AppRouter, calendarFeedRepository repository.CalendarFeedRepository, eventRepository repository.EventRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtCalendarRoutes {
+	routes := jwtCalendarRoutes{
+		/* inject dependencies */
+		calendarFeedRepository: calendarFeedRepository,
+		eventRepository:        eventRepository,
+		staffRepository:        staffRepository,
+		occurrenceRepository:   occurrenceRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "CalendarRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "CalendarRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize an optional variant of the protect middleware, allowing organizers to export their draft events.
+	optionalMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "CalendarRoutes.OptionalJWTBearerMiddleware"),
+		JWTService: *jwtService,
+		Optional:   true,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/events/{id}/calendar.ics",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetEventCalendar)),
+	)
+	router.Post(
+		"/api/me/calendar-feed",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCreateCalendarFeed)),
+	)
+	router.Delete(
+		"/api/me/calendar-feed",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDeleteCalendarFeed)),
+	)
+	// the feed is authenticated by the secret token within its url, as calendar apps are unable to send bearer tokens.
+	router.Get(
+		"/api/calendar-feeds/{token}/events.ics",
+		http.HandlerFunc(routes.HandleGetCalendarFeed),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/calendar.ics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/calendar-feed", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/calendar-feeds/{token}/events.ics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+func (c jwtCalendarRoutes) HandleGetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	exceptions, err := c.occurrenceRepository.ListExceptions(event.ID)
+	if err != nil {
+		c.logger.Errorf(err, "unable to list occurrence exceptions of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	calendar := ical.Calendar{
+		Timestamp: time.Now(),
+		Events:    toCalendarEvents(event, exceptions, requestBaseURL(r)),
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", event.Slug+".ics"))
+	c.writeCalendar(w, calendar)
+}
+
+// HandleCreateCalendarFeed creates the secret feed url of the user within the request context.
+// Any previous feed url of the user stops working, so this is also used to reset a leaked url.
+func (c jwtCalendarRoutes) HandleCreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userId, err := c.LoadUserIdFromContext(r)
+	if err != nil {
+		c.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	token, err := utils.GenerateToken(calendarFeedTokenSize)
+	if err != nil {
+		c.logger.Error(err, "unable to generate calendar feed token")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	if err := c.calendarFeedRepository.SetFeedToken(userId, utils.HashToken(token)); err != nil {
+		c.logger.Errorf(err, "unable to set calendar feed token of user %s", userId)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, dtos.CalendarFeed{
+		URL: fmt.Sprintf("%s/api/calendar-feeds/%s/events.ics", requestBaseURL(r), token),
+	})
+}
+
+func (c jwtCalendarRoutes) HandleDeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userId, err := c.LoadUserIdFromContext(r)
+	if err != nil {
+		c.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	if err := c.calendarFeedRepository.DeleteFeedToken(userId); err != nil {
+		c.logger.Errorf(err, "unable to delete calendar feed of user %s", userId)
+		if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// HandleGetCalendarFeed responds with the events the feeds user attends or follows, generated on each request.
+func (c jwtCalendarRoutes) HandleGetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userId, err := c.calendarFeedRepository.GetUserIdByFeedToken(utils.HashToken(r.PathValue("token")))
+	if err != nil {
+		c.logger.Error(err, "unable to find calendar feed")
+		switch {
+		case errors.Is(err, repository.ErrCalendarFeedNotFound):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	events, err := c.calendarFeedRepository.ListFeedEvents(userId)
+	if err != nil {
+		c.logger.Errorf(err, "unable to list calendar feed events of user %s", userId)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	recurring := []string{}
+	for _, event := range events {
+		if event.IsRecurring() {
+			recurring = append(recurring, event.ID)
+		}
+	}
+	exceptions := map[string][]*models.OccurrenceExceptionModel{}
+	if len(recurring) > 0 {
+		if exceptions, err = c.occurrenceRepository.ListExceptionsForEvents(recurring); err != nil {
+			c.logger.Errorf(err, "unable to list occurrence exceptions of calendar feed events of user %s", userId)
+			utils.WriteInternalErrorJsonResponse(w)
+			return
+		}
+	}
+
+	baseURL := requestBaseURL(r)
+	calendar := ical.Calendar{
+		Name:      "My events",
+		Timestamp: time.Now(),
+		Events:    make([]ical.Event, 0, len(events)),
+	}
+	for _, event := range events {
+		calendar.Events = append(calendar.Events, toCalendarEvents(event, exceptions[event.ID], baseURL)...)
+	}
+
+	// calendar apps must always fetch the latest events.
+	w.Header().Set("Cache-Control", "no-store")
+	c.writeCalendar(w, calendar)
+}
+
+func (c jwtCalendarRoutes) writeCalendar(w http.ResponseWriter, calendar ical.Calendar) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := calendar.Encode(w); err != nil {
+		c.logger.Error(err, "unable to write calendar")
+	}
+}
+
+// toCalendarEvent converts the event into a calendar event, identified by a UID derived from its id.
+// Its times are local times of the events time zone, so that calendar apps expand its recurrence the same way as the events occurrences.
+func toCalendarEvent(event *models.EventModel, baseURL string) ical.Event {
+	calendarEvent := ical.Event{
+		UID:          fmt.Sprintf("%s@%s", event.ID, calendarUIDDomain),
+		Summary:      event.Name,
+		Description:  event.Description.String,
+		Location:     eventLocation(event),
+		URL:          fmt.Sprintf("%s/api/events/%s", baseURL, event.ID),
+		Status:       ical.StatusConfirmed,
+		Start:        event.StartDate,
+		End:          event.EndDate,
+		Created:      event.CreatedAt,
+		LastModified: event.UpdatedAt,
+		TimeZone:     event.Location(),
+	}
+	switch event.Status {
+	case types.DraftEventStatus:
+		calendarEvent.Status = ical.StatusTentative
+	case types.CancelledEventStatus:
+		calendarEvent.Status = ical.StatusCancelled
+	}
+	return calendarEvent
+}
+
+// toCalendarEvents converts the event and the exceptions to its occurrences into calendar events sharing the same UID.
+// Cancelled occurrences are excluded from the recurrence, while each rescheduled occurrence is described by its own calendar event.
+func toCalendarEvents(event *models.EventModel, exceptions []*models.OccurrenceExceptionModel, baseURL string) []ical.Event {
+	series := toCalendarEvent(event, baseURL)
+	if !event.IsRecurring() {
+		return []ical.Event{series}
+	}
+
+	series.RecurrenceRule = event.RecurrenceRule.String
+	calendarEvents := []ical.Event{}
+	for _, exception := range exceptions {
+		occurrence, ok := event.Occurrence(exception.OccurrenceStart, exceptions)
+		switch {
+		case !ok:
+			continue
+		case occurrence.Cancelled:
+			series.ExceptionDates = append(series.ExceptionDates, occurrence.OccurrenceStart)
+		case occurrence.Rescheduled:
+			rescheduled := toCalendarEvent(event, baseURL)
+			rescheduled.RecurrenceID = occurrence.OccurrenceStart
+			rescheduled.Start = occurrence.StartDate
+			rescheduled.End = occurrence.EndDate
+			rescheduled.LastModified = exception.UpdatedAt
+			calendarEvents = append(calendarEvents, rescheduled)
+		}
+	}
+	return append([]ical.Event{series}, calendarEvents...)
+}
+
+// eventLocation describes where the event takes place from its city and country, online events without either are described as online.
+func eventLocation(event *models.EventModel) string {
+	parts := []string{}
+	if event.City.Valid && len(event.City.String) > 0 {
+		parts = append(parts, event.City.String)
+	}
+	if event.Country.Valid && len(event.Country.String) > 0 {
+		parts = append(parts, event.Country.String)
+	}
+	if len(parts) < 1 && event.EventType == types.OnlineEventType {
+		return "Online"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// requestBaseURL returns the scheme and host the request was made to, respecting the X-Forwarded-Proto header set by proxies.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
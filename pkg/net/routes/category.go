@@ -0,0 +1,289 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtCategoryRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	categoryRepository     repository.CategoryRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenCategoryRoutes creates routes using CategoryRepository, EventRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenCategoryRoutes(router net.AppRouter, categoryRepository repository.CategoryRepository, eventRepository repository.EventRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtCategoryRoutes {
+	routes := jwtCategoryRoutes{
+		/* inject dependencies */
+		categoryRepository: categoryRepository,
+		eventRepository:    eventRepository,
+		staffRepository:    staffRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "CategoryRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "CategoryRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize an optional variant of the protect middleware, allowing organizers to view their draft events.
+	optionalMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "CategoryRoutes.OptionalJWTBearerMiddleware"),
+		JWTService: *jwtService,
+		Optional:   true,
+	}
+
+	// initialize a permission middleware (factory) to declare the permissions required by each of the routes.
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger:         logging.NewContextLogger(lw, "CategoryRoutes.PermissionMiddleware"),
+		UserRepository: userRepository,
+	}
+	manageCategories := permissionMiddleware.Require(types.ManageCategoriesPermission)
+
+	// mount routes to router.
+	router.Get(
+		"/api/categories",
+		http.HandlerFunc(routes.HandleListCategories),
+	)
+	router.Post(
+		"/api/categories",
+		protectMiddleware.BeforeNext(manageCategories.BeforeNext(http.HandlerFunc(routes.HandleCreateCategory))),
+	)
+	router.Get(
+		"/api/categories/{id}",
+		http.HandlerFunc(routes.HandleGetCategoryById),
+	)
+	router.Put(
+		"/api/categories/{id}",
+		protectMiddleware.BeforeNext(manageCategories.BeforeNext(http.HandlerFunc(routes.HandleUpdateCategoryById))),
+	)
+	router.Delete(
+		"/api/categories/{id}",
+		protectMiddleware.BeforeNext(manageCategories.BeforeNext(http.HandlerFunc(routes.HandleDeleteCategoryById))),
+	)
+	router.Get(
+		"/api/events/{id}/categories",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListEventCategories)),
+	)
+	router.Put(
+		"/api/events/{id}/categories/{categoryId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleAttachCategory)),
+	)
+	router.Delete(
+		"/api/events/{id}/categories/{categoryId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDetachCategory)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/categories", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/categories/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/categories", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/categories/{categoryId}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+func (c jwtCategoryRoutes) HandleListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := c.categoryRepository.ListCategories()
+	if err != nil {
+		c.logger.Error(err, "unable to list categories")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, categories)
+}
+
+func (c jwtCategoryRoutes) HandleCreateCategory(w http.ResponseWriter, r *http.Request) {
+	payload := dtos.CreateOrUpdateCategory{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	category := &models.CategoryModel{}
+	category.UpdateFrom(payload)
+
+	if err := c.categoryRepository.CreateCategory(category); err != nil {
+		c.logger.Error(err, "unable to create category")
+		writeCategoryChangeError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, category)
+}
+
+func (c jwtCategoryRoutes) HandleGetCategoryById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	category, err := c.categoryRepository.GetCategoryByID(id)
+	if err != nil {
+		c.logger.Errorf(err, "unable to find category with id: %s", id)
+		writeCategoryLookupError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, category)
+}
+
+func (c jwtCategoryRoutes) HandleUpdateCategoryById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	category, err := c.categoryRepository.GetCategoryByID(id)
+	if err != nil {
+		c.logger.Errorf(err, "unable to find category with id: %s", id)
+		writeCategoryLookupError(w, err)
+		return
+	}
+
+	payload := dtos.CreateOrUpdateCategory{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	category.UpdateFrom(payload)
+
+	if err := c.categoryRepository.UpdateCategory(category); err != nil {
+		c.logger.Errorf(err, "unable to update category with id: %s", id)
+		writeCategoryChangeError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, category)
+}
+
+func (c jwtCategoryRoutes) HandleDeleteCategoryById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := c.categoryRepository.DeleteCategory(id); err != nil {
+		c.logger.Errorf(err, "unable to delete category with id: %s", id)
+		if errors.Is(err, repository.ErrCategoryNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+func (c jwtCategoryRoutes) HandleListEventCategories(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	c.writeEventCategories(w, event.ID)
+}
+
+func (c jwtCategoryRoutes) HandleAttachCategory(w http.ResponseWriter, r *http.Request) {
+	c.handleEventCategoryChange(w, r, c.categoryRepository.AttachCategory)
+}
+
+func (c jwtCategoryRoutes) HandleDetachCategory(w http.ResponseWriter, r *http.Request) {
+	c.handleEventCategoryChange(w, r, c.categoryRepository.DetachCategory)
+}
+
+// handleEventCategoryChange applies the change to the event and category in the path, responding with the categories of the event after the change.
+// Only the events organizer, its co-organizers or an admin can change the categories of an event.
+func (c jwtCategoryRoutes) handleEventCategoryChange(w http.ResponseWriter, r *http.Request, change func(eventId string, categoryId string) error) {
+	event, ok := loadEventForStaff(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"), types.ManageEventPermission)
+	if !ok {
+		return
+	}
+
+	categoryId := r.PathValue("categoryId")
+
+	category, err := c.categoryRepository.GetCategoryByID(categoryId)
+	if err != nil {
+		c.logger.Errorf(err, "unable to find category with id: %s", categoryId)
+		writeCategoryLookupError(w, err)
+		return
+	}
+
+	if err := change(event.ID, category.ID); err != nil {
+		c.logger.Errorf(err, "unable to change category %s of event %s", category.ID, event.ID)
+		if errors.Is(err, repository.ErrEventNotFound) || errors.Is(err, repository.ErrCategoryNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	c.writeEventCategories(w, event.ID)
+}
+
+func (c jwtCategoryRoutes) writeEventCategories(w http.ResponseWriter, eventId string) {
+	categories, err := c.categoryRepository.ListCategoriesForEvent(eventId)
+	if err != nil {
+		c.logger.Errorf(err, "unable to list categories of event %s", eventId)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, categories)
+}
+
+// writeCategoryLookupError writes the error response for a failure to load a category from the repository.
+func writeCategoryLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrCategoryNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrRepoConnErr):
+		utils.WriteInternalErrorJsonResponse(w)
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	}
+}
+
+// writeCategoryChangeError writes the error response for a failure to create or update a category.
+func writeCategoryChangeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrCategoryExists):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+	case errors.Is(err, repository.ErrCategoryNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	default:
+		utils.WriteInternalErrorJsonResponse(w)
+	}
+}
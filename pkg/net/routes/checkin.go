@@ -0,0 +1,327 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	checkInTokenGrace = 12 * time.Hour // checkInTokenGrace how long after the occurrence has ended its check-in tokens remain valid.
+	checkInQRCodeSize = 320            // checkInQRCodeSize the width and height of check-in QR codes in pixels.
+)
+
+type jwtCheckInRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	checkInService         service.CheckInService
+	attendanceRepository   repository.AttendanceRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	jwtService             service.JsonWebTokenService
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenCheckInRoutes creates routes using CheckInService, AttendanceRepository, EventRepository, OccurrenceRepository and JsonWebTokenService then mounts them to the provided router.
+// Attendees present a signed check-in token for their occurrence at the door, which the events organizer checks in.
+// Door staff with poor connectivity instead validate check-in codes against a downloaded roster, synchronizing their scans once online.
+func NewJsonWebTokenCheckInRoutes(router net.AppRouter, checkInService service.CheckInService, attendanceRepository repository.AttendanceRepository, eventRepository repository.EventRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtCheckInRoutes {
+	routes := jwtCheckInRoutes{
+		/* inject dependencies */
+		checkInService:       checkInService,
+		attendanceRepository: attendanceRepository,
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		occurrenceRepository: occurrenceRepository,
+		jwtService:           *jwtService,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "CheckInRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "CheckInRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/events/{id}/checkin-token",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetCheckInToken)),
+	)
+	router.Get(
+		"/api/events/{id}/checkin-token/qr",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetCheckInQRCode)),
+	)
+	router.Post(
+		"/api/events/{id}/checkin",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCheckIn)),
+	)
+	router.Post(
+		"/api/events/{id}/checkin/sync",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleSyncCheckIns)),
+	)
+	router.Get(
+		"/api/events/{id}/checkin/roster",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetCheckInRoster)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/checkin-token", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/checkin-token/qr", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/checkin", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/checkin/sync", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/checkin/roster", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleGetCheckInToken responds with the check-in token of the user for the occurrence they attend.
+func (c jwtCheckInRoutes) HandleGetCheckInToken(w http.ResponseWriter, r *http.Request) {
+	token, ok := c.signCheckInToken(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, token)
+}
+
+// HandleGetCheckInQRCode responds with the check-in token of the user for the occurrence they attend, encoded as a QR code PNG image.
+func (c jwtCheckInRoutes) HandleGetCheckInQRCode(w http.ResponseWriter, r *http.Request) {
+	token, ok := c.signCheckInToken(w, r)
+	if !ok {
+		return
+	}
+
+	png, err := qrcode.Encode(token.Token, qrcode.Medium, checkInQRCodeSize)
+	if err != nil {
+		c.logger.Error(err, "unable to encode check-in QR code")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	// the token admits its holder, so it must not be cached by shared caches.
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(png); err != nil {
+		c.logger.Error(err, "unable to write check-in QR code")
+	}
+}
+
+// HandleCheckIn checks in the attendee admitted by the check-in token within the payload, which is limited to the events organizer, its check-in staff and admins.
+// Attendees can only be checked in once, presenting a token again responds with a conflict.
+func (c jwtCheckInRoutes) HandleCheckIn(w http.ResponseWriter, r *http.Request) {
+	// Load the event and ensure the requesting user is permitted to check in its attendees.
+	event, ok := loadEventForStaff(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"), types.CheckInPermission)
+	if !ok {
+		return
+	}
+
+	user, err := c.LoadUserFromContext(r)
+	if err != nil {
+		c.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	payload := dtos.CheckIn{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	claims, err := c.jwtService.ParseCheckInToken(payload.Token)
+	if err != nil {
+		c.logger.Errorf(err, "invalid check-in token presented for event %s", event.ID)
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"invalid or expired check-in token"})
+		return
+	}
+
+	if claims.EventId != event.ID {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"check-in token is for another event"})
+		return
+	}
+
+	if !event.CanBeAttended() {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{fmt.Sprintf("unable to check in attendees of a %s event", event.Status)})
+		return
+	}
+
+	attendee, err := c.attendanceRepository.CheckInAttendee(event.ID, claims.Occurrence, claims.UserId, repository.CheckIn{By: user.ID, At: time.Now()})
+	if err != nil {
+		c.logger.Errorf(err, "unable to check in attendee %s of event %s", claims.UserId, event.ID)
+		switch {
+		case errors.Is(err, repository.ErrAlreadyCheckedIn):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{fmt.Sprintf("%s at %s", err, attendee.CheckedInAt.Time.Format(time.RFC3339))})
+		case errors.Is(err, repository.ErrNotAttending):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{"holder of the check-in token is no longer attending the event"})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	c.logger.Infof("attendee %s of event %s was checked in by %s", attendee.UserID, event.ID, user.ID)
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, attendee)
+}
+
+// HandleSyncCheckIns applies the check-in codes scanned by door staff while offline, responding with the outcome of each scan.
+// Scans can be synchronized repeatedly, such as after a failed request, without checking in attendees again.
+func (c jwtCheckInRoutes) HandleSyncCheckIns(w http.ResponseWriter, r *http.Request) {
+	// Load the event and ensure the requesting user is permitted to check in its attendees.
+	event, ok := loadEventForStaff(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"), types.CheckInPermission)
+	if !ok {
+		return
+	}
+
+	user, err := c.LoadUserFromContext(r)
+	if err != nil {
+		c.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	payload := dtos.SyncCheckIns{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	// scans are synchronized once devices are back online, which may be after the event has completed.
+	if event.Status != types.PublishedEventStatus && event.Status != types.CompletedEventStatus {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{fmt.Sprintf("unable to check in attendees of a %s event", event.Status)})
+		return
+	}
+
+	results, err := c.checkInService.SyncCheckIns(event.ID, user.ID, payload.Scans)
+	if err != nil {
+		c.logger.Errorf(err, "unable to sync check-ins of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, results)
+}
+
+// HandleGetCheckInRoster responds with the attendees of the event along with their check-in codes, or only those of the occurrence query parameter.
+func (c jwtCheckInRoutes) HandleGetCheckInRoster(w http.ResponseWriter, r *http.Request) {
+	// Load the event and ensure the requesting user is permitted to check in its attendees.
+	event, ok := loadEventForStaff(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"), types.CheckInPermission)
+	if !ok {
+		return
+	}
+
+	var occurrence *time.Time
+	if len(r.URL.Query().Get("occurrence")) > 0 {
+		resolved, ok := requestOccurrence(w, r, c.occurrenceRepository, c.logger, event)
+		if !ok {
+			return
+		}
+		occurrence = &resolved.OccurrenceStart
+	}
+
+	roster, err := c.checkInService.Roster(event.ID, occurrence)
+	if err != nil {
+		c.logger.Errorf(err, "unable to load check-in roster of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	// the roster contains the codes admitting each attendee, so it must not be cached by shared caches.
+	w.Header().Set("Cache-Control", "private, no-store")
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, roster)
+}
+
+// signCheckInToken signs a check-in token for the user within the request context, admitting them to the occurrence of the event they attend.
+// Writes an error response and returns false when the user is not attending the occurrence or it has ended.
+func (c jwtCheckInRoutes) signCheckInToken(w http.ResponseWriter, r *http.Request) (*dtos.CheckInToken, bool) {
+	user, err := c.LoadUserFromContext(r)
+	if err != nil {
+		c.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return nil, false
+	}
+
+	event, ok := loadVisibleEvent(w, r, c.UserContextHelpers, c.eventRepository, c.staffRepository, c.logger, r.PathValue("id"))
+	if !ok {
+		return nil, false
+	}
+
+	occurrence, ok := requestOccurrence(w, r, c.occurrenceRepository, c.logger, event)
+	if !ok {
+		return nil, false
+	}
+
+	if occurrence.HasEnded(time.Now()) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to check in to an event that has already ended"})
+		return nil, false
+	}
+
+	if _, err := c.attendanceRepository.GetAttendee(event.ID, occurrence.OccurrenceStart, user.ID); err != nil {
+		c.logger.Errorf(err, "unable to find attendee %s of event %s", user.ID, event.ID)
+		if errors.Is(err, repository.ErrNotAttending) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return nil, false
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return nil, false
+	}
+
+	expiresAt := occurrence.EndDate.Add(checkInTokenGrace)
+	token, err := c.jwtService.SignCheckInToken(service.CheckInTokenPayload{
+		UserId:     user.ID,
+		EventId:    event.ID,
+		Occurrence: occurrence.OccurrenceStart,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		c.logger.Errorf(err, "unable to sign check-in token of attendee %s", user.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return nil, false
+	}
+
+	return &dtos.CheckInToken{
+		Token:      *token,
+		Code:       c.checkInService.SignCode(event.ID, occurrence.OccurrenceStart, user.ID),
+		Occurrence: occurrence.ID,
+		ExpiresAt:  expiresAt,
+	}, true
+}
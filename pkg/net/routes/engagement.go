@@ -0,0 +1,205 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtEngagementRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	engagementRepository   repository.EngagementRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenEngagementRoutes creates routes using EngagementRepository, EventRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenEngagementRoutes(router net.AppRouter, engagementRepository repository.EngagementRepository, eventRepository repository.EventRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtEngagementRoutes {
+	routes := jwtEngagementRoutes{
+		/* inject dependencies */
+		engagementRepository: engagementRepository,
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "EngagementRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "EngagementRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Put(
+		"/api/events/{id}/like",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleLikeEvent)),
+	)
+	router.Delete(
+		"/api/events/{id}/like",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUnlikeEvent)),
+	)
+	router.Put(
+		"/api/events/{id}/follow",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleFollowEvent)),
+	)
+	router.Delete(
+		"/api/events/{id}/follow",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUnfollowEvent)),
+	)
+	router.Get(
+		"/api/me/liked-events",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListLikedEvents)),
+	)
+	router.Get(
+		"/api/me/followed-events",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListFollowedEvents)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/like", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/follow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/liked-events", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/followed-events", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+func (e jwtEngagementRoutes) HandleLikeEvent(w http.ResponseWriter, r *http.Request) {
+	e.handleEngagementChange(w, r, e.engagementRepository.LikeEvent)
+}
+
+func (e jwtEngagementRoutes) HandleUnlikeEvent(w http.ResponseWriter, r *http.Request) {
+	e.handleEngagementChange(w, r, e.engagementRepository.UnlikeEvent)
+}
+
+func (e jwtEngagementRoutes) HandleFollowEvent(w http.ResponseWriter, r *http.Request) {
+	e.handleEngagementChange(w, r, e.engagementRepository.FollowEvent)
+}
+
+func (e jwtEngagementRoutes) HandleUnfollowEvent(w http.ResponseWriter, r *http.Request) {
+	e.handleEngagementChange(w, r, e.engagementRepository.UnfollowEvent)
+}
+
+// handleEngagementChange applies the change for the user within the request context to the event in the path,
+// responding with the event as it is after the change.
+func (e jwtEngagementRoutes) handleEngagementChange(w http.ResponseWriter, r *http.Request, change func(eventId string, userId string) error) {
+	user, err := e.LoadUserFromContext(r)
+	if err != nil {
+		e.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	event, ok := loadVisibleEvent(w, r, e.UserContextHelpers, e.eventRepository, e.staffRepository, e.logger, id)
+	if !ok {
+		return
+	}
+
+	if err := change(event.ID, user.ID); err != nil {
+		e.logger.Errorf(err, "unable to change engagement of user %s with event %s", user.ID, event.ID)
+		if errors.Is(err, repository.ErrEventNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	// reload the event to include the updated likes and follows counts.
+	event, err = e.eventRepository.GetEventByID(id)
+	if err != nil {
+		e.logger.Errorf(err, "unable to find event with id: %s", id)
+		writeEventLookupError(w, err)
+		return
+	}
+
+	if err := applyEngagements(r, e.UserContextHelpers, e.engagementRepository, event); err != nil {
+		e.logger.Error(err, "unable to load engagements")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, event)
+}
+
+func (e jwtEngagementRoutes) HandleListLikedEvents(w http.ResponseWriter, r *http.Request) {
+	e.handleListEngagedEvents(w, r, e.engagementRepository.ListLikedEvents)
+}
+
+func (e jwtEngagementRoutes) HandleListFollowedEvents(w http.ResponseWriter, r *http.Request) {
+	e.handleListEngagedEvents(w, r, e.engagementRepository.ListFollowedEvents)
+}
+
+// handleListEngagedEvents responds with the events returned from list for the user within the request context.
+func (e jwtEngagementRoutes) handleListEngagedEvents(w http.ResponseWriter, r *http.Request, list func(userId string) ([]*models.EventModel, error)) {
+	user, err := e.LoadUserFromContext(r)
+	if err != nil {
+		e.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	events, err := list(user.ID)
+	if err != nil {
+		e.logger.Errorf(err, "unable to list events for user %s", user.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	if err := applyEngagements(r, e.UserContextHelpers, e.engagementRepository, events...); err != nil {
+		e.logger.Error(err, "unable to load engagements")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, events)
+}
+
+// applyEngagements sets the liked_by_me and followed_by_me flags of the events for the user within the request context.
+// The events are left unchanged for unauthenticated requests.
+func applyEngagements(r *http.Request, helpers net.UserContextHelpers, engagementRepository repository.EngagementRepository, events ...*models.EventModel) error {
+	userId, err := helpers.LoadUserIdFromContext(r)
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+
+	engagements, err := engagementRepository.GetEngagements(userId, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		engagement := engagements[event.ID]
+		event.LikedByMe = &engagement.Liked
+		event.FollowedByMe = &engagement.Followed
+	}
+
+	return nil
+}
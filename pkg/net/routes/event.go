@@ -0,0 +1,517 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtEventRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	eventRepository        repository.EventRepository
+	engagementRepository   repository.EngagementRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	staffRepository        repository.EventStaffRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenEventRoutes creates routes using EventRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenEventRoutes(router net.AppRouter, eventRepository repository.EventRepository, engagementRepository repository.EngagementRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtEventRoutes {
+	routes := jwtEventRoutes{
+		/* inject dependencies */
+		eventRepository:      eventRepository,
+		engagementRepository: engagementRepository,
+		occurrenceRepository: occurrenceRepository,
+		staffRepository:      staffRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "EventRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "EventRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize an optional variant of the protect middleware, for public routes which include the requesting users engagements.
+	optionalMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "EventRoutes.OptionalJWTBearerMiddleware"),
+		JWTService: *jwtService,
+		Optional:   true,
+	}
+
+	// initialize a permission middleware (factory) to declare the permissions required by each of the routes.
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger:         logging.NewContextLogger(lw, "EventRoutes.PermissionMiddleware"),
+		UserRepository: userRepository,
+	}
+
+	// mount routes to router.
+	router.Post(
+		"/api/events",
+		protectMiddleware.BeforeNext(permissionMiddleware.RequireVerified(types.CreateEventPermission).BeforeNext(http.HandlerFunc(routes.HandleCreateEvent))),
+	)
+	router.Get(
+		"/api/events",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListEvents)),
+	)
+	router.Get(
+		"/api/events/{id}",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetEventById)),
+	)
+	// slugs are looked up outside of /api/events, as ServeMux can not route a slug segment alongside the {id} of events and their sub-resources.
+	router.Get(
+		"/api/event-slugs/{slug}",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetEventBySlug)),
+	)
+	router.Put(
+		"/api/events/{id}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUpdateEventById)),
+	)
+	router.Delete(
+		"/api/events/{id}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDeleteEventById)),
+	)
+	router.Put(
+		"/api/events/{id}/status",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleChangeEventStatus)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/event-slugs/{slug}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/status", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+func (e jwtEventRoutes) HandleCreateEvent(w http.ResponseWriter, r *http.Request) {
+	user, err := e.LoadUserFromContext(r)
+	if err != nil {
+		e.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	payload := dtos.CreateOrUpdateEvent{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	event := &models.EventModel{
+		OrganizerID: user.ID,
+	}
+	event.UpdateFrom(payload)
+
+	if err := e.eventRepository.CreateEvent(event); err != nil {
+		e.logger.Error(err, "unable to create event")
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, event)
+}
+
+func (e jwtEventRoutes) HandleListEvents(w http.ResponseWriter, r *http.Request) {
+	query := dtos.ListEvents{}
+	if parseErrs := query.ReadQuery(r.URL.Query()); len(parseErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, parseErrs)
+		return
+	}
+
+	// custom validation
+	if validationErrs := query.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	filter := repository.EventFilter{
+		From:      query.From,
+		To:        query.To,
+		EventType: query.EventType,
+		IsPaid:    query.IsPaid,
+		Country:   query.Country,
+		City:      query.City,
+		Tag:       query.Tag,
+		Category:  query.Category,
+		Status:    query.Status,
+		Sort:      query.Sort,
+		Order:     query.Order,
+		Limit:     query.Limit,
+	}
+	if !filter.Sort.IsValid() {
+		filter.Sort = types.SortByStartDate
+	}
+	if !filter.Order.IsValid() {
+		filter.Order = filter.Sort.DefaultOrder()
+	}
+	if filter.Limit < 1 {
+		filter.Limit = repository.DefaultEventPageSize
+	}
+
+	// drafts are only listed for users who create events, limited to their own drafts unless they may manage any event.
+	if filter.Status == types.DraftEventStatus {
+		user, err := e.LoadUserFromContextWithPermission(r, types.CreateEventPermission)
+		if err != nil {
+			e.logger.Error(err, "failed to load user from context")
+			writeUserContextError(w, err)
+			return
+		}
+		if !user.Role.Grants(types.ManageAnyEventPermission) {
+			filter.OrganizerID = user.ID
+		}
+	}
+
+	if len(query.Cursor) > 0 {
+		cursor, err := repository.DecodeEventCursor(query.Cursor)
+		if err != nil {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+			return
+		}
+		filter.Cursor = cursor
+	}
+
+	page, err := e.eventRepository.ListEvents(filter)
+	if err != nil {
+		e.logger.Error(err, "unable to list events")
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	pagination := utils.Pagination{
+		Limit:   filter.Limit,
+		HasMore: page.NextCursor != nil,
+	}
+	if page.NextCursor != nil {
+		pagination.NextCursor = page.NextCursor.Encode()
+	}
+
+	// the occurrences of each event are included when listing a window which is short enough to expand them.
+	if query.From != nil && query.To != nil && query.To.Sub(*query.From) <= dtos.MaxOccurrenceWindow {
+		if page.Events, err = applyOccurrences(e.occurrenceRepository, *query.From, *query.To, page.Events); err != nil {
+			e.logger.Error(err, "unable to expand occurrences")
+			utils.WriteInternalErrorJsonResponse(w)
+			return
+		}
+	}
+
+	if err := applyEngagements(r, e.UserContextHelpers, e.engagementRepository, page.Events...); err != nil {
+		e.logger.Error(err, "unable to load engagements")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WritePaginatedJsonResponse(w, http.StatusOK, page.Events, pagination)
+}
+
+func (e jwtEventRoutes) HandleGetEventById(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, e.UserContextHelpers, e.eventRepository, e.staffRepository, e.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	if err := applyEngagements(r, e.UserContextHelpers, e.engagementRepository, event); err != nil {
+		e.logger.Error(err, "unable to load engagements")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, event)
+}
+
+// HandleGetEventBySlug responds with the event with the slug of /api/event-slugs/{slug}.
+func (e jwtEventRoutes) HandleGetEventBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+
+	event, err := e.eventRepository.GetEventBySlug(slug)
+	if err != nil {
+		e.logger.Errorf(err, "unable to find event with slug: %s", slug)
+		writeEventLookupError(w, err)
+		return
+	}
+
+	if !isEventVisible(r, e.UserContextHelpers, e.staffRepository, event) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{repository.ErrEventNotFound.Error()})
+		return
+	}
+
+	// previous slugs of renamed events permanently redirect to the events current slug.
+	if event.Slug != slug {
+		http.Redirect(w, r, fmt.Sprintf("/api/event-slugs/%s", event.Slug), http.StatusMovedPermanently)
+		return
+	}
+
+	if err := applyEngagements(r, e.UserContextHelpers, e.engagementRepository, event); err != nil {
+		e.logger.Error(err, "unable to load engagements")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, event)
+}
+
+func (e jwtEventRoutes) HandleUpdateEventById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	// Load the event and ensure the requesting user is permitted to manage it.
+	event, ok := e.loadEventForModification(w, r, id, types.ManageEventPermission)
+	if !ok {
+		return
+	}
+
+	payload := dtos.CreateOrUpdateEvent{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.ValidatePartial(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	// updates the event from the payload
+	event.UpdateFrom(payload)
+
+	// dates may have been provided individually, so check the resulting range.
+	if event.EndDate.Before(event.StartDate) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"end_date must not be before start_date"})
+		return
+	}
+
+	// submit the changes
+	if err := e.eventRepository.UpdateEvent(event); err != nil {
+		e.logger.Error(err, "unable to update event")
+		writeEventUpdateError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, event)
+}
+
+func (e jwtEventRoutes) HandleDeleteEventById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	// Load the event and ensure the requesting user is permitted to delete it.
+	if _, ok := e.loadEventForModification(w, r, id, types.DeleteEventPermission); !ok {
+		return
+	}
+
+	if err := e.eventRepository.DeleteEvent(id); err != nil {
+		e.logger.Errorf(err, "failed to delete event with id %s", id)
+		if errors.Is(err, repository.ErrEventNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrEventHasOrders) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+			return
+		}
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// HandleChangeEventStatus moves the event through its lifecycle, such as publishing a draft or cancelling a published event.
+func (e jwtEventRoutes) HandleChangeEventStatus(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	// Load the event and ensure the requesting user is permitted to manage it.
+	event, ok := e.loadEventForModification(w, r, id, types.ManageEventPermission)
+	if !ok {
+		return
+	}
+
+	payload := dtos.ChangeEventStatus{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	if !event.Status.CanTransitionTo(payload.Status) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{fmt.Sprintf("unable to change the status of a %s event to %s", event.Status, payload.Status)})
+		return
+	}
+
+	if payload.Status == types.CompletedEventStatus && !event.HasEnded(time.Now()) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to complete an event that has not yet ended"})
+		return
+	}
+
+	previous := event.Status
+	event.ChangeStatus(payload)
+
+	if err := e.eventRepository.ChangeEventStatus(event, previous); err != nil {
+		e.logger.Errorf(err, "unable to change status of event %s", id)
+		if errors.Is(err, repository.ErrEventStatusConflict) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, event)
+}
+
+// loadEventForModification loads the event with the given id, ensuring that the user within the request context holds the permission on it.
+// Writes an error response and returns false when the event could not be loaded or the user is not permitted.
+func (e jwtEventRoutes) loadEventForModification(w http.ResponseWriter, r *http.Request, id string, permission types.EventPermission) (*models.EventModel, bool) {
+	return loadEventForStaff(w, r, e.UserContextHelpers, e.eventRepository, e.staffRepository, e.logger, id, permission)
+}
+
+// loadEventForStaff loads the event with the given id, ensuring that the user within the request context holds the permission on it,
+// either as its organizer, as a member of its staff whose role permits the action or as an admin.
+// Writes an error response and returns false when the event could not be loaded or the user is not permitted.
+func loadEventForStaff(w http.ResponseWriter, r *http.Request, helpers net.UserContextHelpers, eventRepository repository.EventRepository, staffRepository repository.EventStaffRepository, logger logging.Logger, id string, permission types.EventPermission) (*models.EventModel, bool) {
+	user, err := helpers.LoadUserFromContext(r)
+	if err != nil {
+		logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return nil, false
+	}
+
+	event, err := eventRepository.GetEventByID(id)
+	if err != nil {
+		logger.Errorf(err, "unable to find event with id: %s", id)
+		writeEventLookupError(w, err)
+		return nil, false
+	}
+
+	permitted, err := eventPermits(staffRepository, event, user, permission)
+	if err != nil {
+		logger.Errorf(err, "unable to load staff of event %s", id)
+		utils.WriteInternalErrorJsonResponse(w)
+		return nil, false
+	}
+
+	if !permitted {
+		writeEventPermissionError(w, permission)
+		return nil, false
+	}
+
+	return event, true
+}
+
+// eventPermits returns true if the user holds the permission on the event, loading their staff membership of the event when required.
+// Staff are not loaded for the events organizer and users who may manage any event, who are permitted every action regardless.
+func eventPermits(staffRepository repository.EventStaffRepository, event *models.EventModel, user *models.UserModel, permission types.EventPermission) (bool, error) {
+	if event.Permits(user, nil, permission) {
+		return true, nil
+	}
+
+	staff, err := staffRepository.GetStaff(event.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrStaffNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return event.Permits(user, staff, permission), nil
+}
+
+// loadVisibleEvent loads the event with the given id, ensuring that it is visible to the user within the request context.
+// Writes an error response and returns false when the event could not be loaded, drafts are reported as not found to other users.
+func loadVisibleEvent(w http.ResponseWriter, r *http.Request, helpers net.UserContextHelpers, eventRepository repository.EventRepository, staffRepository repository.EventStaffRepository, logger logging.Logger, id string) (*models.EventModel, bool) {
+	event, err := eventRepository.GetEventByID(id)
+	if err != nil {
+		logger.Errorf(err, "unable to find event with id: %s", id)
+		writeEventLookupError(w, err)
+		return nil, false
+	}
+
+	if !isEventVisible(r, helpers, staffRepository, event) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{repository.ErrEventNotFound.Error()})
+		return nil, false
+	}
+
+	return event, true
+}
+
+// isEventVisible returns true unless the event is a draft, which is only visible to its organizer, its staff and admins.
+func isEventVisible(r *http.Request, helpers net.UserContextHelpers, staffRepository repository.EventStaffRepository, event *models.EventModel) bool {
+	if !event.IsDraft() {
+		return true
+	}
+
+	user, err := helpers.LoadUserFromContext(r)
+	if err != nil {
+		return false
+	}
+
+	permitted, err := eventPermits(staffRepository, event, user, types.ViewDraftPermission)
+	return err == nil && permitted
+}
+
+// writeEventPermissionError writes the error response for a user who does not hold the permission on the event, consistent with middleware.PermissionMiddleware.
+func writeEventPermissionError(w http.ResponseWriter, permission types.EventPermission) {
+	utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{fmt.Sprintf("%s '%v' on the event", middleware.ErrMissingPermission, permission)})
+}
+
+// writeEventUpdateError writes the error response for a failure to store changes to an event.
+func writeEventUpdateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrEventNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrSlugConflict):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
+	}
+}
+
+// writeEventLookupError writes the error response for a failure to load an event from the repository.
+func writeEventLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrEventNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrRepoConnErr):
+		utils.WriteInternalErrorJsonResponse(w)
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	}
+}
@@ -73,11 +73,11 @@ func (authRouter *googleAuthenticationRoutes) HandleGoogleSignUp(w http.Response
 		return
 	}
 
-	//attach refresh token cookie in response
-	err = authRouter.jwtAuthService.AttachRefreshTokenCookie(w, result.User.ID)
+	//start a session, attaching its refresh token cookie in response
+	err = authRouter.jwtAuthService.StartSession(w, r, result)
 
 	if err != nil {
-		authRouter.logger.Error(err, "error attaching refresh token cookie")
+		authRouter.logger.Error(err, "error starting session")
 		utils.WriteInternalErrorJsonResponse(w)
 		return
 	}
@@ -121,11 +121,11 @@ func (authRouter *googleAuthenticationRoutes) HandleGoogleSignIn(w http.Response
 		return
 	}
 
-	//attach refresh token cookie in response
-	err = authRouter.jwtAuthService.AttachRefreshTokenCookie(w, result.User.ID)
+	//start a session, attaching its refresh token cookie in response
+	err = authRouter.jwtAuthService.StartSession(w, r, result)
 
 	if err != nil {
-		authRouter.logger.Error(err, "error attaching refresh token cookie")
+		authRouter.logger.Error(err, "error starting session")
 		utils.WriteInternalErrorJsonResponse(w)
 		return
 	}
@@ -0,0 +1,348 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtOccurrenceRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenOccurrenceRoutes creates routes using EventRepository, OccurrenceRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenOccurrenceRoutes(router net.AppRouter, eventRepository repository.EventRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtOccurrenceRoutes {
+	routes := jwtOccurrenceRoutes{
+		/* inject dependencies */
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		occurrenceRepository: occurrenceRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "OccurrenceRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "OccurrenceRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize an optional variant of the protect middleware, allowing organizers to list the occurrences of their draft events.
+	optionalMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "OccurrenceRoutes.OptionalJWTBearerMiddleware"),
+		JWTService: *jwtService,
+		Optional:   true,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/events/{id}/occurrences",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListOccurrences)),
+	)
+	router.Put(
+		"/api/events/{id}/occurrences/{occurrence}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUpdateOccurrence)),
+	)
+	router.Delete(
+		"/api/events/{id}/occurrences/{occurrence}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleRestoreOccurrence)),
+	)
+	router.Put(
+		"/api/events/{id}/occurrences/{occurrence}/following",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUpdateFollowingOccurrences)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/occurrences", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/occurrences/{occurrence}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/occurrences/{occurrence}/following", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleListOccurrences lists the occurrences of the event within a date window, which defaults to the next 90 days.
+func (o jwtOccurrenceRoutes) HandleListOccurrences(w http.ResponseWriter, r *http.Request) {
+	query := dtos.ListOccurrences{}
+	if parseErrs := query.ReadQuery(r.URL.Query()); len(parseErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, parseErrs)
+		return
+	}
+
+	// custom validation
+	if validationErrs := query.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	event, ok := loadVisibleEvent(w, r, o.UserContextHelpers, o.eventRepository, o.staffRepository, o.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	exceptions, err := o.occurrenceRepository.ListExceptions(event.ID)
+	if err != nil {
+		o.logger.Errorf(err, "unable to list occurrence exceptions of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	from, to := query.Window(time.Now())
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, event.ExpandOccurrences(from, to, exceptions))
+}
+
+// HandleUpdateOccurrence cancels or reschedules a single occurrence of a recurring event.
+func (o jwtOccurrenceRoutes) HandleUpdateOccurrence(w http.ResponseWriter, r *http.Request) {
+	event, ok := o.loadRecurringEventForModification(w, r)
+	if !ok {
+		return
+	}
+
+	payload := dtos.UpdateOccurrence{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	occurrence, ok := resolveOccurrence(w, o.occurrenceRepository, o.logger, event, r.PathValue("occurrence"))
+	if !ok {
+		return
+	}
+
+	exception := models.NewOccurrenceException(occurrence)
+	exception.UpdateFrom(payload)
+
+	var err error
+	if exception.Cancelled || exception.IsRescheduled() {
+		err = o.occurrenceRepository.SetException(exception)
+	} else if err = o.occurrenceRepository.DeleteException(event.ID, occurrence.OccurrenceStart); errors.Is(err, repository.ErrOccurrenceExceptionNotFound) {
+		// an occurrence which is neither cancelled nor rescheduled follows the schedule of its event.
+		err = nil
+	}
+	if err != nil {
+		o.logger.Errorf(err, "unable to update occurrence %s of event %s", occurrence.ID, event.ID)
+		if errors.Is(err, repository.ErrEventNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	updated, _ := event.Occurrence(occurrence.OccurrenceStart, []*models.OccurrenceExceptionModel{exception})
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, updated)
+}
+
+// HandleRestoreOccurrence removes any changes to a single occurrence of a recurring event, so that it follows the schedule of the event.
+func (o jwtOccurrenceRoutes) HandleRestoreOccurrence(w http.ResponseWriter, r *http.Request) {
+	event, ok := o.loadRecurringEventForModification(w, r)
+	if !ok {
+		return
+	}
+
+	start, err := models.ParseOccurrenceID(r.PathValue("occurrence"))
+	if err != nil {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{errInvalidOccurrenceID})
+		return
+	}
+
+	if err := o.occurrenceRepository.DeleteException(event.ID, start); err != nil {
+		o.logger.Errorf(err, "unable to restore occurrence %s of event %s", r.PathValue("occurrence"), event.ID)
+		if errors.Is(err, repository.ErrOccurrenceExceptionNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// HandleUpdateFollowingOccurrences changes the occurrence of a recurring event and all of the occurrences which follow it.
+// The series is split into a new event starting from the occurrence, unless it is the first occurrence in which case the whole event is updated.
+func (o jwtOccurrenceRoutes) HandleUpdateFollowingOccurrences(w http.ResponseWriter, r *http.Request) {
+	event, ok := o.loadRecurringEventForModification(w, r)
+	if !ok {
+		return
+	}
+
+	payload := dtos.CreateOrUpdateEvent{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.ValidatePartial(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	occurrence, ok := resolveOccurrence(w, o.occurrenceRepository, o.logger, event, r.PathValue("occurrence"))
+	if !ok {
+		return
+	}
+
+	if occurrence.OccurrenceStart.Equal(event.StartDate) {
+		event.UpdateFrom(payload)
+		if event.EndDate.Before(event.StartDate) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"end_date must not be before start_date"})
+			return
+		}
+		if err := o.eventRepository.UpdateEvent(event); err != nil {
+			o.logger.Error(err, "unable to update event")
+			writeEventUpdateError(w, err)
+			return
+		}
+		utils.WriteSuccessJsonResponse(w, http.StatusOK, event)
+		return
+	}
+
+	next, err := event.SplitAt(occurrence.OccurrenceStart, payload)
+	if err != nil {
+		o.logger.Errorf(err, "unable to split event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	// dates may have been provided individually, so check the resulting range.
+	if next.EndDate.Before(next.StartDate) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"end_date must not be before start_date"})
+		return
+	}
+
+	if err := o.eventRepository.SplitEvent(event, next, occurrence.OccurrenceStart); err != nil {
+		o.logger.Errorf(err, "unable to split event %s at occurrence %s", event.ID, occurrence.ID)
+		writeEventUpdateError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, next)
+}
+
+// loadRecurringEventForModification loads the recurring event with the id within the request path, ensuring that the user within the request context is permitted to modify it.
+// Writes an error response and returns false when the event could not be loaded, or its occurrences can not be changed.
+func (o jwtOccurrenceRoutes) loadRecurringEventForModification(w http.ResponseWriter, r *http.Request) (*models.EventModel, bool) {
+	// Load the event and ensure the requesting user is permitted to manage it.
+	event, ok := loadEventForStaff(w, r, o.UserContextHelpers, o.eventRepository, o.staffRepository, o.logger, r.PathValue("id"), types.ManageEventPermission)
+	if !ok {
+		return nil, false
+	}
+
+	if !event.IsRecurring() {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"only the occurrences of recurring events can be changed, update the event instead"})
+		return nil, false
+	}
+
+	if event.Status != types.DraftEventStatus && event.Status != types.PublishedEventStatus {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{fmt.Sprintf("unable to change the occurrences of a %s event", event.Status)})
+		return nil, false
+	}
+
+	return event, true
+}
+
+// errInvalidOccurrenceID the error message for an occurrence id which can not be parsed.
+const errInvalidOccurrenceID = "occurrence must be the original start of an occurrence such as 20240605T120000Z"
+
+// resolveOccurrence returns the occurrence of the event with the provided id, applying any exception to it.
+// Writes an error response and returns false when the id is invalid or the event has no such occurrence.
+func resolveOccurrence(w http.ResponseWriter, occurrenceRepository repository.OccurrenceRepository, logger logging.Logger, event *models.EventModel, id string) (*models.OccurrenceModel, bool) {
+	start, err := models.ParseOccurrenceID(id)
+	if err != nil {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{errInvalidOccurrenceID})
+		return nil, false
+	}
+
+	var exceptions []*models.OccurrenceExceptionModel
+	if event.IsRecurring() {
+		if exceptions, err = occurrenceRepository.ListExceptions(event.ID); err != nil {
+			logger.Errorf(err, "unable to list occurrence exceptions of event %s", event.ID)
+			utils.WriteInternalErrorJsonResponse(w)
+			return nil, false
+		}
+	}
+
+	occurrence, ok := event.Occurrence(start, exceptions)
+	if !ok {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{fmt.Sprintf("event has no occurrence %s", models.FormatOccurrenceID(start))})
+		return nil, false
+	}
+
+	return occurrence, true
+}
+
+// requestOccurrence resolves the occurrence of the event identified by the requests occurrence query parameter.
+// The parameter is required for recurring events, while the only occurrence of other events is used when it is omitted.
+func requestOccurrence(w http.ResponseWriter, r *http.Request, occurrenceRepository repository.OccurrenceRepository, logger logging.Logger, event *models.EventModel) (*models.OccurrenceModel, bool) {
+	return resolveOptionalOccurrence(w, occurrenceRepository, logger, event, r.URL.Query().Get("occurrence"))
+}
+
+// resolveOptionalOccurrence resolves the occurrence of the event with the provided id, which may only be empty for events which are not recurring.
+func resolveOptionalOccurrence(w http.ResponseWriter, occurrenceRepository repository.OccurrenceRepository, logger logging.Logger, event *models.EventModel, id string) (*models.OccurrenceModel, bool) {
+	if len(id) < 1 {
+		if event.IsRecurring() {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"occurrence is required for recurring events"})
+			return nil, false
+		}
+		id = models.FormatOccurrenceID(event.StartDate)
+	}
+	return resolveOccurrence(w, occurrenceRepository, logger, event, id)
+}
+
+// applyOccurrences sets the occurrences of each event between from and to, removing recurring events without any occurrences in the window.
+func applyOccurrences(occurrenceRepository repository.OccurrenceRepository, from time.Time, to time.Time, events []*models.EventModel) ([]*models.EventModel, error) {
+	recurring := []string{}
+	for _, event := range events {
+		if event.IsRecurring() {
+			recurring = append(recurring, event.ID)
+		}
+	}
+
+	exceptions := map[string][]*models.OccurrenceExceptionModel{}
+	if len(recurring) > 0 {
+		var err error
+		if exceptions, err = occurrenceRepository.ListExceptionsForEvents(recurring); err != nil {
+			return nil, err
+		}
+	}
+
+	applied := make([]*models.EventModel, 0, len(events))
+	for _, event := range events {
+		event.Occurrences = event.ExpandOccurrences(from, to, exceptions[event.ID])
+		if len(event.Occurrences) > 0 || !event.IsRecurring() {
+			applied = append(applied, event)
+		}
+	}
+	return applied, nil
+}
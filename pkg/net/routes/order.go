@@ -0,0 +1,417 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtOrderRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	orderService           service.OrderService
+	orderRepository        repository.OrderRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenOrderRoutes creates routes using OrderService, OrderRepository, EventRepository, OccurrenceRepository and JsonWebTokenService then mounts them to the provided router.
+// Orders are of a single occurrence, identified by the occurrence within the payload which is required for recurring events.
+func NewJsonWebTokenOrderRoutes(router net.AppRouter, orderService service.OrderService, orderRepository repository.OrderRepository, eventRepository repository.EventRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtOrderRoutes {
+	routes := jwtOrderRoutes{
+		/* inject dependencies */
+		orderService:         orderService,
+		orderRepository:      orderRepository,
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		occurrenceRepository: occurrenceRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "OrderRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "OrderRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Post(
+		"/api/events/{id}/orders",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCreateOrder)),
+	)
+	router.Get(
+		"/api/events/{id}/orders",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListEventOrders)),
+	)
+	router.Get(
+		"/api/orders/{id}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetOrderById)),
+	)
+	router.Put(
+		"/api/orders/{id}/status",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleChangeOrderStatus)),
+	)
+	router.Post(
+		"/api/orders/{id}/payment",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleStartOrderPayment)),
+	)
+	router.Post(
+		"/api/orders/{id}/payment/confirm",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleConfirmOrderPayment)),
+	)
+	router.Get(
+		"/api/me/orders",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListMyOrders)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/orders", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/orders/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/orders/{id}/status", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/orders/{id}/payment", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/orders/{id}/payment/confirm", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/orders", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleCreateOrder places a pending order of tickets for an occurrence of a paid event, which holds the tickets until it is paid or expires.
+func (o jwtOrderRoutes) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
+	user, err := o.LoadUserFromContext(r)
+	if err != nil {
+		o.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	event, ok := loadVisibleEvent(w, r, o.UserContextHelpers, o.eventRepository, o.staffRepository, o.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	if !event.IsPaid {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"tickets are only sold for paid events, attend the event instead"})
+		return
+	}
+
+	if !event.CanBeAttended() {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{fmt.Sprintf("unable to order tickets for a %s event", event.Status)})
+		return
+	}
+
+	payload := dtos.CreateOrder{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	occurrence, ok := resolveOptionalOccurrence(w, o.occurrenceRepository, o.logger, event, payload.Occurrence)
+	if !ok {
+		return
+	}
+
+	if occurrence.Cancelled {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to order tickets for a cancelled occurrence"})
+		return
+	}
+
+	if occurrence.HasEnded(time.Now()) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"unable to order tickets for an event that has already ended"})
+		return
+	}
+
+	order, err := o.orderService.PlaceOrder(user.ID, occurrence, payload)
+	if err != nil {
+		o.logger.Errorf(err, "unable to place order of user %s for event %s", user.ID, event.ID)
+		switch {
+		case errors.Is(err, models.ErrUnknownTicketType), errors.Is(err, models.ErrTicketTypeNotOnSale), errors.Is(err, models.ErrMixedCurrencies),
+			errors.Is(err, repository.ErrTicketTypeNotFound), errors.Is(err, repository.ErrEventNotOpen):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+		case errors.Is(err, repository.ErrTicketsUnavailable):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+		case errors.Is(err, repository.ErrEventNotFound):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, order)
+}
+
+// HandleListEventOrders lists the orders of the event, or only those of the occurrence query parameter.
+func (o jwtOrderRoutes) HandleListEventOrders(w http.ResponseWriter, r *http.Request) {
+	// Load the event and ensure the requesting user is permitted to manage its orders.
+	event, ok := loadEventForStaff(w, r, o.UserContextHelpers, o.eventRepository, o.staffRepository, o.logger, r.PathValue("id"), types.ManageOrdersPermission)
+	if !ok {
+		return
+	}
+
+	var occurrence *time.Time
+	if len(r.URL.Query().Get("occurrence")) > 0 {
+		resolved, ok := requestOccurrence(w, r, o.occurrenceRepository, o.logger, event)
+		if !ok {
+			return
+		}
+		occurrence = &resolved.OccurrenceStart
+	}
+
+	orders, err := o.orderRepository.ListOrdersForEvent(event.ID, occurrence)
+	if err != nil {
+		o.logger.Errorf(err, "unable to list orders of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, orders)
+}
+
+func (o jwtOrderRoutes) HandleGetOrderById(w http.ResponseWriter, r *http.Request) {
+	order, _, _, ok := o.loadOrder(w, r)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, order)
+}
+
+// HandleChangeOrderStatus moves the order through its lifecycle.
+// The user who placed a pending order may cancel it, while marking orders as paid or refunded is left to the events organizer, its co-organizers and admins.
+func (o jwtOrderRoutes) HandleChangeOrderStatus(w http.ResponseWriter, r *http.Request) {
+	order, user, event, ok := o.loadOrder(w, r)
+	if !ok {
+		return
+	}
+
+	payload := dtos.ChangeOrderStatus{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	if !order.Status.CanTransitionTo(payload.Status) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{fmt.Sprintf("unable to change the status of a %s order to %s", order.Status, payload.Status)})
+		return
+	}
+
+	if payload.Status != types.CancelledOrderStatus {
+		permitted, err := eventPermits(o.staffRepository, event, user, types.ManageOrdersPermission)
+		if err != nil {
+			o.logger.Errorf(err, "unable to load staff of event %s", event.ID)
+			utils.WriteInternalErrorJsonResponse(w)
+			return
+		}
+		if !permitted {
+			writeEventPermissionError(w, types.ManageOrdersPermission)
+			return
+		}
+	}
+
+	now := time.Now()
+	if payload.Status == types.PaidOrderStatus && order.IsExpired(now) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{"order has expired and its tickets were released, place a new order instead"})
+		return
+	}
+
+	previous := order.Status
+	var err error
+	if payload.Status == types.RefundedOrderStatus {
+		// refunding an order also refunds its payment through the payment provider.
+		err = o.orderService.RefundOrder(order)
+	} else {
+		order.ChangeStatus(payload.Status, now)
+		err = o.orderRepository.ChangeOrderStatus(order, previous)
+	}
+
+	if err != nil {
+		o.logger.Errorf(err, "unable to change status of order %s", order.ID)
+		switch {
+		case errors.Is(err, service.ErrPaymentIntentNotFound), errors.Is(err, service.ErrPaymentNotRefundable):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.PaymentFailed, http.StatusBadGateway, []string{err.Error()})
+		case errors.Is(err, repository.ErrOrderStatusConflict):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+		case errors.Is(err, repository.ErrEventNotFound):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	o.logger.Infof("order %s of user %s for event %s changed from %s to %s", order.ID, order.UserID, order.EventID, previous, order.Status)
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, order)
+}
+
+// HandleStartOrderPayment starts the payment of a pending order placed by the user, responding with the payment intent to pay against.
+func (o jwtOrderRoutes) HandleStartOrderPayment(w http.ResponseWriter, r *http.Request) {
+	order, user, ok := o.loadOwnOrder(w, r)
+	if !ok {
+		return
+	}
+
+	intent, err := o.orderService.StartPayment(order)
+	if err != nil {
+		o.logger.Errorf(err, "unable to start payment of order %s", order.ID)
+		writeOrderPaymentError(w, err)
+		return
+	}
+
+	o.logger.Infof("user %s started payment %s of order %s", user.ID, intent.ID, order.ID)
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, intent)
+}
+
+// HandleConfirmOrderPayment takes the payment of an order placed by the user, marking it as paid and making them an attendee of the ordered occurrence.
+func (o jwtOrderRoutes) HandleConfirmOrderPayment(w http.ResponseWriter, r *http.Request) {
+	order, _, ok := o.loadOwnOrder(w, r)
+	if !ok {
+		return
+	}
+
+	if err := o.orderService.ConfirmPayment(order); err != nil {
+		o.logger.Errorf(err, "unable to confirm payment of order %s", order.ID)
+		writeOrderPaymentError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, order)
+}
+
+func (o jwtOrderRoutes) HandleListMyOrders(w http.ResponseWriter, r *http.Request) {
+	user, err := o.LoadUserFromContext(r)
+	if err != nil {
+		o.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	orders, err := o.orderRepository.ListOrdersForUser(user.ID)
+	if err != nil {
+		o.logger.Errorf(err, "unable to list orders of user %s", user.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, orders)
+}
+
+// loadOrder loads the order with the id within the request path along with its event, ensuring that the user within the request context placed it, organizes its event or is an admin.
+// Writes an error response and returns false when the order could not be loaded, orders of other users are reported as not found.
+func (o jwtOrderRoutes) loadOrder(w http.ResponseWriter, r *http.Request) (*models.OrderModel, *models.UserModel, *models.EventModel, bool) {
+	user, err := o.LoadUserFromContext(r)
+	if err != nil {
+		o.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return nil, nil, nil, false
+	}
+
+	id := r.PathValue("id")
+
+	order, err := o.orderRepository.GetOrderByID(id)
+	if err != nil {
+		o.logger.Errorf(err, "unable to find order with id: %s", id)
+		switch {
+		case errors.Is(err, repository.ErrOrderNotFound):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+		case errors.Is(err, repository.ErrRepoConnErr):
+			utils.WriteInternalErrorJsonResponse(w)
+		default:
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+		}
+		return nil, nil, nil, false
+	}
+
+	event, err := o.eventRepository.GetEventByID(order.EventID)
+	if err != nil {
+		o.logger.Errorf(err, "unable to find event with id: %s", order.EventID)
+		writeEventLookupError(w, err)
+		return nil, nil, nil, false
+	}
+
+	if !order.IsPlacedBy(user.ID) {
+		permitted, err := eventPermits(o.staffRepository, event, user, types.ManageOrdersPermission)
+		if err != nil {
+			o.logger.Errorf(err, "unable to load staff of event %s", event.ID)
+			utils.WriteInternalErrorJsonResponse(w)
+			return nil, nil, nil, false
+		}
+		if !permitted {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{repository.ErrOrderNotFound.Error()})
+			return nil, nil, nil, false
+		}
+	}
+
+	return order, user, event, true
+}
+
+// loadOwnOrder loads the order with the id within the request path, which must have been placed by the user within the request context.
+// Writes an error response and returns false when the order could not be loaded.
+func (o jwtOrderRoutes) loadOwnOrder(w http.ResponseWriter, r *http.Request) (*models.OrderModel, *models.UserModel, bool) {
+	order, user, _, ok := o.loadOrder(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if !order.IsPlacedBy(user.ID) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{"only the user who placed the order can pay for it"})
+		return nil, nil, false
+	}
+
+	return order, user, true
+}
+
+// writeOrderPaymentError writes the error response for a failure to pay for an order.
+func writeOrderPaymentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrOrderNotPayable), errors.Is(err, service.ErrPaymentNotStarted), errors.Is(err, repository.ErrOrderStatusConflict):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+	case errors.Is(err, service.ErrPaymentDeclined), errors.Is(err, service.ErrPaymentIntentNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.PaymentFailed, http.StatusPaymentRequired, []string{err.Error()})
+	case errors.Is(err, repository.ErrEventNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	default:
+		utils.WriteInternalErrorJsonResponse(w)
+	}
+}
@@ -0,0 +1,231 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtOrganizerApplicationRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	applicationService     service.OrganizerApplicationService
+	applicationRepository  repository.OrganizerApplicationRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenOrganizerApplicationRoutes creates routes using OrganizerApplicationService, OrganizerApplicationRepository and JsonWebTokenService then mounts them to the provided router.
+// Users apply to become organizers, and admins approve or reject the applications within the review queue.
+func NewJsonWebTokenOrganizerApplicationRoutes(router net.AppRouter, applicationService service.OrganizerApplicationService, applicationRepository repository.OrganizerApplicationRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtOrganizerApplicationRoutes {
+	routes := jwtOrganizerApplicationRoutes{
+		/* inject dependencies */
+		applicationService:    applicationService,
+		applicationRepository: applicationRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "OrganizerApplicationRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "OrganizerApplicationRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize a permission middleware (factory) to declare the permissions required by each of the routes.
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger:         logging.NewContextLogger(lw, "OrganizerApplicationRoutes.PermissionMiddleware"),
+		UserRepository: userRepository,
+	}
+	reviewApplications := permissionMiddleware.Require(types.ReviewOrganizerApplicationsPermission)
+	// applicants must verify their email address, as admins rely on it to contact them.
+	verifiedApplicant := permissionMiddleware.RequireVerified()
+
+	// mount routes to router.
+	router.Post(
+		"/api/me/organizer-application",
+		protectMiddleware.BeforeNext(verifiedApplicant.BeforeNext(http.HandlerFunc(routes.HandleApply))),
+	)
+	router.Get(
+		"/api/me/organizer-application",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetMyApplication)),
+	)
+	router.Get(
+		"/api/organizer-applications",
+		protectMiddleware.BeforeNext(reviewApplications.BeforeNext(http.HandlerFunc(routes.HandleListApplications))),
+	)
+	router.Get(
+		"/api/organizer-applications/{id}",
+		protectMiddleware.BeforeNext(reviewApplications.BeforeNext(http.HandlerFunc(routes.HandleGetApplicationById))),
+	)
+	router.Put(
+		"/api/organizer-applications/{id}/review",
+		protectMiddleware.BeforeNext(reviewApplications.BeforeNext(http.HandlerFunc(routes.HandleReviewApplication))),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/me/organizer-application", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/organizer-applications", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/organizer-applications/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/organizer-applications/{id}/review", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleApply submits the application of the requesting user to become an organizer.
+func (a jwtOrganizerApplicationRoutes) HandleApply(w http.ResponseWriter, r *http.Request) {
+	user, err := a.LoadUserFromContext(r)
+	if err != nil {
+		a.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	payload := dtos.ApplyForOrganizer{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	application, err := a.applicationService.Apply(user, payload)
+	if err != nil {
+		a.logger.Errorf(err, "unable to submit organizer application of user %s", user.ID)
+		switch {
+		case errors.Is(err, repository.ErrApplicationPending), errors.Is(err, service.ErrAlreadyOrganizer):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, application)
+}
+
+// HandleGetMyApplication responds with the most recent application of the requesting user, including the outcome of its review.
+func (a jwtOrganizerApplicationRoutes) HandleGetMyApplication(w http.ResponseWriter, r *http.Request) {
+	userId, err := a.LoadUserIdFromContext(r)
+	if err != nil {
+		a.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	application, err := a.applicationRepository.GetLatestApplicationForUser(userId)
+	if err != nil {
+		a.logger.Errorf(err, "unable to find organizer application of user %s", userId)
+		writeApplicationLookupError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, application)
+}
+
+// HandleListApplications responds with the review queue, the pending applications oldest first, or the applications with the status within the query.
+func (a jwtOrganizerApplicationRoutes) HandleListApplications(w http.ResponseWriter, r *http.Request) {
+	query := dtos.ListOrganizerApplications{}
+	if errs := query.ReadQuery(r.URL.Query()); len(errs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, errs)
+		return
+	}
+
+	// custom validation
+	if validationErrs := query.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	applications, err := a.applicationRepository.ListApplications(query.Status)
+	if err != nil {
+		a.logger.Error(err, "unable to list organizer applications")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, applications)
+}
+
+func (a jwtOrganizerApplicationRoutes) HandleGetApplicationById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	application, err := a.applicationRepository.GetApplicationByID(id)
+	if err != nil {
+		a.logger.Errorf(err, "unable to find organizer application with id: %s", id)
+		writeApplicationLookupError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, application)
+}
+
+// HandleReviewApplication approves or rejects the pending application, approving it makes the applicant an organizer.
+func (a jwtOrganizerApplicationRoutes) HandleReviewApplication(w http.ResponseWriter, r *http.Request) {
+	user, err := a.LoadUserFromContext(r)
+	if err != nil {
+		a.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	payload := dtos.ReviewOrganizerApplication{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	application, err := a.applicationService.Review(id, user, payload)
+	if err != nil {
+		a.logger.Errorf(err, "unable to review organizer application with id: %s", id)
+		if errors.Is(err, repository.ErrApplicationReviewed) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+			return
+		}
+		writeApplicationLookupError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, application)
+}
+
+// writeApplicationLookupError writes the error response for a failure to load an organizer application from the repository.
+func writeApplicationLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrApplicationNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrInvalidApplicationId):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	default:
+		utils.WriteInternalErrorJsonResponse(w)
+	}
+}
@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// PaymentSignatureHeader the header carrying the signature of payment webhook requests.
+const PaymentSignatureHeader = "X-Payment-Signature"
+
+type paymentWebhookRoutes struct {
+	orderService service.OrderService
+	logger       logging.Logger
+}
+
+// NewPaymentWebhookRoutes creates the route receiving webhook events of the payment provider using OrderService and mounts it to the provided router.
+// Requests are authenticated by their signature rather than a bearer token, as they are sent by the payment provider.
+func NewPaymentWebhookRoutes(router net.AppRouter, orderService service.OrderService, lw logging.LogWriter) *paymentWebhookRoutes {
+	routes := &paymentWebhookRoutes{
+		orderService: orderService,
+		logger:       logging.NewContextLogger(lw, "PaymentWebhookRoutes"),
+	}
+
+	router.Post("/api/payments/webhook", http.HandlerFunc(routes.HandlePaymentWebhook))
+
+	// Add basic preflight handlers
+	router.Options("/api/payments/webhook", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandlePaymentWebhook applies a signed payment event to the order it concerns.
+// Processed events respond with success even when delivered again, so the provider stops retrying them.
+func (p *paymentWebhookRoutes) HandlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	// the signature covers the exact body, so it is read as is rather than decoded.
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, constants.MAX_BODY_SIZE))
+	if err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	if err := p.orderService.HandlePaymentWebhook(payload, r.Header.Get(PaymentSignatureHeader)); err != nil {
+		p.logger.Error(err, "unable to handle payment webhook")
+		switch {
+		case errors.Is(err, service.ErrInvalidWebhookSignature):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidCredentials, http.StatusUnauthorized, []string{err.Error()})
+		case errors.Is(err, service.ErrInvalidWebhookPayload):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+		case errors.Is(err, service.ErrPaymentMismatch):
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusUnprocessableEntity, []string{err.Error()})
+		default:
+			utils.WriteInternalErrorJsonResponse(w)
+		}
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
@@ -0,0 +1,348 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtReviewRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	reviewRepository       repository.ReviewRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	attendanceRepository   repository.AttendanceRepository
+	userRepository         repository.UserRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenReviewRoutes creates routes using ReviewRepository, EventRepository, AttendanceRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenReviewRoutes(router net.AppRouter, reviewRepository repository.ReviewRepository, eventRepository repository.EventRepository, attendanceRepository repository.AttendanceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtReviewRoutes {
+	routes := jwtReviewRoutes{
+		/* inject dependencies */
+		reviewRepository:     reviewRepository,
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		attendanceRepository: attendanceRepository,
+		userRepository:       userRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "ReviewRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "ReviewRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/events/{id}/reviews",
+		http.HandlerFunc(routes.HandleListEventReviews),
+	)
+	router.Post(
+		"/api/events/{id}/reviews",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCreateReview)),
+	)
+	router.Get(
+		"/api/events/{id}/ratings",
+		http.HandlerFunc(routes.HandleGetEventRatings),
+	)
+	router.Get(
+		"/api/organizers/{id}/ratings",
+		http.HandlerFunc(routes.HandleGetOrganizerRatings),
+	)
+	router.Get(
+		"/api/reviews/{id}",
+		http.HandlerFunc(routes.HandleGetReviewById),
+	)
+	router.Put(
+		"/api/reviews/{id}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUpdateReviewById)),
+	)
+	router.Delete(
+		"/api/reviews/{id}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDeleteReviewById)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/reviews", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/ratings", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/organizers/{id}/ratings", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/reviews/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+func (rv jwtReviewRoutes) HandleListEventReviews(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, rv.UserContextHelpers, rv.eventRepository, rv.staffRepository, rv.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	reviews, err := rv.reviewRepository.ListReviewsForEvent(event.ID)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to list reviews of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, reviews)
+}
+
+func (rv jwtReviewRoutes) HandleGetEventRatings(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, rv.UserContextHelpers, rv.eventRepository, rv.staffRepository, rv.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	summary, err := rv.reviewRepository.GetEventRatingSummary(event.ID)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to get rating summary of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, summary)
+}
+
+func (rv jwtReviewRoutes) HandleGetOrganizerRatings(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	organizer, err := rv.userRepository.GetUserByID(id)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to find user with id: %s", id)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrRepoConnErr) {
+			utils.WriteInternalErrorJsonResponse(w)
+			return
+		}
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+		return
+	}
+
+	summary, err := rv.reviewRepository.GetOrganizerRatingSummary(organizer.ID)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to get rating summary of organizer %s", organizer.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, summary)
+}
+
+func (rv jwtReviewRoutes) HandleCreateReview(w http.ResponseWriter, r *http.Request) {
+	user, err := rv.LoadUserFromContext(r)
+	if err != nil {
+		rv.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	event, ok := loadVisibleEvent(w, r, rv.UserContextHelpers, rv.eventRepository, rv.staffRepository, rv.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	if !event.CanBeReviewed(time.Now()) {
+		message := "unable to review an event that has not yet ended"
+		if event.Status == types.CancelledEventStatus {
+			message = "unable to review a cancelled event"
+		}
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{message})
+		return
+	}
+
+	// Ensure that only users who attended the event can review it.
+	attended, err := rv.attendanceRepository.IsAttending(event.ID, user.ID)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to check attendance of user %s for event %s", user.ID, event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+	if !attended {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{"only attendees of the event can review it"})
+		return
+	}
+
+	payload := dtos.CreateOrUpdateReview{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	review := &models.ReviewModel{
+		EventID:  event.ID,
+		AuthorID: user.ID,
+	}
+	review.UpdateFrom(payload)
+
+	if err := rv.reviewRepository.CreateReview(review); err != nil {
+		rv.logger.Error(err, "unable to create review")
+		if errors.Is(err, repository.ErrAlreadyReviewed) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, review)
+}
+
+func (rv jwtReviewRoutes) HandleGetReviewById(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	review, err := rv.reviewRepository.GetReviewByID(id)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to find review with id: %s", id)
+		writeReviewLookupError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, review)
+}
+
+func (rv jwtReviewRoutes) HandleUpdateReviewById(w http.ResponseWriter, r *http.Request) {
+	user, err := rv.LoadUserFromContext(r)
+	if err != nil {
+		rv.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	review, err := rv.reviewRepository.GetReviewByID(id)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to find review with id: %s", id)
+		writeReviewLookupError(w, err)
+		return
+	}
+
+	// Ensure that only the author is able to change the content of their review.
+	if !review.IsAuthoredBy(user.ID) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{"only the author can edit this review"})
+		return
+	}
+
+	payload := dtos.CreateOrUpdateReview{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.ValidatePartial(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	// updates the review from the payload
+	review.UpdateFrom(payload)
+
+	// submit the changes
+	if err := rv.reviewRepository.UpdateReview(review); err != nil {
+		rv.logger.Error(err, "unable to update review")
+		if errors.Is(err, repository.ErrReviewNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, review)
+}
+
+func (rv jwtReviewRoutes) HandleDeleteReviewById(w http.ResponseWriter, r *http.Request) {
+	user, err := rv.LoadUserFromContext(r)
+	if err != nil {
+		rv.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	review, err := rv.reviewRepository.GetReviewByID(id)
+	if err != nil {
+		rv.logger.Errorf(err, "unable to find review with id: %s", id)
+		writeReviewLookupError(w, err)
+		return
+	}
+
+	// Reviews can be removed by their author, or moderated by the events organizer, its moderators and admins.
+	if !review.IsAuthoredBy(user.ID) {
+		event, err := rv.eventRepository.GetEventByID(review.EventID)
+		if err != nil {
+			rv.logger.Errorf(err, "unable to find event with id: %s", review.EventID)
+			writeEventLookupError(w, err)
+			return
+		}
+		permitted, err := eventPermits(rv.staffRepository, event, user, types.ModerateReviewsPermission)
+		if err != nil {
+			rv.logger.Errorf(err, "unable to load staff of event %s", event.ID)
+			utils.WriteInternalErrorJsonResponse(w)
+			return
+		}
+		if !permitted {
+			writeEventPermissionError(w, types.ModerateReviewsPermission)
+			return
+		}
+	}
+
+	if err := rv.reviewRepository.DeleteReview(review.ID); err != nil {
+		rv.logger.Errorf(err, "failed to delete review with id %s", review.ID)
+		if errors.Is(err, repository.ErrReviewNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// writeReviewLookupError writes the error response for a failure to load a review from the repository.
+func writeReviewLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrReviewNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrRepoConnErr):
+		utils.WriteInternalErrorJsonResponse(w)
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"net/http"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
 )
 
 type UserContextHelpers struct {
@@ -51,3 +53,12 @@ func (h UserContextHelpers) LoadUserFromContextWithRole(r *http.Request, role ty
 var (
 	ErrMissingUserContext = errors.New("no user context provided") // ErrMissingUserContext is returned when no context is found while attempting to load user from http.Requests context.
 )
+
+// writeUserContextError writes the error response for a failure to load the requesting user from the request context.
+func writeUserContextError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repository.ErrRepoConnErr) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
+		return
+	}
+	utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
+}
@@ -0,0 +1,104 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtSessionRoutes struct {
+	authService service.AuthenticationService
+	logger      logging.Logger
+}
+
+// NewJsonWebTokenSessionRoutes creates routes using AuthenticationService and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenSessionRoutes(router net.AppRouter, authService service.AuthenticationService, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtSessionRoutes {
+	routes := jwtSessionRoutes{
+		/* inject dependencies */
+		authService: authService,
+		logger:      logging.NewContextLogger(lw, "SessionRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "SessionRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/me/sessions",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListSessions)),
+	)
+	// ending all sessions from here keeps the current session, logging out of all devices is done through /api/auth/logout/all.
+	router.Delete(
+		"/api/me/sessions",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleEndOtherSessions)),
+	)
+	router.Delete(
+		"/api/me/sessions/{id}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleEndSession)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/me/sessions", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/sessions/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleListSessions responds with the active sessions of the user within the request context, along with the device and when each was last used.
+func (s jwtSessionRoutes) HandleListSessions(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+
+	sessions, err := s.authService.ListSessions(user)
+	if err != nil {
+		s.logger.Errorf(err, "unable to list sessions of user %s", user.Id)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, sessions)
+}
+
+// HandleEndSession ends a session of the user within the request context, logging out the device it was started on.
+func (s jwtSessionRoutes) HandleEndSession(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+	id := r.PathValue("id")
+
+	if err := s.authService.EndSession(w, user, id); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		s.logger.Errorf(err, "unable to end session %s of user %s", id, user.Id)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// HandleEndOtherSessions ends every session of the user within the request context other than the current session, logging out their other devices.
+func (s jwtSessionRoutes) HandleEndOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+
+	if _, err := s.authService.EndAllSessions(w, user, true); err != nil {
+		s.logger.Errorf(err, "unable to end sessions of user %s", user.Id)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
@@ -0,0 +1,319 @@
+package routes
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtStaffRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	staffRepository        repository.EventStaffRepository
+	eventRepository        repository.EventRepository
+	userRepository         repository.UserRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenStaffRoutes creates routes using EventStaffRepository, EventRepository and JsonWebTokenService then mounts them to the provided router.
+// The organizer of an event invites users to its staff, whose role takes effect once they accept the invitation.
+func NewJsonWebTokenStaffRoutes(router net.AppRouter, staffRepository repository.EventStaffRepository, eventRepository repository.EventRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtStaffRoutes {
+	routes := jwtStaffRoutes{
+		/* inject dependencies */
+		staffRepository: staffRepository,
+		eventRepository: eventRepository,
+		userRepository:  userRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "StaffRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "StaffRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/events/{id}/staff",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListStaff)),
+	)
+	router.Post(
+		"/api/events/{id}/staff",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleInviteStaff)),
+	)
+	router.Post(
+		"/api/events/{id}/staff/accept",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleAcceptInvitation)),
+	)
+	router.Put(
+		"/api/events/{id}/staff/{userId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleChangeStaffRole)),
+	)
+	router.Delete(
+		"/api/events/{id}/staff/{userId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleRemoveStaff)),
+	)
+	router.Get(
+		"/api/me/staff-invitations",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListMyInvitations)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/staff", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/staff/accept", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/staff/{userId}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/me/staff-invitations", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleListStaff responds with the staff of the event, including pending invitations.
+func (s jwtStaffRoutes) HandleListStaff(w http.ResponseWriter, r *http.Request) {
+	// Load the event and ensure the requesting user is permitted to manage its staff.
+	event, ok := loadEventForStaff(w, r, s.UserContextHelpers, s.eventRepository, s.staffRepository, s.logger, r.PathValue("id"), types.ManageStaffPermission)
+	if !ok {
+		return
+	}
+
+	staff, err := s.staffRepository.ListStaff(event.ID)
+	if err != nil {
+		s.logger.Errorf(err, "unable to list staff of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, staff)
+}
+
+// HandleInviteStaff invites the registered user with the email address within the payload to the staff of the event.
+func (s jwtStaffRoutes) HandleInviteStaff(w http.ResponseWriter, r *http.Request) {
+	user, err := s.LoadUserFromContext(r)
+	if err != nil {
+		s.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	// Load the event and ensure the requesting user is permitted to manage its staff.
+	event, ok := loadEventForStaff(w, r, s.UserContextHelpers, s.eventRepository, s.staffRepository, s.logger, r.PathValue("id"), types.ManageStaffPermission)
+	if !ok {
+		return
+	}
+
+	payload := dtos.InviteStaff{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	invitee, err := s.userRepository.GetUserByEmail(payload.Email)
+	if err != nil {
+		s.logger.Errorf(err, "unable to find user with email: %s", payload.Email)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	if event.IsOrganizedBy(invitee.ID) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"the events organizer can not be invited to its staff"})
+		return
+	}
+
+	staff := &models.EventStaffModel{
+		EventID:   event.ID,
+		UserID:    invitee.ID,
+		Role:      payload.Role,
+		InvitedBy: sql.NullString{String: user.ID, Valid: true},
+		Username:  invitee.Username,
+		EventName: event.Name,
+	}
+	if err := s.staffRepository.InviteStaff(staff); err != nil {
+		s.logger.Errorf(err, "unable to invite user %s to the staff of event %s", invitee.ID, event.ID)
+		writeStaffChangeError(w, err)
+		return
+	}
+
+	s.logger.Infof("user %s was invited to the staff of event %s as %s by %s", invitee.ID, event.ID, staff.Role, user.ID)
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, staff)
+}
+
+// HandleAcceptInvitation accepts the pending invitation of the requesting user to the staff of the event, after which their role takes effect.
+func (s jwtStaffRoutes) HandleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	user, err := s.LoadUserFromContext(r)
+	if err != nil {
+		s.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	staff, err := s.staffRepository.GetStaff(id, user.ID)
+	if err != nil {
+		s.logger.Errorf(err, "unable to find invitation of user %s to event %s", user.ID, id)
+		writeStaffLookupError(w, err)
+		return
+	}
+
+	if staff.IsActive() {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{"invitation was already accepted"})
+		return
+	}
+
+	if err := s.staffRepository.AcceptInvitation(staff); err != nil {
+		s.logger.Errorf(err, "unable to accept invitation of user %s to event %s", user.ID, id)
+		if errors.Is(err, repository.ErrStaffNotFound) {
+			// the invitation was accepted or withdrawn concurrently.
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{"invitation is no longer pending"})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, staff)
+}
+
+// HandleChangeStaffRole changes the role of a member of the staff of the event, or of their pending invitation.
+func (s jwtStaffRoutes) HandleChangeStaffRole(w http.ResponseWriter, r *http.Request) {
+	// Load the event and ensure the requesting user is permitted to manage its staff.
+	event, ok := loadEventForStaff(w, r, s.UserContextHelpers, s.eventRepository, s.staffRepository, s.logger, r.PathValue("id"), types.ManageStaffPermission)
+	if !ok {
+		return
+	}
+
+	payload := dtos.ChangeStaffRole{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	userId := r.PathValue("userId")
+
+	staff, err := s.staffRepository.GetStaff(event.ID, userId)
+	if err != nil {
+		s.logger.Errorf(err, "unable to find staff member %s of event %s", userId, event.ID)
+		writeStaffLookupError(w, err)
+		return
+	}
+
+	staff.Role = payload.Role
+	if err := s.staffRepository.ChangeStaffRole(staff); err != nil {
+		s.logger.Errorf(err, "unable to change role of staff member %s of event %s", userId, event.ID)
+		writeStaffChangeError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, staff)
+}
+
+// HandleRemoveStaff removes a member from the staff of the event, or withdraws their pending invitation.
+// Staff may also remove themselves, to leave the staff of an event or decline their invitation.
+func (s jwtStaffRoutes) HandleRemoveStaff(w http.ResponseWriter, r *http.Request) {
+	user, err := s.LoadUserFromContext(r)
+	if err != nil {
+		s.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+	userId := r.PathValue("userId")
+
+	if userId != user.ID {
+		// Load the event and ensure the requesting user is permitted to manage its staff.
+		if _, ok := loadEventForStaff(w, r, s.UserContextHelpers, s.eventRepository, s.staffRepository, s.logger, id, types.ManageStaffPermission); !ok {
+			return
+		}
+	}
+
+	if err := s.staffRepository.RemoveStaff(id, userId); err != nil {
+		s.logger.Errorf(err, "unable to remove staff member %s of event %s", userId, id)
+		writeStaffChangeError(w, err)
+		return
+	}
+
+	s.logger.Infof("user %s was removed from the staff of event %s by %s", userId, id, user.ID)
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// HandleListMyInvitations responds with the pending invitations of the requesting user to the staff of upcoming events.
+func (s jwtStaffRoutes) HandleListMyInvitations(w http.ResponseWriter, r *http.Request) {
+	user, err := s.LoadUserFromContext(r)
+	if err != nil {
+		s.logger.Error(err, "failed to load user from context")
+		writeUserContextError(w, err)
+		return
+	}
+
+	invitations, err := s.staffRepository.ListInvitationsForUser(user.ID)
+	if err != nil {
+		s.logger.Errorf(err, "unable to list staff invitations of user %s", user.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, invitations)
+}
+
+// writeStaffLookupError writes the error response for a failure to load a staff member from the repository.
+func writeStaffLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrStaffNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrRepoConnErr):
+		utils.WriteInternalErrorJsonResponse(w)
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	}
+}
+
+// writeStaffChangeError writes the error response for a failure to invite, change or remove a staff member.
+func writeStaffChangeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrStaffConflict):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+	case errors.Is(err, repository.ErrStaffNotFound), errors.Is(err, repository.ErrEventNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	default:
+		utils.WriteInternalErrorJsonResponse(w)
+	}
+}
@@ -0,0 +1,256 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtTagRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	tagRepository          repository.TagRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenTagRoutes creates routes using TagRepository, EventRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenTagRoutes(router net.AppRouter, tagRepository repository.TagRepository, eventRepository repository.EventRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtTagRoutes {
+	routes := jwtTagRoutes{
+		/* inject dependencies */
+		tagRepository:   tagRepository,
+		eventRepository: eventRepository,
+		staffRepository: staffRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "TagRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "TagRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize an optional variant of the protect middleware, allowing organizers to view their draft events.
+	optionalMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "TagRoutes.OptionalJWTBearerMiddleware"),
+		JWTService: *jwtService,
+		Optional:   true,
+	}
+
+	// initialize a permission middleware (factory) to declare the permissions required by each of the routes.
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger:         logging.NewContextLogger(lw, "TagRoutes.PermissionMiddleware"),
+		UserRepository: userRepository,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/tags",
+		http.HandlerFunc(routes.HandleSearchTags),
+	)
+	router.Get(
+		"/api/tags/popular",
+		http.HandlerFunc(routes.HandleListPopularTags),
+	)
+	router.Post(
+		"/api/tags",
+		protectMiddleware.BeforeNext(permissionMiddleware.Require(types.CreateTagPermission).BeforeNext(http.HandlerFunc(routes.HandleCreateTag))),
+	)
+	router.Get(
+		"/api/events/{id}/tags",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListEventTags)),
+	)
+	router.Put(
+		"/api/events/{id}/tags/{tagId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleAttachTag)),
+	)
+	router.Delete(
+		"/api/events/{id}/tags/{tagId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDetachTag)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/tags", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/tags/popular", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/tags", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/tags/{tagId}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleSearchTags responds with the tags starting with the 'q' query parameter, for autocompleting tag names.
+func (t jwtTagRoutes) HandleSearchTags(w http.ResponseWriter, r *http.Request) {
+	query, ok := t.readListTagsQuery(w, r)
+	if !ok {
+		return
+	}
+
+	tags, err := t.tagRepository.SearchTags(query.Query, query.Limit)
+	if err != nil {
+		t.logger.Error(err, "unable to search tags")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, tags)
+}
+
+func (t jwtTagRoutes) HandleListPopularTags(w http.ResponseWriter, r *http.Request) {
+	query, ok := t.readListTagsQuery(w, r)
+	if !ok {
+		return
+	}
+
+	tags, err := t.tagRepository.ListPopularTags(query.Limit)
+	if err != nil {
+		t.logger.Error(err, "unable to list popular tags")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, tags)
+}
+
+// HandleCreateTag creates the tag, responding with the existing tag instead when one with the same name already exists.
+func (t jwtTagRoutes) HandleCreateTag(w http.ResponseWriter, r *http.Request) {
+	payload := dtos.CreateTag{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	tag := models.NewTag(payload)
+
+	created, err := t.tagRepository.CreateTag(tag)
+	if err != nil {
+		t.logger.Error(err, "unable to create tag")
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	if !created {
+		utils.WriteSuccessJsonResponse(w, http.StatusOK, tag)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, tag)
+}
+
+func (t jwtTagRoutes) HandleListEventTags(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, t.UserContextHelpers, t.eventRepository, t.staffRepository, t.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	t.writeEventTags(w, event.ID)
+}
+
+func (t jwtTagRoutes) HandleAttachTag(w http.ResponseWriter, r *http.Request) {
+	t.handleEventTagChange(w, r, t.tagRepository.AttachTag)
+}
+
+func (t jwtTagRoutes) HandleDetachTag(w http.ResponseWriter, r *http.Request) {
+	t.handleEventTagChange(w, r, t.tagRepository.DetachTag)
+}
+
+// handleEventTagChange applies the change to the event and tag in the path, responding with the tags of the event after the change.
+// Only the events organizer, its co-organizers or an admin can change the tags of an event.
+func (t jwtTagRoutes) handleEventTagChange(w http.ResponseWriter, r *http.Request, change func(eventId string, tagId string) error) {
+	event, ok := loadEventForStaff(w, r, t.UserContextHelpers, t.eventRepository, t.staffRepository, t.logger, r.PathValue("id"), types.ManageEventPermission)
+	if !ok {
+		return
+	}
+
+	tagId := r.PathValue("tagId")
+
+	tag, err := t.tagRepository.GetTagByID(tagId)
+	if err != nil {
+		t.logger.Errorf(err, "unable to find tag with id: %s", tagId)
+		writeTagLookupError(w, err)
+		return
+	}
+
+	if err := change(event.ID, tag.ID); err != nil {
+		t.logger.Errorf(err, "unable to change tag %s of event %s", tag.ID, event.ID)
+		if errors.Is(err, repository.ErrEventNotFound) || errors.Is(err, repository.ErrTagNotFound) {
+			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+			return
+		}
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	t.writeEventTags(w, event.ID)
+}
+
+func (t jwtTagRoutes) writeEventTags(w http.ResponseWriter, eventId string) {
+	tags, err := t.tagRepository.ListTagsForEvent(eventId)
+	if err != nil {
+		t.logger.Errorf(err, "unable to list tags of event %s", eventId)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, tags)
+}
+
+// readListTagsQuery reads and validates the query parameters of a request to list tags, applying the default limit.
+// Writes an error response and returns false when the query parameters are invalid.
+func (t jwtTagRoutes) readListTagsQuery(w http.ResponseWriter, r *http.Request) (*dtos.ListTags, bool) {
+	query := &dtos.ListTags{}
+	if parseErrs := query.ReadQuery(r.URL.Query()); len(parseErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, parseErrs)
+		return nil, false
+	}
+
+	// custom validation
+	if validationErrs := query.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return nil, false
+	}
+
+	if query.Limit < 1 {
+		query.Limit = repository.DefaultTagPageSize
+	}
+
+	return query, true
+}
+
+// writeTagLookupError writes the error response for a failure to load a tag from the repository.
+func writeTagLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTagNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrRepoConnErr):
+		utils.WriteInternalErrorJsonResponse(w)
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	}
+}
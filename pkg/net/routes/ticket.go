@@ -0,0 +1,265 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+type jwtTicketRoutes struct {
+	net.UserContextHelpers // include user context helpers
+	ticketTypeRepository   repository.TicketTypeRepository
+	eventRepository        repository.EventRepository
+	staffRepository        repository.EventStaffRepository
+	occurrenceRepository   repository.OccurrenceRepository
+	logger                 logging.Logger
+}
+
+// NewJsonWebTokenTicketRoutes creates routes using TicketTypeRepository, EventRepository, OccurrenceRepository and JsonWebTokenService then mounts them to the provided router.
+func NewJsonWebTokenTicketRoutes(router net.AppRouter, ticketTypeRepository repository.TicketTypeRepository, eventRepository repository.EventRepository, occurrenceRepository repository.OccurrenceRepository, staffRepository repository.EventStaffRepository, userRepository repository.UserRepository, jwtService *service.JsonWebTokenService, lw logging.LogWriter) jwtTicketRoutes {
+	routes := jwtTicketRoutes{
+		/* inject dependencies */
+		ticketTypeRepository: ticketTypeRepository,
+		eventRepository:      eventRepository,
+		staffRepository:      staffRepository,
+		occurrenceRepository: occurrenceRepository,
+		UserContextHelpers: net.UserContextHelpers{
+			R: &userRepository,
+		},
+		logger: logging.NewContextLogger(lw, "TicketRoutes"),
+	}
+
+	// initialize a protect middleware (factory) to wrap and protect each of the routes.
+	protectMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "TicketRoutes.JWTBearerMiddleware"),
+		JWTService: *jwtService,
+	}
+
+	// initialize an optional variant of the protect middleware, allowing organizers to list the tickets of their draft events.
+	optionalMiddleware := middleware.JWTBearerMiddleware{
+		Logger:     logging.NewContextLogger(lw, "TicketRoutes.OptionalJWTBearerMiddleware"),
+		JWTService: *jwtService,
+		Optional:   true,
+	}
+
+	// mount routes to router.
+	router.Get(
+		"/api/events/{id}/ticket-types",
+		optionalMiddleware.BeforeNext(http.HandlerFunc(routes.HandleListTicketTypes)),
+	)
+	router.Post(
+		"/api/events/{id}/ticket-types",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCreateTicketType)),
+	)
+	router.Put(
+		"/api/events/{id}/ticket-types/{ticketTypeId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUpdateTicketType)),
+	)
+	router.Delete(
+		"/api/events/{id}/ticket-types/{ticketTypeId}",
+		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDeleteTicketType)),
+	)
+
+	// Add basic preflight handlers
+	router.Options("/api/events/{id}/ticket-types", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	router.Options("/api/events/{id}/ticket-types/{ticketTypeId}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return routes
+}
+
+// HandleListTicketTypes lists the ticket types of the event.
+// The tickets still available are included for the occurrence query parameter, or for the only occurrence of events which are not recurring.
+func (t jwtTicketRoutes) HandleListTicketTypes(w http.ResponseWriter, r *http.Request) {
+	event, ok := loadVisibleEvent(w, r, t.UserContextHelpers, t.eventRepository, t.staffRepository, t.logger, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	var occurrence *time.Time
+	if len(r.URL.Query().Get("occurrence")) > 0 || !event.IsRecurring() {
+		resolved, ok := requestOccurrence(w, r, t.occurrenceRepository, t.logger, event)
+		if !ok {
+			return
+		}
+		occurrence = &resolved.OccurrenceStart
+	}
+
+	ticketTypes, err := t.ticketTypeRepository.ListTicketTypes(event.ID, occurrence)
+	if err != nil {
+		t.logger.Errorf(err, "unable to list ticket types of event %s", event.ID)
+		utils.WriteInternalErrorJsonResponse(w)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, ticketTypes)
+}
+
+func (t jwtTicketRoutes) HandleCreateTicketType(w http.ResponseWriter, r *http.Request) {
+	event, ok := t.loadPaidEventForModification(w, r)
+	if !ok {
+		return
+	}
+
+	payload := dtos.CreateOrUpdateTicketType{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.Validate(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	ticketType := models.NewTicketType(event.ID, payload)
+	if err := t.ticketTypeRepository.CreateTicketType(ticketType); err != nil {
+		t.logger.Errorf(err, "unable to create ticket type for event %s", event.ID)
+		writeTicketTypeChangeError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusCreated, ticketType)
+}
+
+func (t jwtTicketRoutes) HandleUpdateTicketType(w http.ResponseWriter, r *http.Request) {
+	event, ok := t.loadPaidEventForModification(w, r)
+	if !ok {
+		return
+	}
+
+	ticketType, ok := t.loadTicketType(w, r, event)
+	if !ok {
+		return
+	}
+
+	payload := dtos.CreateOrUpdateTicketType{}
+	if err := utils.ReadJson(w, r, &payload); err != nil {
+		utils.WriteRequestPayloadError(err, w)
+		return
+	}
+
+	// custom validation
+	if validationErrs := payload.ValidatePartial(); len(validationErrs) > 0 {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, validationErrs)
+		return
+	}
+
+	ticketType.UpdateFrom(payload)
+
+	// dates may have been provided individually, so check the resulting sales window.
+	if ticketType.SalesStart.Valid && ticketType.SalesEnd.Valid && !ticketType.SalesEnd.Time.After(ticketType.SalesStart.Time) {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"sales_end must be after sales_start"})
+		return
+	}
+
+	if err := t.ticketTypeRepository.UpdateTicketType(ticketType); err != nil {
+		t.logger.Errorf(err, "unable to update ticket type %s", ticketType.ID)
+		writeTicketTypeChangeError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, ticketType)
+}
+
+func (t jwtTicketRoutes) HandleDeleteTicketType(w http.ResponseWriter, r *http.Request) {
+	event, ok := t.loadPaidEventForModification(w, r)
+	if !ok {
+		return
+	}
+
+	ticketType, ok := t.loadTicketType(w, r, event)
+	if !ok {
+		return
+	}
+
+	if err := t.ticketTypeRepository.DeleteTicketType(ticketType.ID); err != nil {
+		t.logger.Errorf(err, "unable to delete ticket type %s", ticketType.ID)
+		writeTicketTypeChangeError(w, err)
+		return
+	}
+
+	utils.WriteSuccessJsonResponse(w, http.StatusOK, nil)
+}
+
+// loadPaidEventForModification loads the event with the id within the request path, ensuring that the user within the request context is permitted to manage its tickets.
+// Writes an error response and returns false when the event could not be loaded, or does not sell tickets.
+func (t jwtTicketRoutes) loadPaidEventForModification(w http.ResponseWriter, r *http.Request) (*models.EventModel, bool) {
+	// Load the event and ensure the requesting user is permitted to manage it.
+	event, ok := loadEventForStaff(w, r, t.UserContextHelpers, t.eventRepository, t.staffRepository, t.logger, r.PathValue("id"), types.ManageEventPermission)
+	if !ok {
+		return nil, false
+	}
+
+	if !event.IsPaid {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{"tickets can only be sold for paid events"})
+		return nil, false
+	}
+
+	if event.Status != types.DraftEventStatus && event.Status != types.PublishedEventStatus {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{fmt.Sprintf("unable to change the tickets of a %s event", event.Status)})
+		return nil, false
+	}
+
+	return event, true
+}
+
+// loadTicketType loads the ticket type with the id within the request path, which must belong to the event.
+// Writes an error response and returns false when the ticket type could not be loaded.
+func (t jwtTicketRoutes) loadTicketType(w http.ResponseWriter, r *http.Request, event *models.EventModel) (*models.TicketTypeModel, bool) {
+	id := r.PathValue("ticketTypeId")
+
+	ticketType, err := t.ticketTypeRepository.GetTicketTypeByID(id)
+	if err != nil {
+		t.logger.Errorf(err, "unable to find ticket type with id: %s", id)
+		writeTicketTypeLookupError(w, err)
+		return nil, false
+	}
+
+	if ticketType.EventID != event.ID {
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{repository.ErrTicketTypeNotFound.Error()})
+		return nil, false
+	}
+
+	return ticketType, true
+}
+
+// writeTicketTypeLookupError writes the error response for a failure to load a ticket type from the repository.
+func writeTicketTypeLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTicketTypeNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrRepoConnErr):
+		utils.WriteInternalErrorJsonResponse(w)
+	default:
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusBadRequest, []string{err.Error()})
+	}
+}
+
+// writeTicketTypeChangeError writes the error response for a failure to store changes to a ticket type.
+func writeTicketTypeChangeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTicketTypeNotFound), errors.Is(err, repository.ErrEventNotFound):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.NotFound, http.StatusNotFound, []string{err.Error()})
+	case errors.Is(err, repository.ErrTicketTypeNameConflict), errors.Is(err, repository.ErrTicketTypeOrdered), errors.Is(err, repository.ErrTicketQuantityBelowHeld):
+		utils.WriteErrorJsonResponse(w, constants.ErrorCodes.BadRequest, http.StatusConflict, []string{err.Error()})
+	default:
+		utils.WriteInternalErrorJsonResponse(w)
+	}
+}
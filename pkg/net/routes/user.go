@@ -39,22 +39,29 @@ func NewJsonWebTokenUserRoutes(router net.AppRouter, userRepository repository.U
 		JWTService: *jwtService,
 	}
 
+	// initialize a permission middleware (factory) to declare the permissions required by each of the routes.
+	permissionMiddleware := middleware.PermissionMiddleware{
+		Logger:         logging.NewContextLogger(lw, "UserRoutes.PermissionMiddleware"),
+		UserRepository: userRepository,
+	}
+	manageUsers := permissionMiddleware.Require(types.ManageUsersPermission)
+
 	// mount routes to router.
 	router.Post(
 		"/api/users",
-		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleCreateUser)),
+		protectMiddleware.BeforeNext(manageUsers.BeforeNext(http.HandlerFunc(routes.HandleCreateUser))),
 	)
 	router.Get(
 		"/api/users/{id}",
-		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleGetUserById)),
+		protectMiddleware.BeforeNext(manageUsers.BeforeNext(http.HandlerFunc(routes.HandleGetUserById))),
 	)
 	router.Put(
 		"/api/users/{id}",
-		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleUpdateUserById)),
+		protectMiddleware.BeforeNext(manageUsers.BeforeNext(http.HandlerFunc(routes.HandleUpdateUserById))),
 	)
 	router.Delete(
 		"/api/users/{id}",
-		protectMiddleware.BeforeNext(http.HandlerFunc(routes.HandleDeleteUserById)),
+		protectMiddleware.BeforeNext(manageUsers.BeforeNext(http.HandlerFunc(routes.HandleDeleteUserById))),
 	)
 
 	// Add basic preflight handlers
@@ -69,17 +76,6 @@ func NewJsonWebTokenUserRoutes(router net.AppRouter, userRepository repository.U
 }
 
 func (u jwtUserRoutes) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
-	// Ensure that a valid user with the "admin" role is accessing this api.
-	if _, err := u.LoadUserFromContextWithRole(r, types.AdminRole); err != nil {
-		u.logger.Error(err, "failed to load user from context")
-		if errors.Is(err, repository.ErrRepoConnErr) {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
-		} else {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
-		}
-		return
-	}
-
 	payload := dtos.CreateOrUpdateUser{}
 	if err := utils.ReadJson(w, r, &payload); err != nil {
 		utils.WriteRequestPayloadError(err, w)
@@ -105,17 +101,6 @@ func (u jwtUserRoutes) HandleCreateUser(w http.ResponseWriter, r *http.Request)
 }
 
 func (u jwtUserRoutes) HandleGetUserById(w http.ResponseWriter, r *http.Request) {
-	// Ensure that a valid user with the "admin" role is accessing this api.
-	if _, err := u.LoadUserFromContextWithRole(r, types.AdminRole); err != nil {
-		u.logger.Error(err, "failed to load user from context")
-		if errors.Is(err, repository.ErrRepoConnErr) {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
-		} else {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
-		}
-		return
-	}
-
 	id := r.PathValue("id")
 
 	user, err := u.userRepository.GetUserByID(id)
@@ -133,17 +118,6 @@ func (u jwtUserRoutes) HandleGetUserById(w http.ResponseWriter, r *http.Request)
 }
 
 func (u jwtUserRoutes) HandleUpdateUserById(w http.ResponseWriter, r *http.Request) {
-	// Ensure that a valid user with the "admin" role is accessing this api.
-	if _, err := u.LoadUserFromContextWithRole(r, types.AdminRole); err != nil {
-		u.logger.Error(err, "failed to load user from context")
-		if errors.Is(err, repository.ErrRepoConnErr) {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
-		} else {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
-		}
-		return
-	}
-
 	id := r.PathValue("id")
 
 	// Load the user first
@@ -182,17 +156,6 @@ func (u jwtUserRoutes) HandleUpdateUserById(w http.ResponseWriter, r *http.Reque
 }
 
 func (u jwtUserRoutes) HandleDeleteUserById(w http.ResponseWriter, r *http.Request) {
-	// Ensure that a valid user with the "admin" role is accessing this api.
-	if _, err := u.LoadUserFromContextWithRole(r, types.AdminRole); err != nil {
-		u.logger.Error(err, "failed to load user from context")
-		if errors.Is(err, repository.ErrRepoConnErr) {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.InternalServerError, http.StatusInternalServerError, []string{err.Error()})
-		} else {
-			utils.WriteErrorJsonResponse(w, constants.ErrorCodes.AuthInvalidScope, http.StatusUnauthorized, []string{err.Error()})
-		}
-		return
-	}
-
 	id := r.PathValue("id")
 
 	if err := u.userRepository.DeleteUser(id); err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
@@ -16,6 +17,16 @@ type UserContextHelpers struct {
 	R *repository.UserRepository
 }
 
+// LoadUserIdFromContext helper that attempts to read the id of the user from the http.Request's user context key, without loading the user.
+// Returns ErrMissingUserContext for unauthenticated requests.
+func (h UserContextHelpers) LoadUserIdFromContext(r *http.Request) (string, error) {
+	userContext, ok := r.Context().Value(service.USER_CONTEXT_KEY).(*service.JwtPayload)
+	if !ok || len(userContext.Id) < 1 {
+		return "", ErrMissingUserContext
+	}
+	return userContext.Id, nil
+}
+
 // LoadUserFromContext helper that attempts to read the http.Request's user context key or returns an error if it was not found.
 // Returns the loaded user if found.
 func (h UserContextHelpers) LoadUserFromContext(r *http.Request) (*models.UserModel, error) {
@@ -29,6 +40,8 @@ func (h UserContextHelpers) LoadUserFromContext(r *http.Request) (*models.UserMo
 // LoadUserFromContextWithRole helper that attempts to read the http.Request's user context key or returns an error if it was not found.
 // Returns the loaded user if found and has the role specified in the parameters.
 // This helper can be used as a gaurd to protect routes being accessed by users without the specified role.
+//
+// Deprecated: roles only grant permissions, routes instead require them through middleware.PermissionMiddleware or LoadUserFromContextWithPermission.
 func (h UserContextHelpers) LoadUserFromContextWithRole(r *http.Request, role types.Role) (*models.UserModel, error) {
 	user, err := h.LoadUserFromContext(r)
 	if err != nil {
@@ -40,6 +53,20 @@ func (h UserContextHelpers) LoadUserFromContextWithRole(r *http.Request, role ty
 	return user, nil
 }
 
+// LoadUserFromContextWithPermission helper that attempts to read the http.Request's user context key or returns an error if it was not found.
+// Returns the loaded user if found and their role grants the permission, otherwise an error wrapping middleware.ErrMissingPermission.
+// Routes which always require the permission declare it through middleware.PermissionMiddleware instead.
+func (h UserContextHelpers) LoadUserFromContextWithPermission(r *http.Request, permission types.Permission) (*models.UserModel, error) {
+	user, err := h.LoadUserFromContext(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := middleware.CheckPermissions(user.Role, permission); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 var (
 	ErrMissingUserContext = errors.New("no user context provided") // ErrMissingUserContext is returned when no context is found while attempting to load user from http.Requests context.
 )
@@ -3,12 +3,14 @@ package net_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/middleware"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
@@ -186,3 +188,91 @@ func TestUserContextHelpers_LoadUserFromContextWithRole(t *testing.T) {
 		}
 	})
 }
+
+func TestUserContextHelpers_LoadUserIdFromContext(t *testing.T) {
+	t.Run("With context", func(t *testing.T) {
+		payload := &service.JwtPayload{
+			Id:   "user",
+			Role: "user",
+		}
+		r := httptest.NewRequest("GET", "/", nil)
+
+		id, err := userContextHelper.LoadUserIdFromContext(r.WithContext(
+			context.WithValue(r.Context(), service.USER_CONTEXT_KEY, payload),
+		))
+		if err != nil {
+			t.Errorf(err.Error())
+		}
+		if id != payload.Id {
+			t.Errorf("expected id to be %s but was %s", payload.Id, id)
+		}
+	})
+
+	t.Run("With no context", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+
+		id, err := userContextHelper.LoadUserIdFromContext(r)
+		if err != net.ErrMissingUserContext {
+			t.Errorf("error does not match the expected type ErrMissingUserContext")
+		}
+		if id != "" {
+			t.Errorf("expected empty id when attempting to call with no context")
+		}
+	})
+}
+
+func TestUserContextHelpers_LoadUserFromContextWithPermission(t *testing.T) {
+	testcases := []struct {
+		name       string
+		id         string
+		permission types.Permission
+		expectErr  bool
+	}{
+		{name: "Organizer may create events", id: "organizer", permission: types.CreateEventPermission},
+		{name: "Admin may create events", id: "admin", permission: types.CreateEventPermission},
+		{name: "User may not create events", id: "user", permission: types.CreateEventPermission, expectErr: true},
+		{name: "Organizer may not manage users", id: "organizer", permission: types.ManageUsersPermission, expectErr: true},
+		{name: "Admin may manage users", id: "admin", permission: types.ManageUsersPermission},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			payload := &service.JwtPayload{
+				Id:   testcase.id,
+				Role: types.Role(testcase.id),
+			}
+			r := httptest.NewRequest("POST", "/", nil)
+
+			user, err := userContextHelper.LoadUserFromContextWithPermission(r.WithContext(
+				context.WithValue(r.Context(), service.USER_CONTEXT_KEY, payload),
+			), testcase.permission)
+			if testcase.expectErr {
+				if !errors.Is(err, middleware.ErrMissingPermission) {
+					t.Errorf("expected error to be ErrMissingPermission but was %v", err)
+				}
+				if user != nil {
+					t.Errorf("expected nil pointer when attempting to load user without the permission")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf(err.Error())
+			}
+			if user == nil {
+				t.Errorf("failed to load mock user")
+			}
+		})
+	}
+
+	t.Run("With no context", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", nil)
+
+		user, err := userContextHelper.LoadUserFromContextWithPermission(r, types.CreateEventPermission)
+		if err != net.ErrMissingUserContext {
+			t.Errorf("error does not match the expected type ErrMissingUserContext")
+		}
+		if user != nil {
+			t.Errorf("expected nil pointer when attempting to call with no context")
+		}
+	})
+}
@@ -0,0 +1,449 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// AttendanceRepository represents the interface for event attendance related database operations.
+type AttendanceRepository interface {
+	AddAttendee(eventId string, occurrence time.Time, userId string) (types.AttendanceStatus, error)
+	RemoveAttendee(eventId string, occurrence time.Time, userId string) (*AttendanceRemoval, error)
+	PromoteWaitlisted(eventId string) ([]string, error)
+	IsAttending(eventId string, userId string) (bool, error)
+	GetAttendee(eventId string, occurrence time.Time, userId string) (*models.AttendeeModel, error)
+	CheckInAttendee(eventId string, occurrence time.Time, userId string, checkIn CheckIn) (*models.AttendeeModel, error)
+	ListAttendees(eventId string, occurrence *time.Time) ([]*models.AttendeeModel, error)
+	ListWaitlist(eventId string, occurrence *time.Time) ([]*models.WaitlistEntryModel, error)
+	ListUpcomingEventsForUser(userId string) ([]*models.EventModel, error)
+}
+
+// AttendanceRemoval describes the outcome of removing a user from an event.
+type AttendanceRemoval struct {
+	Status   types.AttendanceStatus // Status the user had before being removed.
+	Promoted []string               // Promoted the ids of waitlisted users who took the freed place.
+}
+
+// CheckIn describes who checked in an attendee, when and from which device.
+type CheckIn struct {
+	By     string    // By the id of the user who checked in the attendee.
+	At     time.Time // At the time the attendee was checked in.
+	Device string    // Device the id of the device which scanned the attendee while offline, empty for check-ins at the door.
+}
+
+type sqlAttendanceRepository struct {
+	database *sql.DB
+}
+
+// NewSQLAttendanceRepository creates and returns a new sql flavoured AttendanceRepository instance.
+func NewSQLAttendanceRepository(database *sql.DB) AttendanceRepository {
+	return &sqlAttendanceRepository{database: database}
+}
+
+// lockedEvent the state of an event which determines changes to its attendance, loaded by lockEvent.
+type lockedEvent struct {
+	capacity  sql.NullInt32
+	attendees int
+	status    types.EventStatus
+}
+
+// isFull returns true if the event has a capacity which its attendees have reached.
+func (e lockedEvent) isFull() bool {
+	return e.capacity.Valid && e.attendees >= int(e.capacity.Int32)
+}
+
+// lockEvent locks the events row for the remainder of the transaction, serializing changes to its attendance and status.
+// The capacity of an event applies to each of its occurrences, so only the attendees of the occurrence are counted, which is only reliable while the lock is held.
+func lockEvent(tx *sql.Tx, eventId string, occurrence time.Time) (*lockedEvent, error) {
+	query := `SELECT 
+			e.capacity,
+			(SELECT COUNT(*) FROM public.event_attendees ea WHERE ea.event_id = e.id AND ea.occurrence_start = $2),
+			e.status
+		FROM public.events e WHERE e.id = $1 FOR UPDATE`
+
+	event := &lockedEvent{}
+	err := tx.QueryRow(query, eventId, occurrence).Scan(&event.capacity, &event.attendees, &event.status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	}
+	return event, err
+}
+
+// AddAttendee records the user as attending the occurrence of the event, or adds them to its waitlist when the occurrence is full.
+// Occurrences are identified by the time they were originally scheduled to start.
+func (r *sqlAttendanceRepository) AddAttendee(eventId string, occurrence time.Time, userId string) (types.AttendanceStatus, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	event, err := lockEvent(tx, eventId, occurrence)
+	if err != nil {
+		return "", fmt.Errorf("failed to add attendee: %w", err)
+	}
+	if event.status != types.PublishedEventStatus {
+		return "", ErrEventNotOpen
+	}
+
+	var attending, waitlisted bool
+	err = tx.QueryRow(
+		`SELECT 
+			EXISTS (SELECT 1 FROM public.event_attendees WHERE event_id = $1 AND occurrence_start = $2 AND attendee_id = $3),
+			EXISTS (SELECT 1 FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start = $2 AND user_id = $3)`,
+		eventId, occurrence, userId,
+	).Scan(&attending, &waitlisted)
+	if err != nil {
+		return "", fmt.Errorf("failed to add attendee: %w", err)
+	}
+	if attending {
+		return "", ErrAlreadyAttending
+	}
+	if waitlisted {
+		return "", ErrAlreadyWaitlisted
+	}
+
+	status := types.AttendingStatus
+	query := `INSERT INTO public.event_attendees (event_id, occurrence_start, attendee_id) VALUES ($1, $2, $3)`
+	if event.isFull() {
+		status = types.WaitlistedStatus
+		query = `INSERT INTO public.event_waitlist (event_id, occurrence_start, user_id) VALUES ($1, $2, $3)`
+	}
+
+	if _, err := tx.Exec(query, eventId, occurrence, userId); err != nil {
+		return "", fmt.Errorf("failed to add attendee: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+// RemoveAttendee removes the user from the attendees or waitlist of the occurrence of the event.
+// When an attendee is removed, the next waitlisted user is promoted to attendee within the same transaction.
+func (r *sqlAttendanceRepository) RemoveAttendee(eventId string, occurrence time.Time, userId string) (*AttendanceRemoval, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	event, err := lockEvent(tx, eventId, occurrence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove attendee: %w", err)
+	}
+	// the attendees of completed events are kept, as they are able to review the event.
+	if event.status == types.CompletedEventStatus {
+		return nil, ErrEventNotOpen
+	}
+
+	removal := &AttendanceRemoval{Status: types.AttendingStatus}
+
+	rs, err := tx.Exec(`DELETE FROM public.event_attendees WHERE event_id = $1 AND occurrence_start = $2 AND attendee_id = $3`, eventId, occurrence, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove attendee: %w", err)
+	}
+	affected, err := rs.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected < 1 {
+		removal.Status = types.WaitlistedStatus
+
+		rs, err := tx.Exec(`DELETE FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start = $2 AND user_id = $3`, eventId, occurrence, userId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove attendee: %w", err)
+		}
+		if affected, err := rs.RowsAffected(); affected < 1 {
+			if err != nil {
+				return nil, err
+			}
+			return nil, ErrNotAttending
+		}
+	} else if event.status == types.PublishedEventStatus {
+		if removal.Promoted, err = promoteWaitlisted(tx, eventId, occurrence); err != nil {
+			return nil, fmt.Errorf("failed to remove attendee: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return removal, nil
+}
+
+// PromoteWaitlisted promotes waitlisted users to attendees while each occurrence of the event has free places, such as after its capacity was increased.
+// Returns the ids of the promoted users.
+func (r *sqlAttendanceRepository) PromoteWaitlisted(eventId string) ([]string, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	promoted, err := promoteAllWaitlisted(tx, eventId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote waitlisted users: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
+
+// promoteAllWaitlisted promotes waitlisted users of each occurrence of the event which has a waitlist.
+func promoteAllWaitlisted(tx *sql.Tx, eventId string) ([]string, error) {
+	rows, err := tx.Query(`SELECT DISTINCT occurrence_start FROM public.event_waitlist WHERE event_id = $1 ORDER BY occurrence_start`, eventId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occurrences := []time.Time{}
+	for rows.Next() {
+		var occurrence time.Time
+		if err := rows.Scan(&occurrence); err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	promoted := []string{}
+	for _, occurrence := range occurrences {
+		ids, err := promoteWaitlisted(tx, eventId, occurrence)
+		if err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, ids...)
+	}
+	return promoted, nil
+}
+
+// promoteWaitlisted moves users from the front of the occurrences waitlist to its attendees until the occurrence is full or the waitlist is empty.
+func promoteWaitlisted(tx *sql.Tx, eventId string, occurrence time.Time) ([]string, error) {
+	promoted := []string{}
+	for {
+		event, err := lockEvent(tx, eventId, occurrence)
+		if err != nil {
+			return nil, err
+		}
+		if event.isFull() {
+			return promoted, nil
+		}
+
+		var userId string
+		err = tx.QueryRow(
+			`DELETE FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start = $2 AND user_id = (
+				SELECT user_id FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start = $2 ORDER BY queued_at, user_id LIMIT 1
+			) RETURNING user_id`,
+			eventId, occurrence,
+		).Scan(&userId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return promoted, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(`INSERT INTO public.event_attendees (event_id, occurrence_start, attendee_id) VALUES ($1, $2, $3)`, eventId, occurrence, userId); err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, userId)
+	}
+}
+
+// IsAttending returns true if the user is attending any occurrence of the event.
+func (r *sqlAttendanceRepository) IsAttending(eventId string, userId string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM public.event_attendees WHERE event_id = $1 AND attendee_id = $2)`
+
+	var attending bool
+	if err := r.database.QueryRow(query, eventId, userId).Scan(&attending); err != nil {
+		return false, fmt.Errorf("failed to check attendance: %w", err)
+	}
+
+	return attending, nil
+}
+
+// attendeeColumns lists the columns selected when loading an attendee, in the order expected by scanAttendee.
+const attendeeColumns = `
+				u.id,
+				u.username,
+				u.first_name,
+				u.last_name,
+				u.avatar_url,
+				ea.occurrence_start,
+				ea.checked_in_at,
+				ea.checked_in_device,
+				ea.created_at`
+
+// scanAttendee scans the columns listed in attendeeColumns into a new attendee model.
+func scanAttendee(row rowScanner) (*models.AttendeeModel, error) {
+	attendee := &models.AttendeeModel{}
+	err := row.Scan(
+		&attendee.UserID,
+		&attendee.Username,
+		&attendee.FirstName,
+		&attendee.LastName,
+		&attendee.AvatarUrl,
+		&attendee.OccurrenceStart,
+		&attendee.CheckedInAt,
+		&attendee.CheckedInDevice,
+		&attendee.CreatedAt,
+	)
+	return attendee, err
+}
+
+// GetAttendee retrieves the user attending the occurrence of the event.
+func (r *sqlAttendanceRepository) GetAttendee(eventId string, occurrence time.Time, userId string) (*models.AttendeeModel, error) {
+	query := `SELECT ` + attendeeColumns + ` FROM public.event_attendees ea JOIN public.users u ON u.id = ea.attendee_id
+			WHERE ea.event_id = $1 AND ea.occurrence_start = $2 AND ea.attendee_id = $3`
+
+	attendee, err := scanAttendee(r.database.QueryRow(query, eventId, occurrence, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotAttending
+		}
+		return nil, fmt.Errorf("failed to get attendee: %w", err)
+	}
+
+	return attendee, nil
+}
+
+// CheckInAttendee records the user attending the occurrence of the event as checked in.
+// Attendees can only be checked in once, checking in an attendee again returns the attendee along with ErrAlreadyCheckedIn.
+func (r *sqlAttendanceRepository) CheckInAttendee(eventId string, occurrence time.Time, userId string, checkIn CheckIn) (*models.AttendeeModel, error) {
+	query := `UPDATE public.event_attendees SET checked_in_at = $4, checked_in_by = $5, checked_in_device = $6
+			WHERE event_id = $1 AND occurrence_start = $2 AND attendee_id = $3 AND checked_in_at IS NULL`
+
+	device := sql.NullString{String: checkIn.Device, Valid: len(checkIn.Device) > 0}
+	rs, err := r.database.Exec(query, eventId, occurrence, userId, checkIn.At, checkIn.By, device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check in attendee: %w", err)
+	}
+	affected, err := rs.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	attendee, err := r.GetAttendee(eventId, occurrence, userId)
+	if err != nil {
+		return nil, err
+	}
+	if affected < 1 {
+		return attendee, ErrAlreadyCheckedIn
+	}
+
+	return attendee, nil
+}
+
+// ListAttendees retrieves the users attending the event, or only the provided occurrence of the event.
+// Attendees are ordered by occurrence, then in the order they started attending.
+func (r *sqlAttendanceRepository) ListAttendees(eventId string, occurrence *time.Time) ([]*models.AttendeeModel, error) {
+	query := `SELECT ` + attendeeColumns + ` FROM public.event_attendees ea JOIN public.users u ON u.id = ea.attendee_id
+			WHERE ea.event_id = $1 AND ($2::timestamptz IS NULL OR ea.occurrence_start = $2)
+			ORDER BY ea.occurrence_start, ea.created_at, u.id`
+
+	rows, err := r.database.Query(query, eventId, occurrence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attendees: %w", err)
+	}
+	defer rows.Close()
+
+	attendees := []*models.AttendeeModel{}
+	for rows.Next() {
+		attendee, err := scanAttendee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attendees: %w", err)
+		}
+		attendees = append(attendees, attendee)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list attendees: %w", err)
+	}
+
+	return attendees, nil
+}
+
+// ListWaitlist retrieves the users waiting for a place at the event, or only the provided occurrence of the event.
+// Entries are ordered by occurrence, then in the order they will be promoted.
+func (r *sqlAttendanceRepository) ListWaitlist(eventId string, occurrence *time.Time) ([]*models.WaitlistEntryModel, error) {
+	query := `SELECT 
+				u.id,
+				u.username,
+				u.first_name,
+				u.last_name,
+				u.avatar_url,
+				ew.occurrence_start,
+				ew.queued_at
+			FROM public.event_waitlist ew JOIN public.users u ON u.id = ew.user_id
+			WHERE ew.event_id = $1 AND ($2::timestamptz IS NULL OR ew.occurrence_start = $2)
+			ORDER BY ew.occurrence_start, ew.queued_at, ew.user_id`
+
+	rows, err := r.database.Query(query, eventId, occurrence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.WaitlistEntryModel{}
+	for rows.Next() {
+		entry := &models.WaitlistEntryModel{}
+		err := rows.Scan(
+			&entry.UserID,
+			&entry.Username,
+			&entry.FirstName,
+			&entry.LastName,
+			&entry.AvatarUrl,
+			&entry.OccurrenceStart,
+			&entry.QueuedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list waitlist: %w", err)
+		}
+		// positions are within the waitlist of each occurrence.
+		entry.Position = 1
+		if previous := len(entries) - 1; previous >= 0 && entries[previous].OccurrenceStart.Equal(entry.OccurrenceStart) {
+			entry.Position = entries[previous].Position + 1
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list waitlist: %w", err)
+	}
+
+	return entries, nil
+}
+
+// ListUpcomingEventsForUser retrieves the events with an occurrence the user is attending which has not yet ended, soonest first.
+func (r *sqlAttendanceRepository) ListUpcomingEventsForUser(userId string) ([]*models.EventModel, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.events e
+			WHERE EXISTS (
+				SELECT 1 FROM public.event_attendees ea
+				WHERE ea.event_id = e.id AND ea.attendee_id = $1 AND ea.occurrence_start + (e.end_date - e.start_date) >= now()
+			)
+			ORDER BY e.start_date, e.id`
+
+	return queryEvents(r.database, query, userId)
+}
+
+var (
+	ErrAlreadyAttending  = errors.New("user is already attending the event")    // ErrAlreadyAttending is returned when a user attempts to attend an event more than once.
+	ErrAlreadyWaitlisted = errors.New("user is already on the events waitlist") // ErrAlreadyWaitlisted is returned when a waitlisted user attempts to attend an event again.
+	ErrEventNotOpen      = errors.New("event is not open for attendance")       // ErrEventNotOpen is returned when attending an event which is not published, or leaving an event which has completed.
+	ErrNotAttending      = errors.New("user is not attending the event")        // ErrNotAttending is returned when a user who is neither attending nor waitlisted for an event attempts to cancel their attendance.
+	ErrAlreadyCheckedIn  = errors.New("attendee is already checked in")         // ErrAlreadyCheckedIn is returned when checking in an attendee more than once.
+)
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// CalendarFeedRepository represents the interface for database operations on users calendar subscription feeds.
+type CalendarFeedRepository interface {
+	SetFeedToken(userId string, tokenHash string) error
+	DeleteFeedToken(userId string) error
+	GetUserIdByFeedToken(tokenHash string) (string, error)
+	ListFeedEvents(userId string) ([]*models.EventModel, error)
+}
+
+type sqlCalendarFeedRepository struct {
+	database *sql.DB
+}
+
+// NewSQLCalendarFeedRepository creates and returns a new sql flavoured CalendarFeedRepository instance.
+func NewSQLCalendarFeedRepository(database *sql.DB) CalendarFeedRepository {
+	return &sqlCalendarFeedRepository{database: database}
+}
+
+// SetFeedToken sets the hash of the token for the users feed, replacing any previous token.
+func (r *sqlCalendarFeedRepository) SetFeedToken(userId string, tokenHash string) error {
+	query := `INSERT INTO public.calendar_feeds (user_id, token_hash) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.database.Exec(query, userId, tokenHash); err != nil {
+		return fmt.Errorf("failed to set calendar feed token: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFeedToken removes the users feed, so that its url no longer works.
+func (r *sqlCalendarFeedRepository) DeleteFeedToken(userId string) error {
+	query := `DELETE FROM public.calendar_feeds WHERE user_id = $1`
+
+	rs, err := r.database.Exec(query, userId)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrCalendarFeedNotFound
+	}
+
+	return nil
+}
+
+// GetUserIdByFeedToken retrieves the id of the user whose feed has the token hash.
+func (r *sqlCalendarFeedRepository) GetUserIdByFeedToken(tokenHash string) (string, error) {
+	query := `SELECT user_id FROM public.calendar_feeds WHERE token_hash = $1`
+
+	var userId string
+	if err := r.database.QueryRow(query, tokenHash).Scan(&userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrCalendarFeedNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return "", ErrRepoConnErr
+		}
+		return "", fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return userId, nil
+}
+
+// ListFeedEvents retrieves the events which the user attends or follows, soonest first.
+// Drafts are excluded, while cancelled events are kept so that calendar apps show them as cancelled.
+func (r *sqlCalendarFeedRepository) ListFeedEvents(userId string) ([]*models.EventModel, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.events e
+			WHERE e.status <> 'draft' AND (
+				EXISTS (SELECT 1 FROM public.event_attendees ea WHERE ea.event_id = e.id AND ea.attendee_id = $1)
+				OR EXISTS (SELECT 1 FROM public.event_followers ef WHERE ef.event_id = e.id AND ef.follower_id = $1)
+			)
+			ORDER BY e.start_date, e.id`
+
+	return queryEvents(r.database, query, userId)
+}
+
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found") // ErrCalendarFeedNotFound is returned when a calendar feed token does not match any users feed.
+)
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// CategoryRepository represents the interface for category-related database operations.
+type CategoryRepository interface {
+	CreateCategory(category *models.CategoryModel) error
+	GetCategoryByID(id string) (*models.CategoryModel, error)
+	UpdateCategory(category *models.CategoryModel) error
+	DeleteCategory(id string) error
+	ListCategories() ([]*models.CategoryModel, error)
+	ListCategoriesForEvent(eventId string) ([]*models.CategoryModel, error)
+	AttachCategory(eventId string, categoryId string) error
+	DetachCategory(eventId string, categoryId string) error
+}
+
+type sqlCategoryRepository struct {
+	database *sql.DB
+}
+
+// NewSQLCategoryRepository creates and returns a new sql flavoured CategoryRepository instance.
+func NewSQLCategoryRepository(database *sql.DB) CategoryRepository {
+	return &sqlCategoryRepository{database: database}
+}
+
+// CreateCategory inserts a new category into the database.
+func (r *sqlCategoryRepository) CreateCategory(category *models.CategoryModel) error {
+	query := `INSERT INTO public.categories (name) VALUES ($1) RETURNING id`
+
+	if err := r.database.QueryRow(query, category.Name).Scan(&category.ID); err != nil {
+		if isUniqueViolation(err, "categories_name_key") {
+			return ErrCategoryExists
+		}
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return nil
+}
+
+// GetCategoryByID retrieves a category from the database by its unique ID.
+func (r *sqlCategoryRepository) GetCategoryByID(id string) (*models.CategoryModel, error) {
+	query := `SELECT id, name FROM public.categories WHERE id = $1`
+
+	category := &models.CategoryModel{}
+	if err := r.database.QueryRow(query, id).Scan(&category.ID, &category.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidCategoryId
+	}
+
+	return category, nil
+}
+
+// UpdateCategory update a category in the database.
+func (r *sqlCategoryRepository) UpdateCategory(category *models.CategoryModel) error {
+	query := `UPDATE public.categories SET name = $1 WHERE id = $2`
+
+	rs, err := r.database.Exec(query, category.Name, category.ID)
+	if err != nil {
+		if isUniqueViolation(err, "categories_name_key") {
+			return ErrCategoryExists
+		}
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// DeleteCategory delete a category from the database, removing it from any events it was attached to.
+func (r *sqlCategoryRepository) DeleteCategory(id string) error {
+	query := `DELETE FROM public.categories WHERE id = $1`
+
+	rs, err := r.database.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// ListCategories retrieves all categories, ordered by name.
+func (r *sqlCategoryRepository) ListCategories() ([]*models.CategoryModel, error) {
+	query := `SELECT c.id, c.name FROM public.categories c ORDER BY lower(c.name)`
+
+	return r.queryCategories(query)
+}
+
+// ListCategoriesForEvent retrieves the categories the event is attached to, ordered by name.
+func (r *sqlCategoryRepository) ListCategoriesForEvent(eventId string) ([]*models.CategoryModel, error) {
+	query := `SELECT c.id, c.name FROM public.categories c
+			JOIN public.event_categories e ON e.category_id = c.id
+			WHERE e.event_id = $1
+			ORDER BY lower(c.name)`
+
+	return r.queryCategories(query, eventId)
+}
+
+// AttachCategory attaches the category to the event, attaching an already attached category has no effect.
+func (r *sqlCategoryRepository) AttachCategory(eventId string, categoryId string) error {
+	query := `INSERT INTO public.event_categories (event_id, category_id) VALUES ($1, $2) ON CONFLICT (event_id, category_id) DO NOTHING`
+
+	if _, err := r.database.Exec(query, eventId, categoryId); err != nil {
+		if isForeignKeyViolationOf(err, "event_categories_category_id_fkey") {
+			return ErrCategoryNotFound
+		}
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to attach category: %w", err)
+	}
+
+	return nil
+}
+
+// DetachCategory removes the category from the event, detaching a category which is not attached has no effect.
+func (r *sqlCategoryRepository) DetachCategory(eventId string, categoryId string) error {
+	query := `DELETE FROM public.event_categories WHERE event_id = $1 AND category_id = $2`
+
+	if _, err := r.database.Exec(query, eventId, categoryId); err != nil {
+		return fmt.Errorf("failed to detach category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqlCategoryRepository) queryCategories(query string, args ...any) ([]*models.CategoryModel, error) {
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*models.CategoryModel{}
+	for rows.Next() {
+		category := &models.CategoryModel{}
+		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+			return nil, fmt.Errorf("failed to list categories: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+var (
+	ErrCategoryNotFound  = errors.New("category not found")                           // ErrCategoryNotFound is returned when a category is not found in the database.
+	ErrInvalidCategoryId = errors.New("invalid category id")                          // ErrInvalidCategoryId is returned when a category id is invalid or malformed.
+	ErrCategoryExists    = errors.New("a category with the same name already exists") // ErrCategoryExists is returned when a category name is already in use, ignoring case.
+)
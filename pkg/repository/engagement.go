@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/lib/pq"
+)
+
+// EngagementRepository represents the interface for database operations on users liking and following events.
+type EngagementRepository interface {
+	LikeEvent(eventId string, userId string) error
+	UnlikeEvent(eventId string, userId string) error
+	FollowEvent(eventId string, userId string) error
+	UnfollowEvent(eventId string, userId string) error
+	GetEngagements(userId string, eventIds []string) (map[string]Engagement, error)
+	ListLikedEvents(userId string) ([]*models.EventModel, error)
+	ListFollowedEvents(userId string) ([]*models.EventModel, error)
+}
+
+// Engagement describes whether a user likes and follows an event.
+type Engagement struct {
+	Liked    bool
+	Followed bool
+}
+
+type sqlEngagementRepository struct {
+	database *sql.DB
+}
+
+// NewSQLEngagementRepository creates and returns a new sql flavoured EngagementRepository instance.
+func NewSQLEngagementRepository(database *sql.DB) EngagementRepository {
+	return &sqlEngagementRepository{database: database}
+}
+
+// LikeEvent records the user liking the event, liking an already liked event has no effect.
+func (r *sqlEngagementRepository) LikeEvent(eventId string, userId string) error {
+	query := `INSERT INTO public.event_likes (event_id, user_id) VALUES ($1, $2) ON CONFLICT (event_id, user_id) DO NOTHING`
+
+	if _, err := r.database.Exec(query, eventId, userId); err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to like event: %w", err)
+	}
+
+	return nil
+}
+
+// UnlikeEvent removes the users like of the event, unliking an event that is not liked has no effect.
+func (r *sqlEngagementRepository) UnlikeEvent(eventId string, userId string) error {
+	query := `DELETE FROM public.event_likes WHERE event_id = $1 AND user_id = $2`
+
+	if _, err := r.database.Exec(query, eventId, userId); err != nil {
+		return fmt.Errorf("failed to unlike event: %w", err)
+	}
+
+	return nil
+}
+
+// FollowEvent records the user following the event, following an already followed event has no effect.
+func (r *sqlEngagementRepository) FollowEvent(eventId string, userId string) error {
+	query := `INSERT INTO public.event_followers (event_id, follower_id) VALUES ($1, $2) ON CONFLICT (event_id, follower_id) DO NOTHING`
+
+	if _, err := r.database.Exec(query, eventId, userId); err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to follow event: %w", err)
+	}
+
+	return nil
+}
+
+// UnfollowEvent removes the user as a follower of the event, unfollowing an event that is not followed has no effect.
+func (r *sqlEngagementRepository) UnfollowEvent(eventId string, userId string) error {
+	query := `DELETE FROM public.event_followers WHERE event_id = $1 AND follower_id = $2`
+
+	if _, err := r.database.Exec(query, eventId, userId); err != nil {
+		return fmt.Errorf("failed to unfollow event: %w", err)
+	}
+
+	return nil
+}
+
+// GetEngagements retrieves whether the user likes and follows each of the events, keyed by event id.
+func (r *sqlEngagementRepository) GetEngagements(userId string, eventIds []string) (map[string]Engagement, error) {
+	engagements := make(map[string]Engagement, len(eventIds))
+	if len(eventIds) < 1 {
+		return engagements, nil
+	}
+
+	query := `SELECT 
+				ids.id,
+				EXISTS (SELECT 1 FROM public.event_likes WHERE event_id = ids.id AND user_id = $1),
+				EXISTS (SELECT 1 FROM public.event_followers WHERE event_id = ids.id AND follower_id = $1)
+			FROM unnest($2::uuid[]) AS ids(id)`
+
+	rows, err := r.database.Query(query, userId, pq.Array(eventIds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engagements: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventId string
+		var engagement Engagement
+		if err := rows.Scan(&eventId, &engagement.Liked, &engagement.Followed); err != nil {
+			return nil, fmt.Errorf("failed to get engagements: %w", err)
+		}
+		engagements[eventId] = engagement
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get engagements: %w", err)
+	}
+
+	return engagements, nil
+}
+
+// ListLikedEvents retrieves the events liked by the user, soonest first.
+func (r *sqlEngagementRepository) ListLikedEvents(userId string) ([]*models.EventModel, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.events e JOIN public.event_likes el ON el.event_id = e.id
+			WHERE el.user_id = $1
+			ORDER BY e.start_date, e.id`
+
+	return queryEvents(r.database, query, userId)
+}
+
+// ListFollowedEvents retrieves the events followed by the user, soonest first.
+func (r *sqlEngagementRepository) ListFollowedEvents(userId string) ([]*models.EventModel, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.events e JOIN public.event_followers ef ON ef.event_id = e.id
+			WHERE ef.follower_id = $1
+			ORDER BY e.start_date, e.id`
+
+	return queryEvents(r.database, query, userId)
+}
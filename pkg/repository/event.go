@@ -0,0 +1,670 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/lib/pq"
+)
+
+// EventRepository represents the interface for event-related database operations.
+type EventRepository interface {
+	CreateEvent(event *models.EventModel) error
+	GetEventByID(id string) (*models.EventModel, error)
+	UpdateEvent(event *models.EventModel) error
+	GetEventBySlug(slug string) (*models.EventModel, error)
+	DeleteEvent(id string) error
+	ListEvents(filter EventFilter) (*EventPage, error)
+	ChangeEventStatus(event *models.EventModel, previous types.EventStatus) error
+	CompleteEndedEvents(now time.Time) (int64, error)
+	SplitEvent(event *models.EventModel, next *models.EventModel, occurrence time.Time) error
+}
+
+type sqlEventRepository struct {
+	database *sql.DB
+}
+
+// NewSQLEventRepository creates and returns a new sql flavoured EventRepository instance.
+func NewSQLEventRepository(database *sql.DB) EventRepository {
+	return &sqlEventRepository{database: database}
+}
+
+// eventColumns lists the columns selected when loading an event, in the order expected by scanEvent.
+const eventColumns = `
+				e.id,
+				e.name,
+				e.organizer_id,
+				e.description,
+				e.start_date,
+				e.end_date,
+				e.time_zone,
+				e.is_paid,
+				e.event_type,
+				e.country,
+				e.city,
+				e.slug,
+				e.likes,
+				e.follows,
+				e.attendees,
+				e.capacity,
+				e.status,
+				e.cancellation_reason,
+				e.recurrence_rule,
+				e.last_end_date,
+				e.created_at,
+				e.updated_at`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanEvent scans the columns listed in eventColumns into a new event model.
+func scanEvent(row rowScanner) (*models.EventModel, error) {
+	event := &models.EventModel{}
+	err := row.Scan(
+		&event.ID,
+		&event.Name,
+		&event.OrganizerID,
+		&event.Description,
+		&event.StartDate,
+		&event.EndDate,
+		&event.TimeZone,
+		&event.IsPaid,
+		&event.EventType,
+		&event.Country,
+		&event.City,
+		&event.Slug,
+		&event.Likes,
+		&event.Follows,
+		&event.Attendees,
+		&event.Capacity,
+		&event.Status,
+		&event.CancellationReason,
+		&event.RecurrenceRule,
+		&event.LastEndDate,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+	)
+	return event, err
+}
+
+// CreateEvent inserts a new event into the database, generating a unique slug from its name.
+func (r *sqlEventRepository) CreateEvent(event *models.EventModel) error {
+	event.BeforeCreate()
+
+	var err error
+	// another event may claim the same slug between finding it available and inserting, in which case try again.
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		err = insertEvent(r.database, event)
+		if !isUniqueViolation(err, "events_slug_key") {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+
+	event.AfterCreate()
+
+	return nil
+}
+
+// eventInserter is implemented by both *sql.DB and *sql.Tx.
+type eventInserter interface {
+	querier
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// insertEvent inserts the event with an available slug generated from its name.
+func insertEvent(q eventInserter, event *models.EventModel) error {
+	query := `INSERT INTO public.events (name, organizer_id, description, start_date, end_date, time_zone, is_paid, event_type, country, city, slug, capacity, status, recurrence_rule, last_end_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id, created_at, updated_at`
+
+	var err error
+	event.Slug, err = availableSlug(q, event.Name, sql.NullString{})
+	if err != nil {
+		return err
+	}
+
+	return q.QueryRow(
+		query,
+		event.Name,
+		event.OrganizerID,
+		event.Description,
+		event.StartDate,
+		event.EndDate,
+		event.TimeZone,
+		event.IsPaid,
+		event.EventType,
+		event.Country,
+		event.City,
+		event.Slug,
+		event.Capacity,
+		event.Status,
+		event.RecurrenceRule,
+		event.LastEndDate,
+	).Scan(&event.ID, &event.CreatedAt, &event.UpdatedAt)
+}
+
+// GetEventByID retrieves an event from the database by its unique ID.
+func (r *sqlEventRepository) GetEventByID(id string) (*models.EventModel, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.events e WHERE e.id = $1`
+
+	event, err := scanEvent(r.database.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEventNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidEventId
+	}
+
+	return event, nil
+}
+
+// UpdateEvent update an event in the database.
+// When the events name has changed a new slug is generated, and the previous slug is kept to redirect to the event.
+// When the events capacity has changed, waitlisted users are promoted into any free places.
+// When the events start date has changed, the occurrences its attendees, waitlist and exceptions refer to are moved by the same amount.
+func (r *sqlEventRepository) UpdateEvent(event *models.EventModel) error {
+	event.BeforeUpdate()
+	query := `UPDATE public.events SET name = $1, description = $2, start_date = $3, end_date = $4, time_zone = $5, is_paid = $6, event_type = $7, country = $8, city = $9, slug = $10, capacity = $11, recurrence_rule = $12, last_end_date = $13, updated_at = $14 WHERE id = $15`
+
+	// This is a guard to prevent any partial event from being submitted.
+	// Otherwise it would be possible to accidently empty out columns by passing empty/uninitialized values.
+	if event.CreatedAt.IsZero() {
+		return fmt.Errorf("unable to update an event that was not loaded from the database")
+	}
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previousName, previousSlug string
+	var previousCapacity sql.NullInt32
+	var previousStartDate time.Time
+	err = tx.QueryRow(`SELECT name, slug, capacity, start_date FROM public.events WHERE id = $1 FOR UPDATE`, event.ID).Scan(&previousName, &previousSlug, &previousCapacity, &previousStartDate)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEventNotFound
+		}
+		return err
+	}
+
+	event.Slug = previousSlug
+	if utils.Slugify(previousName) != utils.Slugify(event.Name) {
+		slug, err := availableSlug(tx, event.Name, sql.NullString{String: event.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if slug != previousSlug {
+			if _, err := tx.Exec(`INSERT INTO public.event_slug_redirects (slug, event_id) VALUES ($1, $2) ON CONFLICT (slug) DO NOTHING`, previousSlug, event.ID); err != nil {
+				return err
+			}
+			// the event may be renamed back to a previous name, so its slug no longer needs to redirect.
+			if _, err := tx.Exec(`DELETE FROM public.event_slug_redirects WHERE slug = $1 AND event_id = $2`, slug, event.ID); err != nil {
+				return err
+			}
+			event.Slug = slug
+		}
+	}
+
+	rs, err := tx.Exec(
+		query,
+		event.Name,
+		event.Description,
+		event.StartDate,
+		event.EndDate,
+		event.TimeZone,
+		event.IsPaid,
+		event.EventType,
+		event.Country,
+		event.City,
+		event.Slug,
+		event.Capacity,
+		event.RecurrenceRule,
+		event.LastEndDate, // now updated in model BeforeUpdate lifecycle hook
+		event.UpdatedAt,   // now updated in model BeforeUpdate lifecycle hook
+		event.ID,
+	)
+	if err != nil {
+		if isUniqueViolation(err, "events_slug_key") {
+			return ErrSlugConflict
+		}
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrEventNotFound
+	}
+
+	if !event.StartDate.Equal(previousStartDate) {
+		if err := shiftOccurrences(tx, event.ID, event.ID, previousStartDate, event.StartDate.Sub(previousStartDate)); err != nil {
+			return err
+		}
+	}
+
+	// places may have been freed by increasing or removing the capacity, which are given to waitlisted users.
+	if event.Capacity != previousCapacity {
+		if _, err := promoteAllWaitlisted(tx, event.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	event.AfterUpdate()
+
+	return nil
+}
+
+// GetEventBySlug retrieves an event from the database by either its current slug or one of its previous slugs.
+// Callers can compare the returned events slug with the requested slug to determine if the slug was a previous one.
+func (r *sqlEventRepository) GetEventBySlug(slug string) (*models.EventModel, error) {
+	query := `SELECT ` + eventColumns + ` FROM public.events e WHERE e.slug = $1
+		UNION ALL
+		SELECT ` + eventColumns + ` FROM public.events e JOIN public.event_slug_redirects sr ON sr.event_id = e.id WHERE sr.slug = $1
+		LIMIT 1`
+
+	event, err := scanEvent(r.database.QueryRow(query, slug))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEventNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, fmt.Errorf("failed to get event by slug: %w", err)
+	}
+
+	return event, nil
+}
+
+// DeleteEvent delete an event from the database.
+func (r *sqlEventRepository) DeleteEvent(id string) error {
+	query := `DELETE FROM public.events WHERE id = $1`
+
+	rs, err := r.database.Exec(query, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrEventHasOrders
+		}
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrEventNotFound
+	}
+
+	return nil
+}
+
+// ListEvents retrieves a page of events matching the filter, sorted and positioned after the filters cursor.
+func (r *sqlEventRepository) ListEvents(filter EventFilter) (*EventPage, error) {
+	if !filter.Sort.IsValid() {
+		filter.Sort = types.SortByStartDate
+	}
+	if !filter.Order.IsValid() {
+		filter.Order = filter.Sort.DefaultOrder()
+	}
+	if filter.Limit < 1 || filter.Limit > MaxEventPageSize {
+		filter.Limit = DefaultEventPageSize
+	}
+	if !filter.Status.IsValid() {
+		filter.Status = types.PublishedEventStatus
+	}
+
+	conditions := []string{}
+	args := []interface{}{}
+	// arg appends the value to the query arguments, returning its placeholder.
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions = append(conditions, "e.status = "+arg(filter.Status))
+	if len(filter.OrganizerID) > 0 {
+		conditions = append(conditions, "e.organizer_id = "+arg(filter.OrganizerID))
+	}
+
+	// recurring events without a last end date continue indefinitely.
+	if filter.From != nil {
+		conditions = append(conditions, "(e.last_end_date IS NULL OR e.last_end_date >= "+arg(*filter.From)+")")
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "e.start_date <= "+arg(*filter.To))
+	}
+	if len(filter.EventType) > 0 {
+		conditions = append(conditions, "e.event_type = "+arg(filter.EventType))
+	}
+	if filter.IsPaid != nil {
+		conditions = append(conditions, "e.is_paid = "+arg(*filter.IsPaid))
+	}
+	if len(filter.Country) > 0 {
+		conditions = append(conditions, "lower(e.country) = lower("+arg(filter.Country)+")")
+	}
+	if len(filter.City) > 0 {
+		conditions = append(conditions, "lower(e.city) = lower("+arg(filter.City)+")")
+	}
+	if len(filter.Tag) > 0 {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM public.event_tags et JOIN public.tags t ON t.id = et.tag_id
+			WHERE et.event_id = e.id AND lower(t.name) = lower(`+arg(filter.Tag)+`))`)
+	}
+	if len(filter.Category) > 0 {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM public.event_categories ec JOIN public.categories c ON c.id = ec.category_id
+			WHERE ec.event_id = e.id AND lower(c.name) = lower(`+arg(filter.Category)+`))`)
+	}
+
+	comparison, direction := ">", "ASC"
+	if filter.Order == types.Descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	if filter.Cursor != nil {
+		if filter.Cursor.Sort != filter.Sort || filter.Cursor.Order != filter.Order {
+			return nil, ErrInvalidCursor
+		}
+		value, err := filter.Cursor.value()
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, e.id) %s (%s, %s::uuid)", sortColumn(filter.Sort), comparison, arg(value), arg(filter.Cursor.ID)))
+	}
+
+	query := `SELECT ` + eventColumns + ` FROM public.events e`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	// select one more than the limit to determine if there is a following page.
+	query += fmt.Sprintf(" ORDER BY %s %s, e.id %s LIMIT %s", sortColumn(filter.Sort), direction, direction, arg(filter.Limit+1))
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	page := &EventPage{Events: []*models.EventModel{}}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		page.Events = append(page.Events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	if len(page.Events) > filter.Limit {
+		page.Events = page.Events[:filter.Limit]
+		page.NextCursor = newEventCursor(filter.Sort, filter.Order, page.Events[filter.Limit-1])
+	}
+
+	return page, nil
+}
+
+// maxSlugAttempts the number of times to retry creating an event when its generated slug is claimed concurrently.
+const maxSlugAttempts = 3
+
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// availableSlug generates a slug from the name that is not the current or a previous slug of any other event.
+func availableSlug(q querier, name string, eventId sql.NullString) (string, error) {
+	base := utils.Slugify(name)
+	if len(base) < 1 {
+		base = "event"
+	}
+
+	query := `SELECT slug FROM public.events WHERE (slug = $1 OR slug LIKE $2) AND ($3::uuid IS NULL OR id <> $3::uuid)
+		UNION
+		SELECT slug FROM public.event_slug_redirects WHERE (slug = $1 OR slug LIKE $2) AND ($3::uuid IS NULL OR event_id <> $3::uuid)`
+
+	rows, err := q.Query(query, base, base+"-%", eventId)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	taken := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		taken = append(taken, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	return utils.UniqueSlug(base, taken), nil
+}
+
+// queryEvents runs a query selecting eventColumns, returning each of the resulting events.
+func queryEvents(q querier, query string, args ...any) ([]*models.EventModel, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.EventModel{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ChangeEventStatus moves the event to its current status, provided it is still in the previous status.
+// Any waitlist is cleared when the event is no longer published, as no further places will become available.
+func (r *sqlEventRepository) ChangeEventStatus(event *models.EventModel, previous types.EventStatus) error {
+	event.BeforeUpdate()
+	query := `UPDATE public.events SET status = $1, cancellation_reason = $2, updated_at = $3 WHERE id = $4 AND status = $5`
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rs, err := tx.Exec(query, event.Status, event.CancellationReason, event.UpdatedAt, event.ID, previous)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrEventStatusConflict
+	}
+
+	if event.Status != types.PublishedEventStatus {
+		if _, err := tx.Exec(`DELETE FROM public.event_waitlist WHERE event_id = $1`, event.ID); err != nil {
+			return err
+		}
+		// tickets can only be ordered for published events, so pending orders can no longer be paid.
+		if _, err := tx.Exec(`UPDATE public.orders SET status = 'cancelled', updated_at = $2 WHERE event_id = $1 AND status = 'pending'`, event.ID, event.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	event.AfterUpdate()
+
+	return nil
+}
+
+// CompleteEndedEvents marks all published events whose last occurrence ended before now as completed, clearing their waitlists.
+// Returns the number of events which were completed.
+func (r *sqlEventRepository) CompleteEndedEvents(now time.Time) (int64, error) {
+	query := `WITH completed AS (
+			UPDATE public.events SET status = 'completed', updated_at = $1
+			WHERE status = 'published' AND last_end_date < $1
+			RETURNING id
+		), cleared AS (
+			DELETE FROM public.event_waitlist w USING completed c WHERE w.event_id = c.id
+		)
+		SELECT COUNT(*) FROM completed`
+
+	var completed int64
+	if err := r.database.QueryRow(query, now).Scan(&completed); err != nil {
+		return 0, fmt.Errorf("failed to complete ended events: %w", err)
+	}
+
+	return completed, nil
+}
+
+// SplitEvent ends the recurrence of the event before the occurrence and creates the next event, which continues the series from the occurrence.
+// The attendees, waitlist and exceptions of the occurrence and those following it are moved to the next event, along with its tags and categories.
+func (r *sqlEventRepository) SplitEvent(event *models.EventModel, next *models.EventModel, occurrence time.Time) error {
+	event.BeforeUpdate()
+	next.BeforeCreate()
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rs, err := tx.Exec(
+		`UPDATE public.events SET recurrence_rule = $1, last_end_date = $2, updated_at = $3 WHERE id = $4`,
+		event.RecurrenceRule,
+		event.LastEndDate,
+		event.UpdatedAt,
+		event.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrEventNotFound
+	}
+
+	if err := insertEvent(tx, next); err != nil {
+		if isUniqueViolation(err, "events_slug_key") {
+			return ErrSlugConflict
+		}
+		return err
+	}
+
+	if err := shiftOccurrences(tx, event.ID, next.ID, occurrence, next.StartDate.Sub(occurrence)); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO public.event_tags (event_id, tag_id) SELECT $2, tag_id FROM public.event_tags WHERE event_id = $1`, event.ID, next.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO public.event_categories (event_id, category_id) SELECT $2, category_id FROM public.event_categories WHERE event_id = $1`, event.ID, next.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	event.AfterUpdate()
+	next.AfterCreate()
+
+	return nil
+}
+
+// shiftOccurrences moves the attendees, waitlist, exceptions and orders of the occurrences starting at or after from to the target event, offset by the provided amount.
+// Rows are deleted and inserted rather than updated, so that the attendees counters of both events are maintained by their trigger.
+// Orders are updated in place, as their items reference them.
+func shiftOccurrences(tx *sql.Tx, eventId string, targetId string, from time.Time, offset time.Duration) error {
+	queries := []string{
+		`WITH moved AS (
+			DELETE FROM public.event_attendees WHERE event_id = $1 AND occurrence_start >= $3 RETURNING attendee_id, occurrence_start, created_at
+		)
+		INSERT INTO public.event_attendees (event_id, attendee_id, occurrence_start, created_at)
+		SELECT $2, attendee_id, occurrence_start + make_interval(secs => $4), created_at FROM moved`,
+		`WITH moved AS (
+			DELETE FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start >= $3 RETURNING user_id, occurrence_start, queued_at
+		)
+		INSERT INTO public.event_waitlist (event_id, user_id, occurrence_start, queued_at)
+		SELECT $2, user_id, occurrence_start + make_interval(secs => $4), queued_at FROM moved`,
+		`WITH moved AS (
+			DELETE FROM public.event_occurrence_exceptions WHERE event_id = $1 AND occurrence_start >= $3 RETURNING occurrence_start, cancelled, start_date, end_date, updated_at
+		)
+		INSERT INTO public.event_occurrence_exceptions (event_id, occurrence_start, cancelled, start_date, end_date, updated_at)
+		SELECT $2, occurrence_start + make_interval(secs => $4), cancelled, start_date, end_date, updated_at FROM moved`,
+		`UPDATE public.orders SET event_id = $2, occurrence_start = occurrence_start + make_interval(secs => $4) WHERE event_id = $1 AND occurrence_start >= $3`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, eventId, targetId, from, offset.Seconds()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isUniqueViolation returns true if the error was caused by violating the named unique constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// isForeignKeyViolation returns true if the error was caused by referencing a row which does not exist.
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// isForeignKeyViolationOf returns true if the error was caused by the named foreign key constraint referencing a row which does not exist.
+func isForeignKeyViolationOf(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == constraint
+}
+
+var (
+	ErrEventNotFound       = errors.New("event not found")                                // ErrEventNotFound is returned when an event is not found in the database.
+	ErrInvalidEventId      = errors.New("invalid event id")                               // ErrInvalidEventId is returned when an event id is invalid or malformed.
+	ErrSlugConflict        = errors.New("event slug is already in use")                   // ErrSlugConflict is returned when an events generated slug was claimed by another event.
+	ErrEventStatusConflict = errors.New("event status was changed by another request")    // ErrEventStatusConflict is returned when an events status changed before it could be updated.
+	ErrEventHasOrders      = errors.New("event has ticket orders and can not be deleted") // ErrEventHasOrders is returned when deleting an event which tickets were ordered for, cancel it instead.
+)
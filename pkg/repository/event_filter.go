@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// sortColumn returns the events table column for the sort.
+func sortColumn(sort types.EventSort) string {
+	switch sort {
+	case types.SortByLikes:
+		return "e.likes"
+	case types.SortByAttendees:
+		return "e.attendees"
+	default:
+		return "e.start_date"
+	}
+}
+
+const (
+	DefaultEventPageSize = 20
+	MaxEventPageSize     = 100
+)
+
+// EventFilter represents the criteria used when listing events, empty or nil fields are not filtered on.
+type EventFilter struct {
+	From        *time.Time // From includes only events ending on or after this time.
+	To          *time.Time // To includes only events starting on or before this time.
+	EventType   types.EventType
+	IsPaid      *bool
+	Country     string
+	City        string
+	Tag         string            // Tag includes only events tagged with the tag of this name.
+	Category    string            // Category includes only events within the category of this name.
+	Status      types.EventStatus // Status includes only events in this state, defaulting to published events.
+	OrganizerID string            // OrganizerID includes only events organized by the user with this id.
+	Sort        types.EventSort
+	Order       types.SortOrder
+	Cursor      *EventCursor // Cursor continues the listing after the position it represents.
+	Limit       int
+}
+
+// EventPage a single page of events resulting from listing events.
+type EventPage struct {
+	Events     []*models.EventModel
+	NextCursor *EventCursor // NextCursor is nil when there are no further events.
+}
+
+// EventCursor represents a position within a sorted listing of events.
+type EventCursor struct {
+	Sort  types.EventSort `json:"s"`
+	Order types.SortOrder `json:"o"`
+	Value string          `json:"v"`  // Value of the sorted column for the last event in the page.
+	ID    string          `json:"id"` // ID of the last event in the page, breaking ties between equal values.
+}
+
+// newEventCursor creates a cursor positioned at the provided event.
+func newEventCursor(sort types.EventSort, order types.SortOrder, event *models.EventModel) *EventCursor {
+	cursor := &EventCursor{Sort: sort, Order: order, ID: event.ID}
+	switch sort {
+	case types.SortByLikes:
+		cursor.Value = strconv.Itoa(event.Likes)
+	case types.SortByAttendees:
+		cursor.Value = strconv.Itoa(event.Attendees)
+	default:
+		cursor.Value = event.StartDate.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// value returns the cursors value converted to the type of the sorted column.
+func (c *EventCursor) value() (any, error) {
+	if c.Sort == types.SortByStartDate {
+		return time.Parse(time.RFC3339Nano, c.Value)
+	}
+	return strconv.Atoi(c.Value)
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c *EventCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeEventCursor parses an opaque cursor previously returned from EventCursor.Encode.
+func DecodeEventCursor(encoded string) (*EventCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &EventCursor{}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if !cursor.Sort.IsValid() || !cursor.Order.IsValid() || !utils.IsUUID(cursor.ID) {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := cursor.value(); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor") // ErrInvalidCursor is returned when a cursor is malformed or does not match the requested sorting.
+)
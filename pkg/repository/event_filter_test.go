@@ -0,0 +1,69 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestEventCursor_EncodeAndDecode(t *testing.T) {
+	t.Run("encode and decode start date cursor", func(t *testing.T) {
+		cursor := &repository.EventCursor{
+			Sort:  types.SortByStartDate,
+			Order: types.Ascending,
+			Value: "2024-06-05T12:29:25.432Z",
+			ID:    "1df76107-4585-49f0-b772-85e830b437af",
+		}
+
+		decoded, err := repository.DecodeEventCursor(cursor.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *decoded != *cursor {
+			t.Errorf("expected decoded cursor to be %v but was %v", *cursor, *decoded)
+		}
+	})
+
+	t.Run("decode malformed cursor", func(t *testing.T) {
+		if _, err := repository.DecodeEventCursor("not-a-cursor"); err != repository.ErrInvalidCursor {
+			t.Errorf("expected ErrInvalidCursor but got %v", err)
+		}
+	})
+
+	t.Run("decode cursor with mismatched value", func(t *testing.T) {
+		cursor := &repository.EventCursor{
+			Sort:  types.SortByLikes,
+			Order: types.Descending,
+			Value: "2024-06-05T12:29:25.432Z",
+			ID:    "1df76107-4585-49f0-b772-85e830b437af",
+		}
+		if _, err := repository.DecodeEventCursor(cursor.Encode()); err != repository.ErrInvalidCursor {
+			t.Errorf("expected ErrInvalidCursor but got %v", err)
+		}
+	})
+
+	t.Run("decode cursor with an id which is not a uuid", func(t *testing.T) {
+		cursor := &repository.EventCursor{
+			Sort:  types.SortByStartDate,
+			Order: types.Ascending,
+			Value: "2024-01-01T00:00:00Z",
+			ID:    "x",
+		}
+		if _, err := repository.DecodeEventCursor(cursor.Encode()); err != repository.ErrInvalidCursor {
+			t.Errorf("expected ErrInvalidCursor but got %v", err)
+		}
+	})
+
+	t.Run("decode cursor with unknown sort", func(t *testing.T) {
+		cursor := &repository.EventCursor{
+			Sort:  types.EventSort("name"),
+			Order: types.Ascending,
+			Value: "a",
+			ID:    "1df76107-4585-49f0-b772-85e830b437af",
+		}
+		if _, err := repository.DecodeEventCursor(cursor.Encode()); err != repository.ErrInvalidCursor {
+			t.Errorf("expected ErrInvalidCursor but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// EventStaffRepository represents the interface for database operations on the staff of events.
+type EventStaffRepository interface {
+	InviteStaff(staff *models.EventStaffModel) error
+	GetStaff(eventId string, userId string) (*models.EventStaffModel, error)
+	ListStaff(eventId string) ([]*models.EventStaffModel, error)
+	ListInvitationsForUser(userId string) ([]*models.EventStaffModel, error)
+	ChangeStaffRole(staff *models.EventStaffModel) error
+	AcceptInvitation(staff *models.EventStaffModel) error
+	RemoveStaff(eventId string, userId string) error
+}
+
+type sqlEventStaffRepository struct {
+	database *sql.DB
+}
+
+// NewSQLEventStaffRepository creates and returns a new sql flavoured EventStaffRepository instance.
+func NewSQLEventStaffRepository(database *sql.DB) EventStaffRepository {
+	return &sqlEventStaffRepository{database: database}
+}
+
+// staffColumns lists the columns selected when loading a staff member, in the order expected by scanStaff.
+const staffColumns = `
+				s.event_id,
+				s.user_id,
+				s.role,
+				s.invited_by,
+				s.accepted_at,
+				s.created_at,
+				s.updated_at,
+				u.username,
+				e.name`
+
+// staffTables joins the staff with their user and event, as expected by staffColumns.
+const staffTables = `public.event_staff s
+				JOIN public.users u ON u.id = s.user_id
+				JOIN public.events e ON e.id = s.event_id`
+
+// scanStaff scans the columns listed in staffColumns into a new staff model.
+func scanStaff(row rowScanner) (*models.EventStaffModel, error) {
+	staff := &models.EventStaffModel{}
+	err := row.Scan(
+		&staff.EventID,
+		&staff.UserID,
+		&staff.Role,
+		&staff.InvitedBy,
+		&staff.AcceptedAt,
+		&staff.CreatedAt,
+		&staff.UpdatedAt,
+		&staff.Username,
+		&staff.EventName,
+	)
+	return staff, err
+}
+
+// InviteStaff inserts a pending invitation of the user to the staff of the event.
+func (r *sqlEventStaffRepository) InviteStaff(staff *models.EventStaffModel) error {
+	query := `INSERT INTO public.event_staff (event_id, user_id, role, invited_by) VALUES ($1, $2, $3, $4) RETURNING created_at, updated_at`
+
+	err := r.database.QueryRow(query, staff.EventID, staff.UserID, staff.Role, staff.InvitedBy).Scan(&staff.CreatedAt, &staff.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err, "event_staff_pkey") {
+			return ErrStaffConflict
+		}
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to invite staff: %w", err)
+	}
+
+	return nil
+}
+
+// GetStaff retrieves the staff membership of the user for the event, which may still be a pending invitation.
+func (r *sqlEventStaffRepository) GetStaff(eventId string, userId string) (*models.EventStaffModel, error) {
+	query := `SELECT ` + staffColumns + ` FROM ` + staffTables + ` WHERE s.event_id = $1 AND s.user_id = $2`
+
+	staff, err := scanStaff(r.database.QueryRow(query, eventId, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStaffNotFound
+		}
+		return nil, fmt.Errorf("failed to get staff: %w", err)
+	}
+
+	return staff, nil
+}
+
+// ListStaff retrieves the staff of the event including pending invitations, in the order they were invited.
+func (r *sqlEventStaffRepository) ListStaff(eventId string) ([]*models.EventStaffModel, error) {
+	query := `SELECT ` + staffColumns + ` FROM ` + staffTables + ` WHERE s.event_id = $1 ORDER BY s.created_at, s.user_id`
+
+	return r.queryStaff(query, eventId)
+}
+
+// ListInvitationsForUser retrieves the invitations to the staff of events which the user has not yet accepted, most recent first.
+func (r *sqlEventStaffRepository) ListInvitationsForUser(userId string) ([]*models.EventStaffModel, error) {
+	query := `SELECT ` + staffColumns + ` FROM ` + staffTables + `
+			WHERE s.user_id = $1 AND s.accepted_at IS NULL AND e.status IN ('draft', 'published')
+			ORDER BY s.created_at DESC, s.event_id`
+
+	return r.queryStaff(query, userId)
+}
+
+// ChangeStaffRole stores the role of the staff member.
+func (r *sqlEventStaffRepository) ChangeStaffRole(staff *models.EventStaffModel) error {
+	staff.UpdatedAt = time.Now()
+	query := `UPDATE public.event_staff SET role = $1, updated_at = $2 WHERE event_id = $3 AND user_id = $4`
+
+	return r.update(query, staff.Role, staff.UpdatedAt, staff.EventID, staff.UserID)
+}
+
+// AcceptInvitation records the pending invitation of the staff member as accepted, after which their role takes effect.
+func (r *sqlEventStaffRepository) AcceptInvitation(staff *models.EventStaffModel) error {
+	now := time.Now()
+	query := `UPDATE public.event_staff SET accepted_at = $1, updated_at = $1 WHERE event_id = $2 AND user_id = $3 AND accepted_at IS NULL`
+
+	if err := r.update(query, now, staff.EventID, staff.UserID); err != nil {
+		return err
+	}
+
+	staff.AcceptedAt = sql.NullTime{Time: now, Valid: true}
+	staff.UpdatedAt = now
+
+	return nil
+}
+
+// RemoveStaff removes the user from the staff of the event, or withdraws their pending invitation.
+func (r *sqlEventStaffRepository) RemoveStaff(eventId string, userId string) error {
+	return r.update(`DELETE FROM public.event_staff WHERE event_id = $1 AND user_id = $2`, eventId, userId)
+}
+
+// update executes the statement, which must affect a single staff member.
+func (r *sqlEventStaffRepository) update(query string, args ...any) error {
+	rs, err := r.database.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update staff: %w", err)
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrStaffNotFound
+	}
+
+	return nil
+}
+
+// queryStaff runs the query, which must select the columns listed in staffColumns.
+func (r *sqlEventStaffRepository) queryStaff(query string, args ...any) ([]*models.EventStaffModel, error) {
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staff: %w", err)
+	}
+	defer rows.Close()
+
+	staff := []*models.EventStaffModel{}
+	for rows.Next() {
+		member, err := scanStaff(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list staff: %w", err)
+		}
+		staff = append(staff, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list staff: %w", err)
+	}
+
+	return staff, nil
+}
+
+var (
+	ErrStaffNotFound = errors.New("staff member not found")                           // ErrStaffNotFound is returned when a user is neither staff of the event nor invited to it.
+	ErrStaffConflict = errors.New("user is already staff of or invited to the event") // ErrStaffConflict is returned when inviting a user who is already staff of the event or has a pending invitation.
+)
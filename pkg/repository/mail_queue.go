@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// MailQueueRepository represents the interface for database operations on the queue of emails awaiting delivery.
+type MailQueueRepository interface {
+	EnqueueMail(mail *models.MailModel) error
+	ClaimDueMails(limit int, lease time.Duration) ([]*models.MailModel, error)
+	UpdateMail(mail *models.MailModel) error
+}
+
+type sqlMailQueueRepository struct {
+	database *sql.DB
+}
+
+// NewSQLMailQueueRepository creates and returns a new sql flavoured MailQueueRepository instance.
+func NewSQLMailQueueRepository(database *sql.DB) MailQueueRepository {
+	return &sqlMailQueueRepository{database: database}
+}
+
+// mailColumns lists the columns selected when loading an email, in the order expected by scanMail.
+const mailColumns = `
+				id,
+				recipient,
+				subject,
+				text_body,
+				html_body,
+				status,
+				attempts,
+				last_error,
+				next_attempt_at,
+				sent_at,
+				created_at,
+				updated_at`
+
+// scanMail scans the columns listed in mailColumns into a new mail model.
+func scanMail(row rowScanner) (*models.MailModel, error) {
+	mail := &models.MailModel{}
+	err := row.Scan(
+		&mail.ID,
+		&mail.Recipient,
+		&mail.Subject,
+		&mail.TextBody,
+		&mail.HTMLBody,
+		&mail.Status,
+		&mail.Attempts,
+		&mail.LastError,
+		&mail.NextAttemptAt,
+		&mail.SentAt,
+		&mail.CreatedAt,
+		&mail.UpdatedAt,
+	)
+	return mail, err
+}
+
+// EnqueueMail inserts the email into the queue, to be sent as soon as possible.
+func (r *sqlMailQueueRepository) EnqueueMail(mail *models.MailModel) error {
+	query := `INSERT INTO public.mail_queue (recipient, subject, text_body, html_body, status)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, next_attempt_at, created_at, updated_at`
+
+	err := r.database.QueryRow(query, mail.Recipient, mail.Subject, mail.TextBody, mail.HTMLBody, mail.Status).Scan(&mail.ID, &mail.NextAttemptAt, &mail.CreatedAt, &mail.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue mail: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueMails claims up to the limit of pending emails which are due to be sent, oldest first, counting an attempt to send each of them.
+// Claimed emails are not claimed again until the lease has passed, so that concurrent workers do not send the same email and emails
+// are retried should their worker stop before recording the outcome.
+func (r *sqlMailQueueRepository) ClaimDueMails(limit int, lease time.Duration) ([]*models.MailModel, error) {
+	query := `UPDATE public.mail_queue SET attempts = attempts + 1, next_attempt_at = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id IN (
+				SELECT id FROM public.mail_queue
+				WHERE status = $2 AND next_attempt_at <= CURRENT_TIMESTAMP
+				ORDER BY next_attempt_at
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + mailColumns
+
+	rows, err := r.database.Query(query, time.Now().Add(lease), types.PendingMailStatus, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim mail: %w", err)
+	}
+	defer rows.Close()
+
+	mails := []*models.MailModel{}
+	for rows.Next() {
+		mail, err := scanMail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim mail: %w", err)
+		}
+		mails = append(mails, mail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim mail: %w", err)
+	}
+
+	return mails, nil
+}
+
+// UpdateMail stores the outcome of the latest attempt to send the email.
+func (r *sqlMailQueueRepository) UpdateMail(mail *models.MailModel) error {
+	query := `UPDATE public.mail_queue SET status = $1, last_error = $2, next_attempt_at = $3, sent_at = $4, updated_at = $5 WHERE id = $6`
+
+	if _, err := r.database.Exec(query, mail.Status, mail.LastError, mail.NextAttemptAt, mail.SentAt, mail.UpdatedAt, mail.ID); err != nil {
+		return fmt.Errorf("failed to update mail: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/lib/pq"
+)
+
+// OccurrenceRepository represents the interface for database operations on the exceptions to recurring events occurrences.
+type OccurrenceRepository interface {
+	ListExceptions(eventId string) ([]*models.OccurrenceExceptionModel, error)
+	ListExceptionsForEvents(eventIds []string) (map[string][]*models.OccurrenceExceptionModel, error)
+	SetException(exception *models.OccurrenceExceptionModel) error
+	DeleteException(eventId string, occurrence time.Time) error
+}
+
+type sqlOccurrenceRepository struct {
+	database *sql.DB
+}
+
+// NewSQLOccurrenceRepository creates and returns a new sql flavoured OccurrenceRepository instance.
+func NewSQLOccurrenceRepository(database *sql.DB) OccurrenceRepository {
+	return &sqlOccurrenceRepository{database: database}
+}
+
+// ListExceptions retrieves the exceptions to the occurrences of the event, ordered by occurrence.
+func (r *sqlOccurrenceRepository) ListExceptions(eventId string) ([]*models.OccurrenceExceptionModel, error) {
+	exceptions, err := r.ListExceptionsForEvents([]string{eventId})
+	if err != nil {
+		return nil, err
+	}
+	return exceptions[eventId], nil
+}
+
+// ListExceptionsForEvents retrieves the exceptions to the occurrences of each of the events, keyed by event id.
+func (r *sqlOccurrenceRepository) ListExceptionsForEvents(eventIds []string) (map[string][]*models.OccurrenceExceptionModel, error) {
+	query := `SELECT event_id, occurrence_start, cancelled, start_date, end_date, updated_at
+			FROM public.event_occurrence_exceptions
+			WHERE event_id = ANY($1::uuid[])
+			ORDER BY event_id, occurrence_start`
+
+	rows, err := r.database.Query(query, pq.Array(eventIds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list occurrence exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	exceptions := map[string][]*models.OccurrenceExceptionModel{}
+	for rows.Next() {
+		exception := &models.OccurrenceExceptionModel{}
+		err := rows.Scan(
+			&exception.EventID,
+			&exception.OccurrenceStart,
+			&exception.Cancelled,
+			&exception.StartDate,
+			&exception.EndDate,
+			&exception.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list occurrence exceptions: %w", err)
+		}
+		exceptions[exception.EventID] = append(exceptions[exception.EventID], exception)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list occurrence exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+// SetException creates or replaces the exception to the occurrence.
+// The waitlist of a cancelled occurrence is cleared, as no places will become available, while its attendees are kept.
+func (r *sqlOccurrenceRepository) SetException(exception *models.OccurrenceExceptionModel) error {
+	query := `INSERT INTO public.event_occurrence_exceptions (event_id, occurrence_start, cancelled, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id, occurrence_start) DO UPDATE SET
+			cancelled = EXCLUDED.cancelled,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
+		query,
+		exception.EventID,
+		exception.OccurrenceStart,
+		exception.Cancelled,
+		exception.StartDate,
+		exception.EndDate,
+	).Scan(&exception.UpdatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to set occurrence exception: %w", err)
+	}
+
+	if exception.Cancelled {
+		if _, err := tx.Exec(`DELETE FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start = $2`, exception.EventID, exception.OccurrenceStart); err != nil {
+			return fmt.Errorf("failed to set occurrence exception: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteException removes the exception to the occurrence, restoring it to the schedule of its event.
+func (r *sqlOccurrenceRepository) DeleteException(eventId string, occurrence time.Time) error {
+	query := `DELETE FROM public.event_occurrence_exceptions WHERE event_id = $1 AND occurrence_start = $2`
+
+	rs, err := r.database.Exec(query, eventId, occurrence)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrOccurrenceExceptionNotFound
+	}
+
+	return nil
+}
+
+var (
+	ErrOccurrenceExceptionNotFound = errors.New("occurrence has not been changed") // ErrOccurrenceExceptionNotFound is returned when an occurrence has no exception to remove.
+)
@@ -0,0 +1,379 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/lib/pq"
+)
+
+// OrderRepository represents the interface for database operations on ticket orders.
+type OrderRepository interface {
+	CreateOrder(order *models.OrderModel) error
+	GetOrderByID(id string) (*models.OrderModel, error)
+	ListOrdersForUser(userId string) ([]*models.OrderModel, error)
+	ListOrdersForEvent(eventId string, occurrence *time.Time) ([]*models.OrderModel, error)
+	ChangeOrderStatus(order *models.OrderModel, previous types.OrderStatus) error
+	ExpirePendingOrders(now time.Time) (int64, error)
+	SetPaymentIntent(order *models.OrderModel) error
+	IsPaymentEventProcessed(provider string, eventId string) (bool, error)
+	RecordPaymentEvent(provider string, eventId string, eventType string, orderId string) error
+}
+
+type sqlOrderRepository struct {
+	database *sql.DB
+}
+
+// NewSQLOrderRepository creates and returns a new sql flavoured OrderRepository instance.
+func NewSQLOrderRepository(database *sql.DB) OrderRepository {
+	return &sqlOrderRepository{database: database}
+}
+
+// orderColumns lists the columns selected when loading an order, in the order expected by scanOrder.
+const orderColumns = `
+				o.id,
+				o.event_id,
+				o.user_id,
+				o.occurrence_start,
+				o.status,
+				o.total,
+				o.currency,
+				o.expires_at,
+				o.paid_at,
+				o.payment_provider,
+				o.payment_intent_id,
+				o.created_at,
+				o.updated_at`
+
+// scanOrder scans the columns listed in orderColumns into a new order model, without its items.
+func scanOrder(row rowScanner) (*models.OrderModel, error) {
+	order := &models.OrderModel{}
+	err := row.Scan(
+		&order.ID,
+		&order.EventID,
+		&order.UserID,
+		&order.OccurrenceStart,
+		&order.Status,
+		&order.Total,
+		&order.Currency,
+		&order.ExpiresAt,
+		&order.PaidAt,
+		&order.PaymentProvider,
+		&order.PaymentIntentID,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	return order, err
+}
+
+// CreateOrder inserts the pending order and its items, holding its tickets until it expires.
+// Fails when the event is not published or any of the ordered ticket types has too few tickets left for the occurrence.
+func (r *sqlOrderRepository) CreateOrder(order *models.OrderModel) error {
+	order.BeforeCreate()
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the event lock serializes orders, so the tickets held by other orders can not change while counting them.
+	event, err := lockEvent(tx, order.EventID, order.OccurrenceStart)
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+	if event.status != types.PublishedEventStatus {
+		return ErrEventNotOpen
+	}
+
+	for _, item := range order.Items {
+		var name string
+		var available int
+		err := tx.QueryRow(
+			`SELECT t.name, t.quantity - `+heldTickets+` FROM public.ticket_types t WHERE t.id = $1 AND t.event_id = $3`,
+			item.TicketTypeID, order.OccurrenceStart, order.EventID,
+		).Scan(&name, &available)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTicketTypeNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+		if available < item.Quantity {
+			return fmt.Errorf("%w, %d %s tickets remain", ErrTicketsUnavailable, max(available, 0), name)
+		}
+	}
+
+	err = tx.QueryRow(
+		`INSERT INTO public.orders (event_id, user_id, occurrence_start, status, total, currency, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at, updated_at`,
+		order.EventID,
+		order.UserID,
+		order.OccurrenceStart,
+		order.Status,
+		order.Total,
+		order.Currency,
+		order.ExpiresAt,
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+
+	for _, item := range order.Items {
+		_, err := tx.Exec(
+			`INSERT INTO public.order_items (order_id, ticket_type_id, quantity, unit_price) VALUES ($1, $2, $3, $4)`,
+			order.ID, item.TicketTypeID, item.Quantity, item.UnitPrice,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.AfterCreate()
+
+	return nil
+}
+
+// GetOrderByID retrieves an order and its items from the database by its unique ID.
+func (r *sqlOrderRepository) GetOrderByID(id string) (*models.OrderModel, error) {
+	query := `SELECT ` + orderColumns + ` FROM public.orders o WHERE o.id = $1`
+
+	order, err := scanOrder(r.database.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidOrderId
+	}
+
+	if err := r.loadItems(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ListOrdersForUser retrieves the orders placed by the user, most recent first.
+func (r *sqlOrderRepository) ListOrdersForUser(userId string) ([]*models.OrderModel, error) {
+	query := `SELECT ` + orderColumns + ` FROM public.orders o WHERE o.user_id = $1 ORDER BY o.created_at DESC, o.id`
+
+	return r.queryOrders(query, userId)
+}
+
+// ListOrdersForEvent retrieves the orders of the event, or only the provided occurrence of the event, most recent first.
+func (r *sqlOrderRepository) ListOrdersForEvent(eventId string, occurrence *time.Time) ([]*models.OrderModel, error) {
+	query := `SELECT ` + orderColumns + ` FROM public.orders o
+			WHERE o.event_id = $1 AND ($2::timestamptz IS NULL OR o.occurrence_start = $2)
+			ORDER BY o.created_at DESC, o.id`
+
+	return r.queryOrders(query, eventId, occurrence)
+}
+
+// ChangeOrderStatus stores the status of the order, provided it still has the previous status.
+// Paying an order makes its user an attendee of the ordered occurrence, and refunding it removes them unless they hold another paid order for the occurrence.
+func (r *sqlOrderRepository) ChangeOrderStatus(order *models.OrderModel, previous types.OrderStatus) error {
+	order.BeforeUpdate()
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the event lock serializes the change with other changes to the attendance of the event.
+	if _, err := lockEvent(tx, order.EventID, order.OccurrenceStart); err != nil {
+		return fmt.Errorf("failed to change order status: %w", err)
+	}
+
+	rs, err := tx.Exec(
+		`UPDATE public.orders SET status = $1, paid_at = $2, updated_at = $3 WHERE id = $4 AND status = $5`,
+		order.Status, order.PaidAt, order.UpdatedAt, order.ID, previous,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to change order status: %w", err)
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrOrderStatusConflict
+	}
+
+	switch {
+	case order.Status == types.PaidOrderStatus:
+		// a paid order secures a place, so the user no longer waits for one.
+		queries := []string{
+			`INSERT INTO public.event_attendees (event_id, occurrence_start, attendee_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+			`DELETE FROM public.event_waitlist WHERE event_id = $1 AND occurrence_start = $2 AND user_id = $3`,
+		}
+		for _, query := range queries {
+			if _, err := tx.Exec(query, order.EventID, order.OccurrenceStart, order.UserID); err != nil {
+				return fmt.Errorf("failed to change order status: %w", err)
+			}
+		}
+	case previous == types.PaidOrderStatus:
+		_, err := tx.Exec(
+			`DELETE FROM public.event_attendees ea WHERE ea.event_id = $1 AND ea.occurrence_start = $2 AND ea.attendee_id = $3 AND NOT EXISTS (
+				SELECT 1 FROM public.orders o WHERE o.event_id = $1 AND o.occurrence_start = $2 AND o.user_id = $3 AND o.status = 'paid'
+			)`,
+			order.EventID, order.OccurrenceStart, order.UserID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to change order status: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.AfterUpdate()
+
+	return nil
+}
+
+// ExpirePendingOrders cancels all pending orders which expired before now, returning the number of cancelled orders.
+// The tickets of expired orders are already available to others, so this only records that they can no longer be paid.
+func (r *sqlOrderRepository) ExpirePendingOrders(now time.Time) (int64, error) {
+	query := `UPDATE public.orders SET status = 'cancelled', updated_at = $1 WHERE status = 'pending' AND expires_at <= $1`
+
+	rs, err := r.database.Exec(query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire pending orders: %w", err)
+	}
+
+	return rs.RowsAffected()
+}
+
+// SetPaymentIntent stores the payment intent of the order, which must still be pending.
+func (r *sqlOrderRepository) SetPaymentIntent(order *models.OrderModel) error {
+	order.BeforeUpdate()
+	query := `UPDATE public.orders SET payment_provider = $1, payment_intent_id = $2, updated_at = $3 WHERE id = $4 AND status = 'pending'`
+
+	rs, err := r.database.Exec(query, order.PaymentProvider, order.PaymentIntentID, order.UpdatedAt, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to set payment intent: %w", err)
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrOrderStatusConflict
+	}
+
+	order.AfterUpdate()
+
+	return nil
+}
+
+// IsPaymentEventProcessed returns true if the webhook event of the payment provider has already been processed.
+func (r *sqlOrderRepository) IsPaymentEventProcessed(provider string, eventId string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM public.payment_events WHERE provider = $1 AND event_id = $2)`
+
+	var processed bool
+	if err := r.database.QueryRow(query, provider, eventId).Scan(&processed); err != nil {
+		return false, fmt.Errorf("failed to check payment event: %w", err)
+	}
+
+	return processed, nil
+}
+
+// RecordPaymentEvent records that the webhook event of the payment provider has been processed, the order id is empty for events of unknown orders.
+func (r *sqlOrderRepository) RecordPaymentEvent(provider string, eventId string, eventType string, orderId string) error {
+	query := `INSERT INTO public.payment_events (provider, event_id, event_type, order_id) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`
+
+	if _, err := r.database.Exec(query, provider, eventId, eventType, sql.NullString{String: orderId, Valid: len(orderId) > 0}); err != nil {
+		return fmt.Errorf("failed to record payment event: %w", err)
+	}
+
+	return nil
+}
+
+// queryOrders runs the query selecting orderColumns and loads the items of each of the resulting orders.
+func (r *sqlOrderRepository) queryOrders(query string, args ...any) ([]*models.OrderModel, error) {
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.OrderModel{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	if err := r.loadItems(orders...); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// loadItems sets the items of each of the orders.
+func (r *sqlOrderRepository) loadItems(orders ...*models.OrderModel) error {
+	if len(orders) < 1 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(orders))
+	byId := map[string]*models.OrderModel{}
+	for _, order := range orders {
+		order.Items = []*models.OrderItemModel{}
+		ids = append(ids, order.ID)
+		byId[order.ID] = order
+	}
+
+	query := `SELECT oi.order_id, oi.ticket_type_id, t.name, oi.quantity, oi.unit_price
+			FROM public.order_items oi JOIN public.ticket_types t ON t.id = oi.ticket_type_id
+			WHERE oi.order_id = ANY($1)
+			ORDER BY oi.order_id, oi.unit_price, t.name`
+
+	rows, err := r.database.Query(query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to load order items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderId string
+		item := &models.OrderItemModel{}
+		if err := rows.Scan(&orderId, &item.TicketTypeID, &item.Name, &item.Quantity, &item.UnitPrice); err != nil {
+			return fmt.Errorf("failed to load order items: %w", err)
+		}
+		byId[orderId].Items = append(byId[orderId].Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to load order items: %w", err)
+	}
+
+	return nil
+}
+
+var (
+	ErrOrderNotFound       = errors.New("order not found")                             // ErrOrderNotFound is returned when an order is not found in the database.
+	ErrInvalidOrderId      = errors.New("invalid order id")                            // ErrInvalidOrderId is returned when an order id is invalid or malformed.
+	ErrOrderStatusConflict = errors.New("order status was changed by another request") // ErrOrderStatusConflict is returned when an orders status changed before it could be updated.
+	ErrTicketsUnavailable  = errors.New("not enough tickets are available")            // ErrTicketsUnavailable is returned when ordering more tickets than remain for the occurrence.
+)
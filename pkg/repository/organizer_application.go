@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/lib/pq"
+)
+
+// OrganizerApplicationRepository represents the interface for database operations on applications of users to become organizers.
+type OrganizerApplicationRepository interface {
+	CreateApplication(application *models.OrganizerApplicationModel) error
+	GetApplicationByID(id string) (*models.OrganizerApplicationModel, error)
+	GetLatestApplicationForUser(userId string) (*models.OrganizerApplicationModel, error)
+	ListApplications(status types.OrganizerApplicationStatus) ([]*models.OrganizerApplicationModel, error)
+	ReviewApplication(application *models.OrganizerApplicationModel) error
+}
+
+type sqlOrganizerApplicationRepository struct {
+	database *sql.DB
+}
+
+// NewSQLOrganizerApplicationRepository creates and returns a new sql flavoured OrganizerApplicationRepository instance.
+func NewSQLOrganizerApplicationRepository(database *sql.DB) OrganizerApplicationRepository {
+	return &sqlOrganizerApplicationRepository{database: database}
+}
+
+// applicationColumns lists the columns selected when loading an application, in the order expected by scanApplication.
+const applicationColumns = `
+				a.id,
+				a.user_id,
+				a.reason,
+				a.links,
+				a.contact_email,
+				a.contact_phone,
+				a.status,
+				a.reviewed_by,
+				a.review_comment,
+				a.reviewed_at,
+				a.created_at,
+				a.updated_at,
+				u.username`
+
+// scanApplication scans the columns listed in applicationColumns into a new application model.
+func scanApplication(row rowScanner) (*models.OrganizerApplicationModel, error) {
+	application := &models.OrganizerApplicationModel{}
+	err := row.Scan(
+		&application.ID,
+		&application.UserID,
+		&application.Reason,
+		pq.Array(&application.Links),
+		&application.ContactEmail,
+		&application.ContactPhone,
+		&application.Status,
+		&application.ReviewedBy,
+		&application.ReviewComment,
+		&application.ReviewedAt,
+		&application.CreatedAt,
+		&application.UpdatedAt,
+		&application.Username,
+	)
+	return application, err
+}
+
+// CreateApplication inserts the pending application, which fails when the user already has an application awaiting review.
+func (r *sqlOrganizerApplicationRepository) CreateApplication(application *models.OrganizerApplicationModel) error {
+	query := `INSERT INTO public.organizer_applications (user_id, reason, links, contact_email, contact_phone, status)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`
+
+	err := r.database.QueryRow(
+		query,
+		application.UserID,
+		application.Reason,
+		pq.Array(application.Links),
+		application.ContactEmail,
+		application.ContactPhone,
+		application.Status,
+	).Scan(&application.ID, &application.CreatedAt, &application.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err, "organizer_applications_user_id_pending_key") {
+			return ErrApplicationPending
+		}
+		return fmt.Errorf("failed to create organizer application: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplicationByID retrieves an application from the database by its unique ID.
+func (r *sqlOrganizerApplicationRepository) GetApplicationByID(id string) (*models.OrganizerApplicationModel, error) {
+	query := `SELECT ` + applicationColumns + ` FROM public.organizer_applications a
+			JOIN public.users u ON u.id = a.user_id
+			WHERE a.id = $1`
+
+	return r.getApplication(query, id)
+}
+
+// GetLatestApplicationForUser retrieves the most recent application of the user.
+func (r *sqlOrganizerApplicationRepository) GetLatestApplicationForUser(userId string) (*models.OrganizerApplicationModel, error) {
+	query := `SELECT ` + applicationColumns + ` FROM public.organizer_applications a
+			JOIN public.users u ON u.id = a.user_id
+			WHERE a.user_id = $1
+			ORDER BY a.created_at DESC, a.id
+			LIMIT 1`
+
+	return r.getApplication(query, userId)
+}
+
+// ListApplications retrieves the applications with the status, oldest first so that pending applications are reviewed in the order they were made.
+func (r *sqlOrganizerApplicationRepository) ListApplications(status types.OrganizerApplicationStatus) ([]*models.OrganizerApplicationModel, error) {
+	query := `SELECT ` + applicationColumns + ` FROM public.organizer_applications a
+			JOIN public.users u ON u.id = a.user_id
+			WHERE a.status = $1
+			ORDER BY a.created_at, a.id`
+
+	rows, err := r.database.Query(query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizer applications: %w", err)
+	}
+	defer rows.Close()
+
+	applications := []*models.OrganizerApplicationModel{}
+	for rows.Next() {
+		application, err := scanApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizer applications: %w", err)
+		}
+		applications = append(applications, application)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organizer applications: %w", err)
+	}
+
+	return applications, nil
+}
+
+// ReviewApplication stores the decision on the application, provided it is still pending.
+// Approving an application changes the role of the applicant to organizer, unless they already have a role beyond that of a user.
+func (r *sqlOrganizerApplicationRepository) ReviewApplication(application *models.OrganizerApplicationModel) error {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rs, err := tx.Exec(
+		`UPDATE public.organizer_applications
+			SET status = $1, reviewed_by = $2, review_comment = $3, reviewed_at = $4, updated_at = $5
+			WHERE id = $6 AND status = $7`,
+		application.Status,
+		application.ReviewedBy,
+		application.ReviewComment,
+		application.ReviewedAt,
+		application.UpdatedAt,
+		application.ID,
+		types.PendingApplicationStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to review organizer application: %w", err)
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrApplicationReviewed
+	}
+
+	if application.IsApproved() {
+		_, err := tx.Exec(
+			`UPDATE public.users SET role = $1, updated_at = $2 WHERE id = $3 AND role = $4`,
+			types.OrganizerRole, application.UpdatedAt, application.UserID, types.UserRole,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to promote applicant: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getApplication runs the query, which must select the columns listed in applicationColumns for a single application.
+func (r *sqlOrganizerApplicationRepository) getApplication(query string, args ...any) (*models.OrganizerApplicationModel, error) {
+	application, err := scanApplication(r.database.QueryRow(query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrApplicationNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidApplicationId
+	}
+
+	return application, nil
+}
+
+var (
+	ErrApplicationNotFound  = errors.New("organizer application not found")                        // ErrApplicationNotFound is returned when an organizer application does not exist.
+	ErrInvalidApplicationId = errors.New("invalid organizer application id")                       // ErrInvalidApplicationId is returned when looking up an application with an id that is not a valid uuid.
+	ErrApplicationPending   = errors.New("user already has an organizer application under review") // ErrApplicationPending is returned when applying while a previous application awaits review.
+	ErrApplicationReviewed  = errors.New("organizer application was already reviewed")             // ErrApplicationReviewed is returned when reviewing an application which is no longer pending.
+)
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// ReviewRepository represents the interface for review-related database operations.
+type ReviewRepository interface {
+	CreateReview(review *models.ReviewModel) error
+	GetReviewByID(id string) (*models.ReviewModel, error)
+	UpdateReview(review *models.ReviewModel) error
+	DeleteReview(id string) error
+	ListReviewsForEvent(eventId string) ([]*models.ReviewModel, error)
+	GetEventRatingSummary(eventId string) (*models.RatingSummary, error)
+	GetOrganizerRatingSummary(organizerId string) (*models.RatingSummary, error)
+}
+
+type sqlReviewRepository struct {
+	database *sql.DB
+}
+
+// NewSQLReviewRepository creates and returns a new sql flavoured ReviewRepository instance.
+func NewSQLReviewRepository(database *sql.DB) ReviewRepository {
+	return &sqlReviewRepository{database: database}
+}
+
+// reviewColumns lists the columns selected when loading a review, in the order expected by scanReview.
+const reviewColumns = `
+				r.id,
+				r.title,
+				r.body,
+				r.rating,
+				r.event_id,
+				r.author_id,
+				r.created_at,
+				r.updated_at`
+
+// scanReview scans the columns listed in reviewColumns into a new review model.
+func scanReview(row rowScanner) (*models.ReviewModel, error) {
+	review := &models.ReviewModel{}
+	err := row.Scan(
+		&review.ID,
+		&review.Title,
+		&review.Body,
+		&review.Rating,
+		&review.EventID,
+		&review.AuthorID,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	return review, err
+}
+
+// CreateReview inserts a new review into the database.
+func (r *sqlReviewRepository) CreateReview(review *models.ReviewModel) error {
+	review.BeforeCreate()
+
+	query := `INSERT INTO public.reviews (title, body, rating, event_id, author_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`
+
+	err := r.database.QueryRow(query, review.Title, review.Body, review.Rating, review.EventID, review.AuthorID).Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err, "reviews_event_id_author_id_key") {
+			return ErrAlreadyReviewed
+		}
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	review.AfterCreate()
+
+	return nil
+}
+
+// GetReviewByID retrieves a review from the database by its unique ID.
+func (r *sqlReviewRepository) GetReviewByID(id string) (*models.ReviewModel, error) {
+	query := `SELECT ` + reviewColumns + ` FROM public.reviews r WHERE r.id = $1`
+
+	review, err := scanReview(r.database.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidReviewId
+	}
+
+	return review, nil
+}
+
+// UpdateReview update a review in the database.
+func (r *sqlReviewRepository) UpdateReview(review *models.ReviewModel) error {
+	review.BeforeUpdate()
+	query := `UPDATE public.reviews SET title = $1, body = $2, rating = $3, updated_at = $4 WHERE id = $5`
+
+	// This is a guard to prevent any partial review from being submitted.
+	// Otherwise it would be possible to accidently empty out columns by passing empty/uninitialized values.
+	if review.CreatedAt.IsZero() {
+		return fmt.Errorf("unable to update a review that was not loaded from the database")
+	}
+
+	rs, err := r.database.Exec(
+		query,
+		review.Title,
+		review.Body,
+		review.Rating,
+		review.UpdatedAt, // now updated in model BeforeUpdate lifecycle hook
+		review.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrReviewNotFound
+	}
+
+	review.AfterUpdate()
+
+	return nil
+}
+
+// DeleteReview delete a review from the database.
+func (r *sqlReviewRepository) DeleteReview(id string) error {
+	query := `DELETE FROM public.reviews WHERE id = $1`
+
+	rs, err := r.database.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrReviewNotFound
+	}
+
+	return nil
+}
+
+// ListReviewsForEvent retrieves the reviews of the event, newest first.
+func (r *sqlReviewRepository) ListReviewsForEvent(eventId string) ([]*models.ReviewModel, error) {
+	query := `SELECT ` + reviewColumns + ` FROM public.reviews r WHERE r.event_id = $1 ORDER BY r.created_at DESC, r.id`
+
+	rows, err := r.database.Query(query, eventId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*models.ReviewModel{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// GetEventRatingSummary retrieves the aggregated ratings of the event, as maintained by the update_rating_summary trigger.
+func (r *sqlReviewRepository) GetEventRatingSummary(eventId string) (*models.RatingSummary, error) {
+	query := `SELECT 
+				COALESCE(SUM(one_star), 0),
+				COALESCE(SUM(two_star), 0),
+				COALESCE(SUM(three_star), 0),
+				COALESCE(SUM(four_star), 0),
+				COALESCE(SUM(five_star), 0)
+			FROM public.event_rating_summaries WHERE event_id = $1`
+
+	return r.getRatingSummary(query, eventId)
+}
+
+// GetOrganizerRatingSummary retrieves the aggregated ratings across all events organized by the user.
+func (r *sqlReviewRepository) GetOrganizerRatingSummary(organizerId string) (*models.RatingSummary, error) {
+	query := `SELECT 
+				COALESCE(SUM(s.one_star), 0),
+				COALESCE(SUM(s.two_star), 0),
+				COALESCE(SUM(s.three_star), 0),
+				COALESCE(SUM(s.four_star), 0),
+				COALESCE(SUM(s.five_star), 0)
+			FROM public.event_rating_summaries s JOIN public.events e ON e.id = s.event_id
+			WHERE e.organizer_id = $1`
+
+	return r.getRatingSummary(query, organizerId)
+}
+
+func (r *sqlReviewRepository) getRatingSummary(query string, args ...any) (*models.RatingSummary, error) {
+	var oneStar, twoStar, threeStar, fourStar, fiveStar int
+	err := r.database.QueryRow(query, args...).Scan(&oneStar, &twoStar, &threeStar, &fourStar, &fiveStar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating summary: %w", err)
+	}
+
+	return models.NewRatingSummary(oneStar, twoStar, threeStar, fourStar, fiveStar), nil
+}
+
+var (
+	ErrReviewNotFound  = errors.New("review not found")                    // ErrReviewNotFound is returned when a review is not found in the database.
+	ErrInvalidReviewId = errors.New("invalid review id")                   // ErrInvalidReviewId is returned when a review id is invalid or malformed.
+	ErrAlreadyReviewed = errors.New("user has already reviewed the event") // ErrAlreadyReviewed is returned when a user attempts to review an event more than once.
+)
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// SessionRepository represents the interface for database operations on the sessions of users and the refresh tokens issued for them.
+type SessionRepository interface {
+	CreateSession(session *models.SessionModel) (string, error)
+	RotateRefreshToken(tokenId string, use SessionUse) (*models.SessionModel, string, error)
+	ListActiveSessions(userId string) ([]*models.SessionModel, error)
+	RevokeSession(userId string, sessionId string) error
+	RevokeUserSessions(userId string, exceptSessionId string) (int64, error)
+	IsSessionActive(userId string, sessionId string) (bool, error)
+}
+
+// SessionUse describes the device refreshing a session, and until when the refreshed session is kept alive.
+type SessionUse struct {
+	At        time.Time
+	UserAgent string
+	IPAddress string
+	ExpiresAt time.Time
+}
+
+type sqlSessionRepository struct {
+	database *sql.DB
+}
+
+// NewSQLSessionRepository creates and returns a new sql flavoured SessionRepository instance.
+func NewSQLSessionRepository(database *sql.DB) SessionRepository {
+	return &sqlSessionRepository{database: database}
+}
+
+// sessionColumns lists the columns selected when loading a session, in the order expected by scanSession.
+const sessionColumns = `id, user_id, user_agent, ip_address, created_at, last_used_at, expires_at, revoked_at`
+
+// scanSession scans the columns listed in sessionColumns into a new session model.
+func scanSession(row rowScanner) (*models.SessionModel, error) {
+	session := &models.SessionModel{}
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+	return session, err
+}
+
+// CreateSession inserts the session along with its first refresh token, returning the id of the refresh token.
+func (r *sqlSessionRepository) CreateSession(session *models.SessionModel) (string, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO public.user_sessions (user_id, user_agent, ip_address, expires_at)
+			VALUES ($1, $2, $3, $4) RETURNING id, created_at, last_used_at`
+
+	err = tx.QueryRow(query, session.UserID, session.UserAgent, session.IPAddress, session.ExpiresAt).Scan(&session.ID, &session.CreatedAt, &session.LastUsedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	tokenId, err := insertRefreshToken(tx, session.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenId, tx.Commit()
+}
+
+// RotateRefreshToken uses the refresh token with the id, replacing it with a new refresh token of the same session which is returned along with the refreshed session.
+// Each refresh token may only be used once. Using a refresh token again means it was stolen, from either the user or whoever stole it,
+// so the whole session is revoked and ErrRefreshTokenReused is returned.
+func (r *sqlSessionRepository) RotateRefreshToken(tokenId string, use SessionUse) (*models.SessionModel, string, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback()
+
+	var (
+		sessionId string
+		usedAt    sql.NullTime
+	)
+	err = tx.QueryRow(`SELECT session_id, used_at FROM public.refresh_tokens WHERE id = $1 FOR UPDATE`, tokenId).Scan(&sessionId, &usedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrSessionNotFound
+		}
+		return nil, "", fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	if usedAt.Valid {
+		if _, err := tx.Exec(`UPDATE public.user_sessions SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, use.At, sessionId); err != nil {
+			return nil, "", fmt.Errorf("failed to revoke session: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, "", err
+		}
+		return nil, "", ErrRefreshTokenReused
+	}
+
+	query := `UPDATE public.user_sessions SET user_agent = $1, ip_address = $2, last_used_at = $3, expires_at = $4
+			WHERE id = $5 AND revoked_at IS NULL AND expires_at > $3
+			RETURNING ` + sessionColumns
+
+	session, err := scanSession(tx.QueryRow(query, use.UserAgent, use.IPAddress, use.At, use.ExpiresAt, sessionId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrSessionRevoked
+		}
+		return nil, "", fmt.Errorf("failed to refresh session: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE public.refresh_tokens SET used_at = $1 WHERE id = $2`, use.At, tokenId); err != nil {
+		return nil, "", fmt.Errorf("failed to use refresh token: %w", err)
+	}
+
+	next, err := insertRefreshToken(tx, sessionId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, next, tx.Commit()
+}
+
+// ListActiveSessions retrieves the sessions of the user which were neither revoked nor have expired, most recently used first.
+func (r *sqlSessionRepository) ListActiveSessions(userId string) ([]*models.SessionModel, error) {
+	query := `SELECT ` + sessionColumns + ` FROM public.user_sessions
+			WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+			ORDER BY last_used_at DESC, id`
+
+	rows, err := r.database.Query(query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*models.SessionModel{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes the active session of the user with the id, its refresh tokens are no longer accepted.
+func (r *sqlSessionRepository) RevokeSession(userId string, sessionId string) error {
+	// the id is compared as text, so that ids which are not a valid uuid are simply not found.
+	query := `UPDATE public.user_sessions SET revoked_at = CURRENT_TIMESTAMP
+			WHERE id::text = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+
+	rs, err := r.database.Exec(query, sessionId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeUserSessions revokes every active session of the user, other than the session with the excepted id when one is provided.
+// Returns the number of sessions which were revoked.
+func (r *sqlSessionRepository) RevokeUserSessions(userId string, exceptSessionId string) (int64, error) {
+	query := `UPDATE public.user_sessions SET revoked_at = CURRENT_TIMESTAMP
+			WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP AND id::text <> $2`
+
+	rs, err := r.database.Exec(query, userId, exceptSessionId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return rs.RowsAffected()
+}
+
+// IsSessionActive returns true if the user has a session with the id which was neither revoked nor has expired.
+func (r *sqlSessionRepository) IsSessionActive(userId string, sessionId string) (bool, error) {
+	// ids which are not a valid uuid are never active, checking them first keeps the lookup on the primary key.
+	if !utils.IsUUID(sessionId) {
+		return false, nil
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM public.user_sessions
+				WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP)`
+
+	var active bool
+	if err := r.database.QueryRow(query, sessionId, userId).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return active, nil
+}
+
+// insertRefreshToken inserts a new refresh token of the session, returning its id.
+func insertRefreshToken(tx *sql.Tx, sessionId string) (string, error) {
+	var tokenId string
+	if err := tx.QueryRow(`INSERT INTO public.refresh_tokens (session_id) VALUES ($1) RETURNING id`, sessionId).Scan(&tokenId); err != nil {
+		return "", fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return tokenId, nil
+}
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")                  // ErrSessionNotFound is returned when a refresh token was not issued for any session, or revoking a session the user does not have.
+	ErrSessionRevoked     = errors.New("session was revoked or has expired") // ErrSessionRevoked is returned when refreshing a session which was revoked or has expired.
+	ErrRefreshTokenReused = errors.New("refresh token was already used")     // ErrRefreshTokenReused is returned when a refresh token is used again, which revokes its session.
+)
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// TagRepository represents the interface for tag-related database operations.
+type TagRepository interface {
+	CreateTag(tag *models.TagModel) (bool, error)
+	GetTagByID(id string) (*models.TagModel, error)
+	SearchTags(prefix string, limit int) ([]*models.TagModel, error)
+	ListPopularTags(limit int) ([]*models.TagModel, error)
+	ListTagsForEvent(eventId string) ([]*models.TagModel, error)
+	AttachTag(eventId string, tagId string) error
+	DetachTag(eventId string, tagId string) error
+}
+
+const (
+	DefaultTagPageSize = 10 // DefaultTagPageSize is the number of tags returned when no limit is provided.
+	MaxTagPageSize     = 50 // MaxTagPageSize is the maximum number of tags which can be requested at once.
+)
+
+type sqlTagRepository struct {
+	database *sql.DB
+}
+
+// NewSQLTagRepository creates and returns a new sql flavoured TagRepository instance.
+func NewSQLTagRepository(database *sql.DB) TagRepository {
+	return &sqlTagRepository{database: database}
+}
+
+// tagColumns lists the columns selected when loading a tag, in the order expected by scanTag.
+const tagColumns = `
+				t.id,
+				t.name,
+				(SELECT COUNT(*) FROM public.event_tags et WHERE et.tag_id = t.id)`
+
+// scanTag scans the columns listed in tagColumns into a new tag model.
+func scanTag(row rowScanner) (*models.TagModel, error) {
+	tag := &models.TagModel{}
+	err := row.Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.Events,
+	)
+	return tag, err
+}
+
+// likeEscaper escapes the wildcard characters of a LIKE pattern so that they are matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// CreateTag inserts a new tag into the database unless a tag with the same name, ignoring case, already exists.
+// The tag is populated from the existing tag in that case and false is returned.
+func (r *sqlTagRepository) CreateTag(tag *models.TagModel) (bool, error) {
+	query := `INSERT INTO public.tags (name) VALUES ($1) ON CONFLICT ((lower(name))) DO NOTHING RETURNING id`
+
+	err := r.database.QueryRow(query, tag.Name).Scan(&tag.ID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	query = `SELECT ` + tagColumns + ` FROM public.tags t WHERE lower(t.name) = lower($1)`
+
+	existing, err := scanTag(r.database.QueryRow(query, tag.Name))
+	if err != nil {
+		return false, fmt.Errorf("failed to load existing tag: %w", err)
+	}
+	*tag = *existing
+
+	return false, nil
+}
+
+// GetTagByID retrieves a tag from the database by its unique ID.
+func (r *sqlTagRepository) GetTagByID(id string) (*models.TagModel, error) {
+	query := `SELECT ` + tagColumns + ` FROM public.tags t WHERE t.id = $1`
+
+	tag, err := scanTag(r.database.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTagNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidTagId
+	}
+
+	return tag, nil
+}
+
+// SearchTags retrieves the tags whose name starts with the prefix, ignoring case, most used first.
+func (r *sqlTagRepository) SearchTags(prefix string, limit int) ([]*models.TagModel, error) {
+	query := `SELECT ` + tagColumns + ` FROM public.tags t WHERE lower(t.name) LIKE lower($1) ORDER BY 3 DESC, lower(t.name) LIMIT $2`
+
+	return r.queryTags(query, likeEscaper.Replace(prefix)+"%", tagPageSize(limit))
+}
+
+// ListPopularTags retrieves the tags attached to the most events.
+func (r *sqlTagRepository) ListPopularTags(limit int) ([]*models.TagModel, error) {
+	query := `SELECT ` + tagColumns + ` FROM public.tags t
+			WHERE EXISTS (SELECT 1 FROM public.event_tags et WHERE et.tag_id = t.id)
+			ORDER BY 3 DESC, lower(t.name) LIMIT $1`
+
+	return r.queryTags(query, tagPageSize(limit))
+}
+
+// ListTagsForEvent retrieves the tags attached to the event, ordered by name.
+func (r *sqlTagRepository) ListTagsForEvent(eventId string) ([]*models.TagModel, error) {
+	query := `SELECT ` + tagColumns + ` FROM public.tags t
+			JOIN public.event_tags e ON e.tag_id = t.id
+			WHERE e.event_id = $1
+			ORDER BY lower(t.name)`
+
+	return r.queryTags(query, eventId)
+}
+
+// AttachTag attaches the tag to the event, attaching an already attached tag has no effect.
+func (r *sqlTagRepository) AttachTag(eventId string, tagId string) error {
+	query := `INSERT INTO public.event_tags (event_id, tag_id) VALUES ($1, $2) ON CONFLICT (event_id, tag_id) DO NOTHING`
+
+	if _, err := r.database.Exec(query, eventId, tagId); err != nil {
+		if isForeignKeyViolationOf(err, "event_tags_tag_id_fkey") {
+			return ErrTagNotFound
+		}
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to attach tag: %w", err)
+	}
+
+	return nil
+}
+
+// DetachTag removes the tag from the event, detaching a tag which is not attached has no effect.
+func (r *sqlTagRepository) DetachTag(eventId string, tagId string) error {
+	query := `DELETE FROM public.event_tags WHERE event_id = $1 AND tag_id = $2`
+
+	if _, err := r.database.Exec(query, eventId, tagId); err != nil {
+		return fmt.Errorf("failed to detach tag: %w", err)
+	}
+
+	return nil
+}
+
+// tagPageSize returns the limit, or the default page size when the limit is out of bounds.
+func tagPageSize(limit int) int {
+	if limit < 1 || limit > MaxTagPageSize {
+		return DefaultTagPageSize
+	}
+	return limit
+}
+
+func (r *sqlTagRepository) queryTags(query string, args ...any) ([]*models.TagModel, error) {
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []*models.TagModel{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+var (
+	ErrTagNotFound  = errors.New("tag not found")  // ErrTagNotFound is returned when a tag is not found in the database.
+	ErrInvalidTagId = errors.New("invalid tag id") // ErrInvalidTagId is returned when a tag id is invalid or malformed.
+)
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// TicketTypeRepository represents the interface for database operations on the ticket types of paid events.
+type TicketTypeRepository interface {
+	CreateTicketType(ticketType *models.TicketTypeModel) error
+	GetTicketTypeByID(id string) (*models.TicketTypeModel, error)
+	ListTicketTypes(eventId string, occurrence *time.Time) ([]*models.TicketTypeModel, error)
+	UpdateTicketType(ticketType *models.TicketTypeModel) error
+	DeleteTicketType(id string) error
+}
+
+type sqlTicketTypeRepository struct {
+	database *sql.DB
+}
+
+// NewSQLTicketTypeRepository creates and returns a new sql flavoured TicketTypeRepository instance.
+func NewSQLTicketTypeRepository(database *sql.DB) TicketTypeRepository {
+	return &sqlTicketTypeRepository{database: database}
+}
+
+// ticketTypeColumns lists the columns selected when loading a ticket type, in the order expected by scanTicketType.
+const ticketTypeColumns = `
+				t.id,
+				t.event_id,
+				t.name,
+				t.description,
+				t.price,
+				t.currency,
+				t.quantity,
+				t.sales_start,
+				t.sales_end,
+				t.created_at,
+				t.updated_at`
+
+// heldTickets counts the tickets of the ticket type t which are held for the occurrence $2, by orders which are paid or pending and not yet expired.
+const heldTickets = `(
+				SELECT COALESCE(SUM(oi.quantity), 0) FROM public.order_items oi JOIN public.orders o ON o.id = oi.order_id
+				WHERE oi.ticket_type_id = t.id AND o.occurrence_start = $2 AND (o.status = 'paid' OR (o.status = 'pending' AND o.expires_at > now()))
+			)`
+
+// scanTicketType scans the columns listed in ticketTypeColumns into a new ticket type model.
+func scanTicketType(row rowScanner, extra ...any) (*models.TicketTypeModel, error) {
+	ticketType := &models.TicketTypeModel{}
+	err := row.Scan(append([]any{
+		&ticketType.ID,
+		&ticketType.EventID,
+		&ticketType.Name,
+		&ticketType.Description,
+		&ticketType.Price,
+		&ticketType.Currency,
+		&ticketType.Quantity,
+		&ticketType.SalesStart,
+		&ticketType.SalesEnd,
+		&ticketType.CreatedAt,
+		&ticketType.UpdatedAt,
+	}, extra...)...)
+	return ticketType, err
+}
+
+// CreateTicketType inserts a new ticket type for its event into the database.
+func (r *sqlTicketTypeRepository) CreateTicketType(ticketType *models.TicketTypeModel) error {
+	ticketType.BeforeCreate()
+	query := `INSERT INTO public.ticket_types (event_id, name, description, price, currency, quantity, sales_start, sales_end)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at, updated_at`
+
+	err := r.database.QueryRow(
+		query,
+		ticketType.EventID,
+		ticketType.Name,
+		ticketType.Description,
+		ticketType.Price,
+		ticketType.Currency,
+		ticketType.Quantity,
+		ticketType.SalesStart,
+		ticketType.SalesEnd,
+	).Scan(&ticketType.ID, &ticketType.CreatedAt, &ticketType.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err, "ticket_types_event_id_name_key") {
+			return ErrTicketTypeNameConflict
+		}
+		if isForeignKeyViolation(err) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to create ticket type: %w", err)
+	}
+
+	ticketType.AfterCreate()
+
+	return nil
+}
+
+// GetTicketTypeByID retrieves a ticket type from the database by its unique ID.
+func (r *sqlTicketTypeRepository) GetTicketTypeByID(id string) (*models.TicketTypeModel, error) {
+	query := `SELECT ` + ticketTypeColumns + ` FROM public.ticket_types t WHERE t.id = $1`
+
+	ticketType, err := scanTicketType(r.database.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTicketTypeNotFound
+		}
+		if reflect.TypeOf(err) == reflect.TypeOf(&net.OpError{}) {
+			return nil, ErrRepoConnErr
+		}
+		return nil, ErrInvalidTicketTypeId
+	}
+
+	return ticketType, nil
+}
+
+// ListTicketTypes retrieves the ticket types of the event, cheapest first.
+// When an occurrence is provided the number of tickets still available for it is included.
+func (r *sqlTicketTypeRepository) ListTicketTypes(eventId string, occurrence *time.Time) ([]*models.TicketTypeModel, error) {
+	query := `SELECT ` + ticketTypeColumns + `,
+				CASE WHEN $2::timestamptz IS NULL THEN NULL ELSE t.quantity - ` + heldTickets + ` END
+			FROM public.ticket_types t WHERE t.event_id = $1
+			ORDER BY t.price, t.name, t.id`
+
+	rows, err := r.database.Query(query, eventId, occurrence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket types: %w", err)
+	}
+	defer rows.Close()
+
+	ticketTypes := []*models.TicketTypeModel{}
+	for rows.Next() {
+		var available sql.NullInt64
+		ticketType, err := scanTicketType(rows, &available)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ticket types: %w", err)
+		}
+		if available.Valid {
+			// tickets held before the quantity was lowered are kept, so never report fewer than none.
+			n := max(int(available.Int64), 0)
+			ticketType.Available = &n
+		}
+		ticketTypes = append(ticketTypes, ticketType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list ticket types: %w", err)
+	}
+
+	return ticketTypes, nil
+}
+
+// UpdateTicketType updates the ticket type within the database.
+// The quantity can not be lowered below the number of tickets held for any occurrence of the event.
+func (r *sqlTicketTypeRepository) UpdateTicketType(ticketType *models.TicketTypeModel) error {
+	ticketType.BeforeUpdate()
+
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// lock the event to serialize the change with orders of its tickets.
+	if err := tx.QueryRow(`SELECT id FROM public.events WHERE id = $1 FOR UPDATE`, ticketType.EventID).Scan(new(string)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEventNotFound
+		}
+		return fmt.Errorf("failed to update ticket type: %w", err)
+	}
+
+	var held int
+	err = tx.QueryRow(
+		`SELECT COALESCE(MAX(held), 0) FROM (
+			SELECT SUM(oi.quantity) AS held FROM public.order_items oi JOIN public.orders o ON o.id = oi.order_id
+			WHERE oi.ticket_type_id = $1 AND (o.status = 'paid' OR (o.status = 'pending' AND o.expires_at > now()))
+			GROUP BY o.occurrence_start
+		) h`,
+		ticketType.ID,
+	).Scan(&held)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket type: %w", err)
+	}
+	if ticketType.Quantity < held {
+		return fmt.Errorf("%w, %d tickets are held for an occurrence", ErrTicketQuantityBelowHeld, held)
+	}
+
+	query := `UPDATE public.ticket_types SET name = $1, description = $2, price = $3, currency = $4, quantity = $5, sales_start = $6, sales_end = $7, updated_at = $8 WHERE id = $9`
+
+	rs, err := tx.Exec(
+		query,
+		ticketType.Name,
+		ticketType.Description,
+		ticketType.Price,
+		ticketType.Currency,
+		ticketType.Quantity,
+		ticketType.SalesStart,
+		ticketType.SalesEnd,
+		ticketType.UpdatedAt,
+		ticketType.ID,
+	)
+	if err != nil {
+		if isUniqueViolation(err, "ticket_types_event_id_name_key") {
+			return ErrTicketTypeNameConflict
+		}
+		return fmt.Errorf("failed to update ticket type: %w", err)
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrTicketTypeNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	ticketType.AfterUpdate()
+
+	return nil
+}
+
+// DeleteTicketType removes the ticket type from the database, which is only possible while it has never been ordered.
+func (r *sqlTicketTypeRepository) DeleteTicketType(id string) error {
+	query := `DELETE FROM public.ticket_types WHERE id = $1`
+
+	rs, err := r.database.Exec(query, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrTicketTypeOrdered
+		}
+		return err
+	}
+
+	if affected, err := rs.RowsAffected(); affected < 1 {
+		if err != nil {
+			return err
+		}
+		return ErrTicketTypeNotFound
+	}
+
+	return nil
+}
+
+var (
+	ErrTicketTypeNotFound      = errors.New("ticket type not found")                               // ErrTicketTypeNotFound is returned when a ticket type is not found in the database.
+	ErrInvalidTicketTypeId     = errors.New("invalid ticket type id")                              // ErrInvalidTicketTypeId is returned when a ticket type id is invalid or malformed.
+	ErrTicketTypeNameConflict  = errors.New("event already has a ticket type with the name")       // ErrTicketTypeNameConflict is returned when an event already has a ticket type with the same name.
+	ErrTicketTypeOrdered       = errors.New("ticket type has been ordered and can not be deleted") // ErrTicketTypeOrdered is returned when deleting a ticket type which is part of an order.
+	ErrTicketQuantityBelowHeld = errors.New("quantity is less than the number of tickets ordered") // ErrTicketQuantityBelowHeld is returned when lowering the quantity of a ticket type below the tickets already held.
+)
@@ -62,7 +62,7 @@ func (r *sqlUserRepository) GetUserByID(id string) (*models.UserModel, error) {
 				created_at,
 				updated_at,
 				google_id,
-				avatar_url 
+				avatar_url
 	
 			FROM public.users WHERE id = $1`
 
@@ -171,7 +171,7 @@ func (r *sqlUserRepository) GetUserByEmail(email string) (*models.UserModel, err
 				created_at,
 				updated_at,
 				google_id,
-				avatar_url 
+				avatar_url
 			FROM public.users WHERE email = $1`
 
 	user := &models.UserModel{}
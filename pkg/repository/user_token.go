@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// UserTokenRepository represents the interface for database operations on the single-use tokens sent to users.
+type UserTokenRepository interface {
+	CreateToken(token *models.UserTokenModel) error
+	CountTokensSince(userId string, purpose types.UserTokenPurpose, since time.Time) (int, error)
+	VerifyEmail(tokenHash string) (string, error)
+	ResetPassword(tokenHash string, passwordHash string) (string, error)
+}
+
+type sqlUserTokenRepository struct {
+	database *sql.DB
+}
+
+// NewSQLUserTokenRepository creates and returns a new sql flavoured UserTokenRepository instance.
+func NewSQLUserTokenRepository(database *sql.DB) UserTokenRepository {
+	return &sqlUserTokenRepository{database: database}
+}
+
+// CreateToken inserts the token, replacing the unused tokens of the user with the same purpose so that only the most recently sent token works.
+func (r *sqlUserTokenRepository) CreateToken(token *models.UserTokenModel) error {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`UPDATE public.user_tokens SET used_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`,
+		token.UserID, token.Purpose,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to replace user tokens: %w", err)
+	}
+
+	query := `INSERT INTO public.user_tokens (user_id, purpose, token_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+
+	err = tx.QueryRow(query, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// CountTokensSince counts the tokens with the purpose which were sent to the user since the time, whether used or not.
+func (r *sqlUserTokenRepository) CountTokensSince(userId string, purpose types.UserTokenPurpose, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM public.user_tokens WHERE user_id = $1 AND purpose = $2 AND created_at > $3`
+
+	var count int
+	if err := r.database.QueryRow(query, userId, purpose, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count user tokens: %w", err)
+	}
+
+	return count, nil
+}
+
+// VerifyEmail uses the email verification token with the hash, marking the email address of its user as verified.
+// Returns the id of the user whose email address was verified.
+func (r *sqlUserTokenRepository) VerifyEmail(tokenHash string) (string, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	userId, err := useToken(tx, types.EmailVerificationTokenPurpose, tokenHash)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec(`UPDATE public.users SET verified = TRUE, updated_at = CURRENT_TIMESTAMP WHERE id = $1`, userId); err != nil {
+		return "", fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return userId, tx.Commit()
+}
+
+// ResetPassword uses the password reset token with the hash, replacing the password of its user with the password hash.
+// The sessions of the user are revoked, signing them out of every device. Returns the id of the user whose password was reset.
+func (r *sqlUserTokenRepository) ResetPassword(tokenHash string, passwordHash string) (string, error) {
+	tx, err := r.database.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	userId, err := useToken(tx, types.PasswordResetTokenPurpose, tokenHash)
+	if err != nil {
+		return "", err
+	}
+
+	// receiving the token proves the user owns their email address, so it is verified as well.
+	query := `UPDATE public.users SET password = $1, verified = TRUE, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	if _, err := tx.Exec(query, passwordHash, userId); err != nil {
+		return "", fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE public.user_sessions SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`, userId); err != nil {
+		return "", fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return userId, tx.Commit()
+}
+
+// useToken marks the unused and unexpired token with the purpose and hash as used, returning the id of its user.
+func useToken(tx *sql.Tx, purpose types.UserTokenPurpose, tokenHash string) (string, error) {
+	query := `UPDATE public.user_tokens SET used_at = CURRENT_TIMESTAMP
+			WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+			RETURNING user_id`
+
+	var userId string
+	if err := tx.QueryRow(query, tokenHash, purpose).Scan(&userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrTokenInvalid
+		}
+		return "", fmt.Errorf("failed to use user token: %w", err)
+	}
+
+	return userId, nil
+}
+
+var (
+	ErrTokenInvalid = errors.New("token is invalid, expired or was already used") // ErrTokenInvalid is returned when using a token which does not exist, has expired or was already used.
+)
@@ -3,7 +3,9 @@ package service
 import (
 	"database/sql"
 	"errors"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
@@ -19,6 +21,9 @@ const (
 	USER_CONTEXT_KEY types.ContextKey = "user"
 )
 
+// maxSessionUserAgentLength the number of bytes of the user agent kept for a session.
+const maxSessionUserAgentLength = 512
+
 var (
 	ErrInvalidCredentials  = errors.New("invalid credentials")
 	ErrUserAlreadyExists   = errors.New("user already exists with given email")
@@ -26,15 +31,27 @@ var (
 	ErrInvalidGoogleToken  = errors.New("google id token is invalid")
 	ErrGoogleClietIdNotSet = errors.New("google client id not configured")
 	ErrInvalidRefreshToken = errors.New("refresh token is invalid")
+	ErrSessionEnded        = errors.New("session has ended")
+	ErrSessionLookup       = errors.New("unable to look up session")
 )
 
 // AuthenticationService for signing up and logging in users.
+// Users who sign in are given a session, the access tokens of which are refreshed with the refresh token cookie attached to responses.
 type AuthenticationService interface {
 	ValidateSignIn(dto *dtos.Login) (*dtos.LoginSuccess, error)
 	ValidateSignUp(dto *dtos.Register) (string, error)
 	CheckUser(id string) (*dtos.LoginUser, error)
-	ValidateRefresh(refreshToken string) (*string, error)
-	AttachRefreshTokenCookie(w http.ResponseWriter, userId string) error
+	StartSession(w http.ResponseWriter, r *http.Request, login *dtos.LoginSuccess) error
+	ValidateRefresh(w http.ResponseWriter, r *http.Request, refreshToken string) (*string, error)
+	ListSessions(user *JwtPayload) ([]*ActiveSession, error)
+	EndSession(w http.ResponseWriter, user *JwtPayload, sessionId string) error
+	EndAllSessions(w http.ResponseWriter, user *JwtPayload, keepCurrent bool) (int64, error)
+}
+
+// ActiveSession a session of the user which was neither revoked nor has expired.
+type ActiveSession struct {
+	*models.SessionModel
+	Current bool `json:"current"` // Current whether the session is the one the request listing the sessions was made with.
 }
 
 type AuthenticationServiceConfiguration struct {
@@ -43,22 +60,28 @@ type AuthenticationServiceConfiguration struct {
 
 // jsonWebTokenAuthenticationService implementation of the AuthenticationService using the JsonWebTokenService.
 type jsonWebTokenAuthenticationService struct {
-	logger     logging.Logger
-	jwtService JsonWebTokenService
-	userRepo   repository.UserRepository
-	config     *AuthenticationServiceConfiguration
+	logger              logging.Logger
+	jwtService          JsonWebTokenService
+	userRepo            repository.UserRepository
+	sessionRepo         repository.SessionRepository
+	verificationService EmailVerificationService
+	config              *AuthenticationServiceConfiguration
 }
 
 // NewJsonWebTokenAuthenticationService create a JWT flavoured AuthenticationService.
-func NewJsonWebTokenAuthenticationService(userRepo repository.UserRepository, jwtService JsonWebTokenService, lw logging.LogWriter, config *AuthenticationServiceConfiguration) AuthenticationService {
+// Users who sign up are sent a link verifying their email address through the EmailVerificationService.
+func NewJsonWebTokenAuthenticationService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, jwtService JsonWebTokenService, verificationService EmailVerificationService, lw logging.LogWriter, config *AuthenticationServiceConfiguration) AuthenticationService {
 	return &jsonWebTokenAuthenticationService{
-		logger:     logging.NewContextLogger(lw, "JsonWebTokenAuthenticationService"),
-		jwtService: jwtService,
-		userRepo:   userRepo,
-		config:     config,
+		logger:              logging.NewContextLogger(lw, "JsonWebTokenAuthenticationService"),
+		jwtService:          jwtService,
+		userRepo:            userRepo,
+		sessionRepo:         sessionRepo,
+		verificationService: verificationService,
+		config:              config,
 	}
 }
 
+// ValidateSignIn checks the credentials of the user signing in, the access token of the result is signed once their session is started.
 func (svc *jsonWebTokenAuthenticationService) ValidateSignIn(dto *dtos.Login) (*dtos.LoginSuccess, error) {
 
 	//check if user exists with provided email
@@ -76,16 +99,6 @@ func (svc *jsonWebTokenAuthenticationService) ValidateSignIn(dto *dtos.Login) (*
 		return nil, ErrInvalidCredentials
 	}
 
-	token, err := svc.jwtService.SignAccessToken(JwtPayload{
-		Id:   existingUser.ID,
-		Role: existingUser.Role,
-	})
-
-	if err != nil {
-		svc.logger.Error(err, "error signing token")
-		return nil, err
-	}
-
 	return &dtos.LoginSuccess{
 		User: dtos.LoginUser{
 			ID:        existingUser.ID,
@@ -94,7 +107,6 @@ func (svc *jsonWebTokenAuthenticationService) ValidateSignIn(dto *dtos.Login) (*
 			LastName:  existingUser.LastName.String,
 			Role:      existingUser.Role,
 		},
-		AccessToken: *token,
 	}, nil
 }
 
@@ -124,6 +136,11 @@ func (svc *jsonWebTokenAuthenticationService) ValidateSignUp(dto *dtos.Register)
 		return "", err
 	}
 
+	// the user is signed up regardless, as they can request another verification link.
+	if err := svc.verificationService.SendVerification(model); err != nil {
+		svc.logger.Errorf(err, "unable to send verification email to user %s", model.ID)
+	}
+
 	return "successfully signed up", nil
 }
 
@@ -145,7 +162,40 @@ func (svc *jsonWebTokenAuthenticationService) CheckUser(id string) (*dtos.LoginU
 	}, nil
 }
 
-func (svc *jsonWebTokenAuthenticationService) ValidateRefresh(refreshToken string) (*string, error) {
+// StartSession starts a session of the signed in user on the device making the request.
+// The refresh token of the session is attached as a cookie, and the access token of the login is signed for the session.
+func (svc *jsonWebTokenAuthenticationService) StartSession(w http.ResponseWriter, r *http.Request, login *dtos.LoginSuccess) error {
+	userAgent, ipAddress := requestDevice(r)
+	session := models.NewSession(login.User.ID, userAgent, ipAddress, RefreshTokenLifetime)
+
+	tokenId, err := svc.sessionRepo.CreateSession(session)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.attachRefreshTokenCookie(w, session.UserID, session.ID, tokenId); err != nil {
+		return err
+	}
+
+	accessToken, err := svc.jwtService.SignAccessToken(JwtPayload{
+		Id:        login.User.ID,
+		Role:      login.User.Role,
+		SessionId: session.ID,
+	})
+	if err != nil {
+		return err
+	}
+	login.AccessToken = *accessToken
+
+	svc.logger.Infof("started session %s of user %s", session.ID, session.UserID)
+
+	return nil
+}
+
+// ValidateRefresh refreshes the session of the refresh token, returning a new access token.
+// The refresh token is rotated, a new refresh token replacing it is attached as a cookie. Using a refresh token which was already
+// replaced revokes its session, as either the user or whoever stole the token would otherwise keep the session alive.
+func (svc *jsonWebTokenAuthenticationService) ValidateRefresh(w http.ResponseWriter, r *http.Request, refreshToken string) (*string, error) {
 
 	claims, err := svc.jwtService.ParseRefreshToken(refreshToken)
 
@@ -154,6 +204,12 @@ func (svc *jsonWebTokenAuthenticationService) ValidateRefresh(refreshToken strin
 		return nil, ErrInvalidRefreshToken
 	}
 
+	// refresh tokens issued before sessions were recorded can not be revoked, so they are no longer accepted.
+	if claims.TokenId == "" {
+		svc.logger.Warnf("refresh token of user %s was not issued for a session", claims.Id)
+		return nil, ErrInvalidRefreshToken
+	}
+
 	existingUser, err := svc.userRepo.GetUserByID(claims.Id)
 
 	if err != nil {
@@ -161,9 +217,38 @@ func (svc *jsonWebTokenAuthenticationService) ValidateRefresh(refreshToken strin
 		return nil, ErrUserNotFound
 	}
 
+	userAgent, ipAddress := requestDevice(r)
+	now := time.Now()
+	session, tokenId, err := svc.sessionRepo.RotateRefreshToken(claims.TokenId, repository.SessionUse{
+		At:        now,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+		ExpiresAt: now.Add(RefreshTokenLifetime),
+	})
+
+	switch {
+	case errors.Is(err, repository.ErrRefreshTokenReused):
+		svc.logger.Warnf("refresh token of session %s of user %s was reused, the session was revoked", claims.SessionId, claims.Id)
+		return nil, ErrInvalidRefreshToken
+	case errors.Is(err, repository.ErrSessionNotFound), errors.Is(err, repository.ErrSessionRevoked):
+		return nil, ErrInvalidRefreshToken
+	case err != nil:
+		svc.logger.Error(err, "error refreshing session")
+		return nil, err
+	case session.UserID != existingUser.ID:
+		svc.logger.Warnf("refresh token of user %s was issued for session %s of another user", claims.Id, session.ID)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if err := svc.attachRefreshTokenCookie(w, session.UserID, session.ID, tokenId); err != nil {
+		svc.logger.Error(err, "error attaching refresh token cookie")
+		return nil, err
+	}
+
 	accessToken, err := svc.jwtService.SignAccessToken(JwtPayload{
-		Id:   existingUser.ID,
-		Role: existingUser.Role,
+		Id:        existingUser.ID,
+		Role:      existingUser.Role,
+		SessionId: session.ID,
 	})
 
 	if err != nil {
@@ -174,25 +259,77 @@ func (svc *jsonWebTokenAuthenticationService) ValidateRefresh(refreshToken strin
 	return accessToken, nil
 }
 
-func (svc *jsonWebTokenAuthenticationService) AttachRefreshTokenCookie(w http.ResponseWriter, userId string) error {
+// ListSessions returns the active sessions of the user, marking the session their access token was issued for as current.
+func (svc *jsonWebTokenAuthenticationService) ListSessions(user *JwtPayload) ([]*ActiveSession, error) {
+	sessions, err := svc.sessionRepo.ListActiveSessions(user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*ActiveSession, 0, len(sessions))
+	for _, session := range sessions {
+		active = append(active, &ActiveSession{SessionModel: session, Current: session.ID == user.SessionId})
+	}
+
+	return active, nil
+}
+
+// EndSession revokes the session of the user with the id, ending the session the access token was issued for clears its refresh token cookie.
+// Access tokens already issued for the session are rejected once it is revoked, see NewSessionJsonWebTokenService.
+func (svc *jsonWebTokenAuthenticationService) EndSession(w http.ResponseWriter, user *JwtPayload, sessionId string) error {
+	if sessionId == user.SessionId {
+		svc.clearRefreshTokenCookie(w)
+	}
+
+	if err := svc.sessionRepo.RevokeSession(user.Id, sessionId); err != nil {
+		return err
+	}
+
+	svc.logger.Infof("ended session %s of user %s", sessionId, user.Id)
+
+	return nil
+}
+
+// EndAllSessions revokes every session of the user, signing them out of all devices, or only of their other devices when keeping the current session.
+// Returns the number of sessions which were ended.
+func (svc *jsonWebTokenAuthenticationService) EndAllSessions(w http.ResponseWriter, user *JwtPayload, keepCurrent bool) (int64, error) {
+	except := ""
+	if keepCurrent {
+		except = user.SessionId
+	} else {
+		svc.clearRefreshTokenCookie(w)
+	}
+
+	ended, err := svc.sessionRepo.RevokeUserSessions(user.Id, except)
+	if err != nil {
+		return 0, err
+	}
+
+	svc.logger.Infof("ended %d sessions of user %s", ended, user.Id)
+
+	return ended, nil
+}
+
+// attachRefreshTokenCookie attaches the refresh token with the id, issued for the session of the user, as a cookie of the response.
+func (svc *jsonWebTokenAuthenticationService) attachRefreshTokenCookie(w http.ResponseWriter, userId string, sessionId string, tokenId string) error {
 
 	refreshToken, err := svc.jwtService.SignRefreshToken(RefreshTokenPayload{
-		Id: userId,
+		Id:        userId,
+		SessionId: sessionId,
+		TokenId:   tokenId,
 	})
 
 	if err != nil {
 		return err
 	}
 
-	week := time.Hour * 24 * 7
-
 	cookie := &http.Cookie{
 		Name:     constants.REFRESH_TOKEN_COOKIE,
 		Value:    *refreshToken,
 		Path:     constants.REFRESH_TOKEN_COOKIE_PATH, // The cookie will only be sent for requests to this path
 		HttpOnly: true,                                // The cookie is inaccessible to JavaScript
 		Secure:   svc.config.IsProduction,             // The cookie will only be sent over HTTPS in production
-		MaxAge:   int(week.Seconds()),
+		MaxAge:   int(RefreshTokenLifetime.Seconds()),
 	}
 
 	// Set the cookie
@@ -200,3 +337,37 @@ func (svc *jsonWebTokenAuthenticationService) AttachRefreshTokenCookie(w http.Re
 
 	return nil
 }
+
+// clearRefreshTokenCookie removes the refresh token cookie from the client.
+func (svc *jsonWebTokenAuthenticationService) clearRefreshTokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     constants.REFRESH_TOKEN_COOKIE,
+		Value:    "",
+		Path:     constants.REFRESH_TOKEN_COOKIE_PATH,
+		HttpOnly: true,
+		Secure:   svc.config.IsProduction,
+		MaxAge:   -1, // The cookie is deleted immediately
+	})
+}
+
+// requestDevice returns the user agent and ip address of the device making the request, which are shown to users listing their sessions.
+// The ip address respects the X-Forwarded-For header set by proxies, it is only informative and must not be trusted.
+func requestDevice(r *http.Request) (string, string) {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxSessionUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxSessionUserAgentLength], "")
+	}
+
+	ipAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ipAddress = r.RemoteAddr
+	}
+	if forwarded, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ","); forwarded != "" {
+		ipAddress = strings.TrimSpace(forwarded)
+	}
+	if ip := net.ParseIP(ipAddress); ip != nil {
+		return userAgent, ip.String()
+	}
+
+	return userAgent, ""
+}
@@ -0,0 +1,271 @@
+package service_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/constants"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestAuthenticationService_StartSession(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	jwtService := service.NewJsonWebTokenService(&service.JsonWebTokenConfiguration{
+		AccessTokenSecret:  "access",
+		RefreshTokenSecret: "refresh",
+	}, lw)
+
+	var created *models.SessionModel
+	sessions := mock.SessionRepository{
+		CreateSessionFn: func(session *models.SessionModel) (string, error) {
+			session.ID = "session"
+			created = session
+			return "token", nil
+		},
+	}
+	svc := service.NewJsonWebTokenAuthenticationService(mock.UserRepository{}, sessions, jwtService, nil, lw, &service.AuthenticationServiceConfiguration{})
+
+	r := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	login := &dtos.LoginSuccess{User: dtos.LoginUser{ID: "user", Role: types.UserRole}}
+
+	if err := svc.StartSession(w, r, login); err != nil {
+		t.Fatal(err)
+	}
+
+	if created == nil || created.UserID != "user" || created.UserAgent != "test-agent" || created.IPAddress != "192.0.2.1" {
+		t.Errorf("expected a session of the user on the requesting device to be created but got %+v", created)
+	}
+
+	accessToken, err := jwtService.ParseAccessToken(login.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accessToken.SessionId != "session" {
+		t.Errorf("expected the access token to be issued for the session but was issued for %q", accessToken.SessionId)
+	}
+
+	refreshToken := refreshTokenCookie(t, jwtService, w)
+	if refreshToken.SessionId != "session" || refreshToken.TokenId != "token" {
+		t.Errorf("expected the refresh token of the session to be attached but got %+v", refreshToken)
+	}
+}
+
+func TestAuthenticationService_ValidateRefresh(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	jwtService := service.NewJsonWebTokenService(&service.JsonWebTokenConfiguration{
+		AccessTokenSecret:  "access",
+		RefreshTokenSecret: "refresh",
+	}, lw)
+
+	refreshToken, err := jwtService.SignRefreshToken(service.RefreshTokenPayload{Id: "user", SessionId: "session", TokenId: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyRefreshToken, err := jwtService.SignRefreshToken(service.RefreshTokenPayload{Id: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name          string
+		refreshToken  string
+		rotateErr     error
+		sessionUserId string
+		expectedError error
+	}{
+		{name: "rotates the refresh token of the session", refreshToken: *refreshToken, sessionUserId: "user"},
+		{name: "rejects refresh tokens which were already used", refreshToken: *refreshToken, rotateErr: repository.ErrRefreshTokenReused, expectedError: service.ErrInvalidRefreshToken},
+		{name: "rejects refresh tokens of revoked sessions", refreshToken: *refreshToken, rotateErr: repository.ErrSessionRevoked, expectedError: service.ErrInvalidRefreshToken},
+		{name: "rejects refresh tokens of sessions of another user", refreshToken: *refreshToken, sessionUserId: "other", expectedError: service.ErrInvalidRefreshToken},
+		{name: "rejects refresh tokens not issued for a session", refreshToken: *legacyRefreshToken, expectedError: service.ErrInvalidRefreshToken},
+		{name: "rejects invalid refresh tokens", refreshToken: "invalid", expectedError: service.ErrInvalidRefreshToken},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mock.UserRepository{
+				GetUserByIDFn: func(id string) (*models.UserModel, error) {
+					return &models.UserModel{Model: models.Model{ID: id}, Role: types.UserRole}, nil
+				},
+			}
+			rotated := ""
+			sessions := mock.SessionRepository{
+				RotateRefreshTokenFn: func(tokenId string, use repository.SessionUse) (*models.SessionModel, string, error) {
+					rotated = tokenId
+					if tc.rotateErr != nil {
+						return nil, "", tc.rotateErr
+					}
+					return &models.SessionModel{ID: "session", UserID: tc.sessionUserId, ExpiresAt: use.ExpiresAt}, "next", nil
+				},
+			}
+			svc := service.NewJsonWebTokenAuthenticationService(users, sessions, jwtService, nil, lw, &service.AuthenticationServiceConfiguration{})
+
+			w := httptest.NewRecorder()
+			accessToken, err := svc.ValidateRefresh(w, httptest.NewRequest(http.MethodGet, "/api/auth/refresh", nil), tc.refreshToken)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v but got %v", tc.expectedError, err)
+			}
+			if tc.expectedError != nil {
+				if len(w.Result().Cookies()) > 0 {
+					t.Error("expected no refresh token to be attached")
+				}
+				return
+			}
+
+			if rotated != "token" {
+				t.Errorf("expected the refresh token to be rotated but %q was", rotated)
+			}
+			if payload, err := jwtService.ParseAccessToken(*accessToken); err != nil || payload.SessionId != "session" {
+				t.Errorf("expected an access token to be signed for the session but got %+v, %v", payload, err)
+			}
+			if next := refreshTokenCookie(t, jwtService, w); next.TokenId != "next" || next.SessionId != "session" {
+				t.Errorf("expected the rotated refresh token to be attached but got %+v", next)
+			}
+		})
+	}
+}
+
+// refreshTokenCookie parses the refresh token attached as a cookie of the response.
+func refreshTokenCookie(t *testing.T, jwtService service.JsonWebTokenService, w *httptest.ResponseRecorder) *service.RefreshTokenPayload {
+	t.Helper()
+
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == constants.REFRESH_TOKEN_COOKIE {
+			payload, err := jwtService.ParseRefreshToken(cookie.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return payload
+		}
+	}
+
+	t.Fatal("expected a refresh token cookie to be attached")
+	return nil
+}
+
+func TestAuthenticationService_EndSession(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	user := &service.JwtPayload{Id: "user", Role: types.UserRole, SessionId: "current"}
+
+	testcases := []struct {
+		name          string
+		sessionId     string
+		expectCleared bool
+	}{
+		{name: "ending the current session clears the refresh token cookie", sessionId: "current", expectCleared: true},
+		{name: "ending another session keeps the refresh token cookie", sessionId: "other"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			revoked := ""
+			sessions := mock.SessionRepository{
+				RevokeSessionFn: func(userId string, sessionId string) error {
+					if userId != user.Id {
+						t.Errorf("expected a session of user %s to be revoked but was of %s", user.Id, userId)
+					}
+					revoked = sessionId
+					return nil
+				},
+			}
+			svc := service.NewJsonWebTokenAuthenticationService(mock.UserRepository{}, sessions, nil, nil, lw, &service.AuthenticationServiceConfiguration{})
+
+			w := httptest.NewRecorder()
+			if err := svc.EndSession(w, user, tc.sessionId); err != nil {
+				t.Fatal(err)
+			}
+
+			if revoked != tc.sessionId {
+				t.Errorf("expected session %s to be revoked but %q was", tc.sessionId, revoked)
+			}
+			if cleared := refreshTokenCookieCleared(w); cleared != tc.expectCleared {
+				t.Errorf("expected refresh token cookie cleared to be %v but was %v", tc.expectCleared, cleared)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_EndAllSessions(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	user := &service.JwtPayload{Id: "user", Role: types.UserRole, SessionId: "current"}
+
+	testcases := []struct {
+		name           string
+		keepCurrent    bool
+		expectedExcept string
+		expectCleared  bool
+	}{
+		{name: "logging out of all devices ends the current session", expectCleared: true},
+		{name: "logging out of other devices keeps the current session", keepCurrent: true, expectedExcept: "current"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			except := "unset"
+			sessions := mock.SessionRepository{
+				RevokeUserSessionsFn: func(userId string, exceptSessionId string) (int64, error) {
+					except = exceptSessionId
+					return 2, nil
+				},
+			}
+			svc := service.NewJsonWebTokenAuthenticationService(mock.UserRepository{}, sessions, nil, nil, lw, &service.AuthenticationServiceConfiguration{})
+
+			w := httptest.NewRecorder()
+			ended, err := svc.EndAllSessions(w, user, tc.keepCurrent)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ended != 2 {
+				t.Errorf("expected 2 sessions to be ended but %d were", ended)
+			}
+			if except != tc.expectedExcept {
+				t.Errorf("expected sessions other than %q to be revoked but got %q", tc.expectedExcept, except)
+			}
+			if cleared := refreshTokenCookieCleared(w); cleared != tc.expectCleared {
+				t.Errorf("expected refresh token cookie cleared to be %v but was %v", tc.expectCleared, cleared)
+			}
+		})
+	}
+}
+
+func TestAuthenticationService_ListSessions(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	sessions := mock.SessionRepository{
+		ListActiveSessionsFn: func(userId string) ([]*models.SessionModel, error) {
+			return []*models.SessionModel{{ID: "other", UserID: userId}, {ID: "current", UserID: userId}}, nil
+		},
+	}
+	svc := service.NewJsonWebTokenAuthenticationService(mock.UserRepository{}, sessions, nil, nil, lw, &service.AuthenticationServiceConfiguration{})
+
+	active, err := svc.ListSessions(&service.JwtPayload{Id: "user", SessionId: "current"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(active) != 2 || active[0].Current || !active[1].Current {
+		t.Errorf("expected only the session of the access token to be current but got %+v, %+v", active[0], active[1])
+	}
+}
+
+// refreshTokenCookieCleared returns whether the response removes the refresh token cookie from the client.
+func refreshTokenCookieCleared(w *httptest.ResponseRecorder) bool {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == constants.REFRESH_TOKEN_COOKIE && cookie.MaxAge < 0 {
+			return true
+		}
+	}
+	return false
+}
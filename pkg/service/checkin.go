@@ -0,0 +1,204 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// checkInCodeMacSize the number of bytes of the HMAC kept within check-in codes, keeping codes short enough to enter by hand.
+const checkInCodeMacSize = 12
+
+// CheckInService issues the check-in codes of attendees and applies check-ins scanned by door staff while offline.
+// Codes are signed for an attendee of an occurrence, so devices can validate them against a downloaded roster without connectivity.
+type CheckInService interface {
+	SignCode(eventId string, occurrence time.Time, userId string) string
+	ParseCode(eventId string, code string) (*CheckInCode, error)
+	Roster(eventId string, occurrence *time.Time) (*CheckInRoster, error)
+	SyncCheckIns(eventId string, staffId string, scans []dtos.CheckInScan) ([]*dtos.CheckInSyncResult, error)
+}
+
+// CheckInConfiguration settings for the check-in service.
+type CheckInConfiguration struct {
+	CodeSecret string // CodeSecret the secret check-in codes are signed with.
+}
+
+// CheckInCode identifies the attendee of an occurrence admitted by a check-in code.
+type CheckInCode struct {
+	UserId     string
+	Occurrence time.Time // Occurrence the time the attended occurrence was originally scheduled to start.
+}
+
+// CheckInRoster the attendees of an event along with their check-in codes, downloaded by door staff for offline validation.
+type CheckInRoster struct {
+	EventID     string                `json:"event_id"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	Attendees   []*CheckInRosterEntry `json:"attendees"`
+}
+
+// CheckInRosterEntry an attendee of the roster.
+type CheckInRosterEntry struct {
+	*models.AttendeeModel
+	Occurrence string `json:"occurrence"` // Occurrence the id of the attended occurrence.
+	Code       string `json:"code"`
+}
+
+type checkInService struct {
+	logger               logging.Logger
+	config               *CheckInConfiguration
+	attendanceRepository repository.AttendanceRepository
+}
+
+// NewCheckInService creates a new implementation of the CheckInService.
+func NewCheckInService(config *CheckInConfiguration, attendanceRepository repository.AttendanceRepository, lw logging.LogWriter) CheckInService {
+	return &checkInService{
+		logger:               logging.NewContextLogger(lw, "CheckInService"),
+		config:               config,
+		attendanceRepository: attendanceRepository,
+	}
+}
+
+// SignCode returns the check-in code of the user attending the occurrence of the event.
+// Codes are formed of the users id, the occurrence and a signature of both along with the event, separated by dots.
+func (svc *checkInService) SignCode(eventId string, occurrence time.Time, userId string) string {
+	occ := strconv.FormatInt(occurrence.UnixNano(), 36)
+	return strings.Join([]string{userId, occ, base64.RawURLEncoding.EncodeToString(svc.sign(eventId, userId, occ))}, ".")
+}
+
+// ParseCode verifies that the check-in code was signed for an attendee of the event, returning the attendee it admits.
+func (svc *checkInService) ParseCode(eventId string, code string) (*CheckInCode, error) {
+	parts := strings.Split(code, ".")
+	if len(parts) != 3 || len(parts[0]) < 1 {
+		return nil, ErrInvalidCheckInCode
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(mac, svc.sign(eventId, parts[0], parts[1])) {
+		return nil, ErrInvalidCheckInCode
+	}
+
+	occurrence, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil {
+		return nil, ErrInvalidCheckInCode
+	}
+
+	return &CheckInCode{UserId: parts[0], Occurrence: time.Unix(0, occurrence).UTC()}, nil
+}
+
+// Roster returns the attendees of the event, or only the provided occurrence of the event, along with their check-in codes.
+func (svc *checkInService) Roster(eventId string, occurrence *time.Time) (*CheckInRoster, error) {
+	attendees, err := svc.attendanceRepository.ListAttendees(eventId, occurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	roster := &CheckInRoster{
+		EventID:     eventId,
+		GeneratedAt: time.Now(),
+		Attendees:   make([]*CheckInRosterEntry, 0, len(attendees)),
+	}
+	for _, attendee := range attendees {
+		roster.Attendees = append(roster.Attendees, &CheckInRosterEntry{
+			AttendeeModel: attendee,
+			Occurrence:    models.FormatOccurrenceID(attendee.OccurrenceStart),
+			Code:          svc.SignCode(eventId, attendee.OccurrenceStart, attendee.UserID),
+		})
+	}
+
+	return roster, nil
+}
+
+// SyncCheckIns applies the scans of the event made by door staff while offline, returning the outcome of each scan in the order provided.
+// Scans are applied in the order they were scanned, so the earliest scan of an attendee checks them in. Syncing the same scans again has no effect,
+// scans of attendees already checked in by another device or at the door are reported as conflicts.
+func (svc *checkInService) SyncCheckIns(eventId string, staffId string, scans []dtos.CheckInScan) ([]*dtos.CheckInSyncResult, error) {
+	order := make([]int, len(scans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scans[order[i]].ScannedAt.Before(*scans[order[j]].ScannedAt)
+	})
+
+	now := time.Now()
+	results := make([]*dtos.CheckInSyncResult, len(scans))
+	for _, i := range order {
+		result, err := svc.applyScan(eventId, staffId, scans[i], now)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// applyScan checks in the attendee admitted by the scanned code, at the time it was scanned.
+func (svc *checkInService) applyScan(eventId string, staffId string, scan dtos.CheckInScan, now time.Time) (*dtos.CheckInSyncResult, error) {
+	result := &dtos.CheckInSyncResult{Code: scan.Code}
+
+	code, err := svc.ParseCode(eventId, scan.Code)
+	if err != nil {
+		result.Status = types.InvalidCodeSyncStatus
+		result.Message = err.Error()
+		return result, nil
+	}
+	result.UserID = code.UserId
+	result.Occurrence = models.FormatOccurrenceID(code.Occurrence)
+
+	// device clocks can not be trusted to be accurate, but check-ins can not have happened in the future.
+	at := *scan.ScannedAt
+	if at.After(now) {
+		at = now
+	}
+
+	attendee, err := svc.attendanceRepository.CheckInAttendee(eventId, code.Occurrence, code.UserId, repository.CheckIn{By: staffId, At: at, Device: scan.DeviceID})
+	switch {
+	case err == nil:
+		result.Status = types.CheckedInSyncStatus
+		svc.logger.Infof("attendee %s of event %s was checked in by device %s", code.UserId, eventId, scan.DeviceID)
+	case errors.Is(err, repository.ErrAlreadyCheckedIn):
+		result.Status = types.ConflictSyncStatus
+		if attendee.CheckedInDevice.Valid && attendee.CheckedInDevice.String == scan.DeviceID {
+			result.Status = types.AlreadySyncedSyncStatus
+		} else if attendee.CheckedInDevice.Valid {
+			result.Message = fmt.Sprintf("already checked in by device %s", attendee.CheckedInDevice.String)
+		} else {
+			result.Message = "already checked in at the door"
+		}
+	case errors.Is(err, repository.ErrNotAttending):
+		result.Status = types.RevokedSyncStatus
+		result.Message = "holder of the code is no longer attending the event"
+		return result, nil
+	default:
+		return nil, err
+	}
+
+	result.CheckedInAt = &attendee.CheckedInAt.Time
+	result.CheckedInDevice = attendee.CheckedInDevice.String
+
+	return result, nil
+}
+
+// sign returns the truncated signature of the check-in code of the user for the occurrence of the event.
+func (svc *checkInService) sign(eventId string, userId string, occurrence string) []byte {
+	mac := hmac.New(sha256.New, []byte(svc.config.CodeSecret))
+	mac.Write([]byte("checkin-code:" + eventId + ":" + userId + ":" + occurrence))
+	return mac.Sum(nil)[:checkInCodeMacSize]
+}
+
+var (
+	ErrInvalidCheckInCode = errors.New("invalid check-in code") // ErrInvalidCheckInCode is returned when a check-in code was not signed for an attendee of the event.
+)
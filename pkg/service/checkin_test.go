@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestCheckInService_Codes(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	svc := service.NewCheckInService(&service.CheckInConfiguration{CodeSecret: "secret"}, mock.AttendanceRepository{}, lw)
+	occurrence := time.Date(2024, time.June, 1, 18, 30, 0, 500, time.UTC)
+
+	code := svc.SignCode("event", occurrence, "user")
+
+	t.Run("parses codes signed for the event", func(t *testing.T) {
+		parsed, err := svc.ParseCode("event", code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.UserId != "user" || !parsed.Occurrence.Equal(occurrence) {
+			t.Errorf("expected code of user for occurrence %v but got %+v", occurrence, parsed)
+		}
+	})
+
+	invalid := []struct {
+		name    string
+		eventId string
+		code    string
+	}{
+		{name: "rejects codes of another event", eventId: "other", code: code},
+		{name: "rejects codes signed with another secret", eventId: "event", code: service.NewCheckInService(&service.CheckInConfiguration{CodeSecret: "other"}, mock.AttendanceRepository{}, lw).SignCode("event", occurrence, "user")},
+		{name: "rejects codes of another user", eventId: "event", code: "other" + code[len("user"):]},
+		{name: "rejects malformed codes", eventId: "event", code: "user"},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := svc.ParseCode(test.eventId, test.code); !errors.Is(err, service.ErrInvalidCheckInCode) {
+				t.Errorf("expected error %v but got %v", service.ErrInvalidCheckInCode, err)
+			}
+		})
+	}
+}
+
+func TestCheckInService_SyncCheckIns(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.CheckInConfiguration{CodeSecret: "secret"}
+	occurrence := time.Date(2024, time.June, 1, 18, 30, 0, 0, time.UTC)
+	scannedAt := occurrence.Add(10 * time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	// attendees checked in so far, alice is no longer attending and carol was checked in at the door.
+	checkedIn := map[string]*models.AttendeeModel{
+		"carol": {UserID: "carol", CheckedInAt: sql.NullTime{Time: occurrence, Valid: true}},
+	}
+	attendance := mock.AttendanceRepository{
+		CheckInAttendeeFn: func(eventId string, o time.Time, userId string, checkIn repository.CheckIn) (*models.AttendeeModel, error) {
+			if userId == "alice" {
+				return nil, repository.ErrNotAttending
+			}
+			if attendee, ok := checkedIn[userId]; ok {
+				return attendee, repository.ErrAlreadyCheckedIn
+			}
+			if checkIn.At.After(time.Now()) {
+				t.Errorf("expected check-in to not be in the future but was %v", checkIn.At)
+			}
+			attendee := &models.AttendeeModel{
+				UserID:          userId,
+				CheckedInAt:     sql.NullTime{Time: checkIn.At, Valid: true},
+				CheckedInDevice: sql.NullString{String: checkIn.Device, Valid: true},
+			}
+			checkedIn[userId] = attendee
+			return attendee, nil
+		},
+	}
+	svc := service.NewCheckInService(config, attendance, lw)
+
+	scans := []dtos.CheckInScan{
+		{Code: svc.SignCode("event", occurrence, "bob"), ScannedAt: &future, DeviceID: "door-2"},
+		{Code: svc.SignCode("event", occurrence, "bob"), ScannedAt: &scannedAt, DeviceID: "door-1"},
+		{Code: svc.SignCode("event", occurrence, "alice"), ScannedAt: &scannedAt, DeviceID: "door-1"},
+		{Code: svc.SignCode("event", occurrence, "carol"), ScannedAt: &scannedAt, DeviceID: "door-1"},
+		{Code: svc.SignCode("other", occurrence, "dave"), ScannedAt: &scannedAt, DeviceID: "door-1"},
+	}
+	expected := []types.CheckInSyncStatus{
+		types.ConflictSyncStatus,
+		types.CheckedInSyncStatus,
+		types.RevokedSyncStatus,
+		types.ConflictSyncStatus,
+		types.InvalidCodeSyncStatus,
+	}
+
+	results, err := svc.SyncCheckIns("event", "staff", scans)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		if result.Status != expected[i] {
+			t.Errorf("expected scan %d to be %s but was %s", i, expected[i], result.Status)
+		}
+	}
+
+	t.Run("syncing the same scans again has no effect", func(t *testing.T) {
+		results, err := svc.SyncCheckIns("event", "staff", scans[1:2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results[0].Status != types.AlreadySyncedSyncStatus || !results[0].CheckedInAt.Equal(scannedAt) {
+			t.Errorf("expected scan to already be synced at %v but got %+v", scannedAt, results[0])
+		}
+	})
+}
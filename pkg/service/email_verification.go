@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+// verificationTokenSize the number of random bytes within an email verification token.
+const verificationTokenSize = 32
+
+// EmailVerificationService sends users a link containing a single-use token, which verifies their email address when followed.
+type EmailVerificationService interface {
+	SendVerification(user *models.UserModel) error
+	ResendVerification(userId string) error
+	Verify(token string) (string, error)
+}
+
+// EmailVerificationConfiguration settings for the email verification service.
+type EmailVerificationConfiguration struct {
+	BaseURL        string        // BaseURL the url the api is publicly reachable at, which verification links point to.
+	TokenLifetime  time.Duration // TokenLifetime how long a verification link can be followed for after it was sent.
+	ResendInterval time.Duration // ResendInterval how long a user must wait before another verification link is sent to them.
+	MaxSendsPerDay int           // MaxSendsPerDay the number of verification links which may be sent to a user within a day.
+}
+
+type emailVerificationService struct {
+	logger              logging.Logger
+	config              *EmailVerificationConfiguration
+	userTokenRepository repository.UserTokenRepository
+	userRepository      repository.UserRepository
+	notifier            Notifier
+}
+
+// NewEmailVerificationService creates a new implementation of the EmailVerificationService.
+func NewEmailVerificationService(config *EmailVerificationConfiguration, userTokenRepository repository.UserTokenRepository, userRepository repository.UserRepository, notifier Notifier, lw logging.LogWriter) EmailVerificationService {
+	return &emailVerificationService{
+		logger:              logging.NewContextLogger(lw, "EmailVerificationService"),
+		config:              config,
+		userTokenRepository: userTokenRepository,
+		userRepository:      userRepository,
+		notifier:            notifier,
+	}
+}
+
+// SendVerification sends the user a link verifying their email address, any link sent to them before stops working.
+// Returns ErrVerificationThrottled when a link was sent to the user too recently or too often.
+func (svc *emailVerificationService) SendVerification(user *models.UserModel) error {
+	if user.Verified {
+		return ErrAlreadyVerified
+	}
+
+	throttled, err := isTokenThrottled(svc.userTokenRepository, user.ID, types.EmailVerificationTokenPurpose, svc.config.ResendInterval, svc.config.MaxSendsPerDay)
+	if err != nil {
+		return err
+	}
+	if throttled {
+		return ErrVerificationThrottled
+	}
+
+	token, err := utils.GenerateToken(verificationTokenSize)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.userTokenRepository.CreateToken(models.NewUserToken(user.ID, types.EmailVerificationTokenPurpose, token, svc.config.TokenLifetime)); err != nil {
+		return err
+	}
+
+	svc.logger.Infof("sending email verification link to user %s", user.ID)
+
+	return svc.notifier.Notify(user, Notification{
+		Template: mail.VerifyEmailTemplate,
+		Data: map[string]any{
+			"Link":      fmt.Sprintf("%s/api/auth/verify?token=%s", svc.config.BaseURL, token),
+			"ExpiresIn": svc.config.TokenLifetime.String(),
+		},
+	})
+}
+
+// ResendVerification sends another link verifying the email address of the user.
+func (svc *emailVerificationService) ResendVerification(userId string) error {
+	user, err := svc.userRepository.GetUserByID(userId)
+	if err != nil {
+		return err
+	}
+
+	return svc.SendVerification(user)
+}
+
+// Verify uses the token sent within a verification link, marking the email address of the user it was sent to as verified.
+// Returns the id of the verified user, or ErrInvalidVerificationToken when the token is unknown, has expired or was already used.
+func (svc *emailVerificationService) Verify(token string) (string, error) {
+	userId, err := svc.userTokenRepository.VerifyEmail(utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenInvalid) {
+			return "", ErrInvalidVerificationToken
+		}
+		return "", err
+	}
+
+	svc.logger.Infof("user %s verified their email address", userId)
+
+	return userId, nil
+}
+
+// isTokenThrottled returns true when a token with the purpose was sent to the user within the resend interval, or the daily limit of tokens was reached.
+func isTokenThrottled(userTokenRepository repository.UserTokenRepository, userId string, purpose types.UserTokenPurpose, resendInterval time.Duration, maxSendsPerDay int) (bool, error) {
+	now := time.Now()
+
+	recent, err := userTokenRepository.CountTokensSince(userId, purpose, now.Add(-resendInterval))
+	if err != nil {
+		return false, err
+	}
+	if recent > 0 {
+		return true, nil
+	}
+
+	daily, err := userTokenRepository.CountTokensSince(userId, purpose, now.Add(-24*time.Hour))
+	if err != nil {
+		return false, err
+	}
+
+	return daily >= maxSendsPerDay, nil
+}
+
+var (
+	ErrAlreadyVerified          = errors.New("email address is already verified")                     // ErrAlreadyVerified is returned when sending a verification link to a user whose email address is verified.
+	ErrVerificationThrottled    = errors.New("verification email was sent recently, try again later") // ErrVerificationThrottled is returned when verification links are requested too often.
+	ErrInvalidVerificationToken = errors.New("verification link is invalid or has expired")           // ErrInvalidVerificationToken is returned when verifying with a token which is unknown, expired or was already used.
+)
@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+func TestEmailVerificationService_SendVerification(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.EmailVerificationConfiguration{
+		BaseURL:        "https://events.example.com",
+		TokenLifetime:  24 * time.Hour,
+		ResendInterval: time.Minute,
+		MaxSendsPerDay: 3,
+	}
+
+	testcases := []struct {
+		name          string
+		verified      bool
+		recent        int // recent the number of links sent within the resend interval.
+		daily         int // daily the number of links sent within the last day.
+		expectedError error
+	}{
+		{name: "sends a link to unverified users", daily: 2},
+		{name: "does not send links to verified users", verified: true, expectedError: service.ErrAlreadyVerified},
+		{name: "throttles links sent within the resend interval", recent: 1, daily: 1, expectedError: service.ErrVerificationThrottled},
+		{name: "throttles links beyond the daily limit", daily: 3, expectedError: service.ErrVerificationThrottled},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created *models.UserTokenModel
+			tokens := mock.UserTokenRepository{
+				CreateTokenFn: func(token *models.UserTokenModel) error {
+					created = token
+					return nil
+				},
+				CountTokensSinceFn: func(userId string, purpose types.UserTokenPurpose, since time.Time) (int, error) {
+					if time.Since(since) > time.Hour {
+						return tc.daily, nil
+					}
+					return tc.recent, nil
+				},
+			}
+			var notifications []service.Notification
+			notifier := mock.Notifier{
+				NotifyFn: func(user *models.UserModel, notification service.Notification) error {
+					notifications = append(notifications, notification)
+					return nil
+				},
+			}
+			svc := service.NewEmailVerificationService(config, tokens, mock.UserRepository{}, notifier, lw)
+
+			user := &models.UserModel{Model: models.Model{ID: "user"}, Email: "user@example.com", Verified: tc.verified}
+			err := svc.SendVerification(user)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v but got %v", tc.expectedError, err)
+			}
+			if tc.expectedError != nil {
+				if created != nil || len(notifications) > 0 {
+					t.Error("expected no link to be sent")
+				}
+				return
+			}
+
+			if created == nil || created.UserID != user.ID || created.Purpose != types.EmailVerificationTokenPurpose {
+				t.Fatalf("expected an email verification token of the user to be stored but got %+v", created)
+			}
+			if len(notifications) != 1 {
+				t.Fatalf("expected the user to be notified once but was notified %d times", len(notifications))
+			}
+
+			if notifications[0].Template != mail.VerifyEmailTemplate {
+				t.Errorf("expected template %s but was %s", mail.VerifyEmailTemplate, notifications[0].Template)
+			}
+
+			// the link contains the token itself, while only its hash is stored.
+			link, _ := notifications[0].Data["Link"].(string)
+			token, ok := strings.CutPrefix(link, config.BaseURL+"/api/auth/verify?token=")
+			if !ok {
+				t.Fatalf("expected the link to be within the notification but was %q", link)
+			}
+			if utils.HashToken(token) != created.TokenHash {
+				t.Errorf("expected the stored hash to match the token within the link")
+			}
+		})
+	}
+}
+
+func TestEmailVerificationService_Verify(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	tokens := mock.UserTokenRepository{
+		VerifyEmailFn: func(tokenHash string) (string, error) {
+			if tokenHash == utils.HashToken("valid") {
+				return "user", nil
+			}
+			return "", repository.ErrTokenInvalid
+		},
+	}
+	svc := service.NewEmailVerificationService(&service.EmailVerificationConfiguration{}, tokens, mock.UserRepository{}, mock.Notifier{}, lw)
+
+	t.Run("verifies the user the token was sent to", func(t *testing.T) {
+		userId, err := svc.Verify("valid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if userId != "user" {
+			t.Errorf("expected user to be verified but was %s", userId)
+		}
+	})
+
+	t.Run("rejects unknown, expired or used tokens", func(t *testing.T) {
+		if _, err := svc.Verify("invalid"); !errors.Is(err, service.ErrInvalidVerificationToken) {
+			t.Errorf("expected error %v but got %v", service.ErrInvalidVerificationToken, err)
+		}
+	})
+}
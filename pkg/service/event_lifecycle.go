@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+)
+
+// EventLifecycleService moves events through the parts of their lifecycle which are not driven by their organizers.
+type EventLifecycleService interface {
+	CompleteEndedEvents() (int64, error)
+	Run(ctx context.Context)
+}
+
+// EventLifecycleConfiguration settings for the event lifecycle service.
+type EventLifecycleConfiguration struct {
+	CompletionInterval time.Duration // CompletionInterval how often published events which have ended are marked as completed.
+}
+
+type eventLifecycleService struct {
+	logger          logging.Logger
+	config          *EventLifecycleConfiguration
+	eventRepository repository.EventRepository
+}
+
+// NewEventLifecycleService creates a new implementation of the EventLifecycleService.
+func NewEventLifecycleService(config *EventLifecycleConfiguration, eventRepository repository.EventRepository, lw logging.LogWriter) EventLifecycleService {
+	return &eventLifecycleService{
+		logger:          logging.NewContextLogger(lw, "EventLifecycleService"),
+		config:          config,
+		eventRepository: eventRepository,
+	}
+}
+
+// CompleteEndedEvents marks all published events which have ended as completed, returning the number of completed events.
+func (svc *eventLifecycleService) CompleteEndedEvents() (int64, error) {
+	completed, err := svc.eventRepository.CompleteEndedEvents(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if completed > 0 {
+		svc.logger.Infof("marked %d ended events as completed", completed)
+	}
+
+	return completed, nil
+}
+
+// Run completes ended events immediately and then once every completion interval, until the context is done.
+func (svc *eventLifecycleService) Run(ctx context.Context) {
+	ticker := time.NewTicker(svc.config.CompletionInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := svc.CompleteEndedEvents(); err != nil {
+			svc.logger.Error(err, "unable to complete ended events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
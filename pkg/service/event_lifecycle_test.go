@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+)
+
+func TestEventLifecycleService_CompleteEndedEvents(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+
+	t.Run("completes events which ended before now", func(t *testing.T) {
+		before := time.Now()
+		svc := service.NewEventLifecycleService(&service.EventLifecycleConfiguration{CompletionInterval: time.Minute}, mock.EventRepository{
+			CompleteEndedEventsFn: func(now time.Time) (int64, error) {
+				if now.Before(before) {
+					t.Errorf("expected events ending before %v to be completed but was %v", before, now)
+				}
+				return 2, nil
+			},
+		}, lw)
+
+		completed, err := svc.CompleteEndedEvents()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if completed != 2 {
+			t.Errorf("expected 2 completed events but got %d", completed)
+		}
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		expected := errors.New("connection refused")
+		svc := service.NewEventLifecycleService(&service.EventLifecycleConfiguration{CompletionInterval: time.Minute}, mock.EventRepository{
+			CompleteEndedEventsFn: func(now time.Time) (int64, error) {
+				return 0, expected
+			},
+		}, lw)
+
+		if _, err := svc.CompleteEndedEvents(); !errors.Is(err, expected) {
+			t.Errorf("expected error %v but got %v", expected, err)
+		}
+	})
+}
+
+func TestEventLifecycleService_Run(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+
+	t.Run("completes events on every interval until cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		runs := 0
+		svc := service.NewEventLifecycleService(&service.EventLifecycleConfiguration{CompletionInterval: time.Millisecond}, mock.EventRepository{
+			CompleteEndedEventsFn: func(now time.Time) (int64, error) {
+				runs++
+				if runs == 3 {
+					cancel()
+				}
+				return 0, nil
+			},
+		}, lw)
+
+		done := make(chan struct{})
+		go func() {
+			svc.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected run to return once the context was cancelled")
+		}
+		if runs != 3 {
+			t.Errorf("expected 3 runs but got %d", runs)
+		}
+	})
+}
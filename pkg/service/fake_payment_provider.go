@@ -0,0 +1,131 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+// FakePaymentProviderName the name of the fake payment provider.
+const FakePaymentProviderName = "fake"
+
+// FakePaymentProvider a local PaymentProvider for development and tests, which keeps payment intents in memory and takes every payment it is asked to.
+// Webhook events are signed with the hex encoded HMAC-SHA256 of their body, see SignWebhook.
+type FakePaymentProvider struct {
+	secret  []byte
+	mu      sync.Mutex
+	intents map[string]*PaymentIntent
+}
+
+// NewFakePaymentProvider creates a fake payment provider, which signs webhook events with the provided secret.
+func NewFakePaymentProvider(webhookSecret string) *FakePaymentProvider {
+	return &FakePaymentProvider{
+		secret:  []byte(webhookSecret),
+		intents: map[string]*PaymentIntent{},
+	}
+}
+
+func (p *FakePaymentProvider) Name() string {
+	return FakePaymentProviderName
+}
+
+// CreatePaymentIntent creates an intent to pay the orders total, which requires confirmation.
+func (p *FakePaymentProvider) CreatePaymentIntent(order *models.OrderModel) (*PaymentIntent, error) {
+	id, err := utils.GenerateToken(12)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := utils.GenerateToken(24)
+	if err != nil {
+		return nil, err
+	}
+
+	intent := &PaymentIntent{
+		ID:           "fake_pi_" + id,
+		Provider:     FakePaymentProviderName,
+		OrderID:      order.ID,
+		Amount:       order.Total,
+		Currency:     order.Currency,
+		Status:       PaymentRequiresConfirmation,
+		ClientSecret: "fake_secret_" + secret,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.intents[intent.ID] = intent
+
+	copied := *intent
+	return &copied, nil
+}
+
+// ConfirmPayment takes the payment of the intent, confirming an intent which already succeeded has no effect.
+func (p *FakePaymentProvider) ConfirmPayment(intentId string) (*PaymentIntent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentId]
+	if !ok {
+		return nil, ErrPaymentIntentNotFound
+	}
+	if intent.Status == PaymentRequiresConfirmation {
+		intent.Status = PaymentSucceeded
+	}
+
+	copied := *intent
+	return &copied, nil
+}
+
+// Refund refunds the payment of the intent, which must have succeeded.
+func (p *FakePaymentProvider) Refund(intentId string, amount int64) (*PaymentRefund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentId]
+	if !ok {
+		return nil, ErrPaymentIntentNotFound
+	}
+	if intent.Status != PaymentSucceeded || amount < 0 || amount > intent.Amount {
+		return nil, ErrPaymentNotRefundable
+	}
+
+	id, err := utils.GenerateToken(12)
+	if err != nil {
+		return nil, err
+	}
+
+	intent.Status = PaymentRefunded
+
+	return &PaymentRefund{ID: "fake_re_" + id, IntentID: intent.ID, Amount: amount}, nil
+}
+
+// VerifyWebhook checks that the payload was signed with the webhook secret, returning the event it contains.
+func (p *FakePaymentProvider) VerifyWebhook(payload []byte, signature string) (*PaymentEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, p.sign(payload)) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	event := &PaymentEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+	}
+
+	return event, nil
+}
+
+// SignWebhook returns the signature of the webhook payload, allowing webhook events to be sent to a local server by hand.
+func (p *FakePaymentProvider) SignWebhook(payload []byte) string {
+	return hex.EncodeToString(p.sign(payload))
+}
+
+func (p *FakePaymentProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+)
+
+func TestFakePaymentProvider_Payments(t *testing.T) {
+	provider := service.NewFakePaymentProvider("secret")
+	order := &models.OrderModel{Model: models.Model{ID: "order"}, Total: 3000, Currency: "EUR"}
+
+	intent, err := provider.CreatePaymentIntent(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if intent.OrderID != "order" || intent.Amount != 3000 || intent.Currency != "EUR" || intent.Status != service.PaymentRequiresConfirmation {
+		t.Errorf("expected intent to pay 3000 EUR for order but got %+v", intent)
+	}
+
+	if _, err := provider.Refund(intent.ID, 3000); !errors.Is(err, service.ErrPaymentNotRefundable) {
+		t.Errorf("expected unconfirmed payment to not be refundable but got %v", err)
+	}
+
+	confirmed, err := provider.ConfirmPayment(intent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if confirmed.Status != service.PaymentSucceeded {
+		t.Errorf("expected payment to succeed but was %s", confirmed.Status)
+	}
+
+	if _, err := provider.Refund(intent.ID, 3001); !errors.Is(err, service.ErrPaymentNotRefundable) {
+		t.Errorf("expected refund of more than was paid to fail but got %v", err)
+	}
+
+	refund, err := provider.Refund(intent.ID, 3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.IntentID != intent.ID || refund.Amount != 3000 {
+		t.Errorf("expected refund of 3000 for intent but got %+v", refund)
+	}
+
+	if _, err := provider.Refund(intent.ID, 3000); !errors.Is(err, service.ErrPaymentNotRefundable) {
+		t.Errorf("expected refunded payment to not be refunded again but got %v", err)
+	}
+
+	if _, err := provider.ConfirmPayment("unknown"); !errors.Is(err, service.ErrPaymentIntentNotFound) {
+		t.Errorf("expected error %v but got %v", service.ErrPaymentIntentNotFound, err)
+	}
+}
+
+func TestFakePaymentProvider_VerifyWebhook(t *testing.T) {
+	provider := service.NewFakePaymentProvider("secret")
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded","intent_id":"fake_pi_1","order_id":"order","amount":3000,"currency":"EUR"}`)
+
+	tests := []struct {
+		name      string
+		provider  *service.FakePaymentProvider
+		payload   []byte
+		signature string
+		err       error
+	}{
+		{name: "accepts payload signed with the secret", provider: provider, payload: payload, signature: provider.SignWebhook(payload)},
+		{name: "rejects payload signed with another secret", provider: provider, payload: payload, signature: service.NewFakePaymentProvider("other").SignWebhook(payload), err: service.ErrInvalidWebhookSignature},
+		{name: "rejects tampered payload", provider: provider, payload: append([]byte(" "), payload...), signature: provider.SignWebhook(payload), err: service.ErrInvalidWebhookSignature},
+		{name: "rejects malformed signature", provider: provider, payload: payload, signature: "not hex", err: service.ErrInvalidWebhookSignature},
+		{name: "rejects signed payload which is not an event", provider: provider, payload: []byte("{"), signature: provider.SignWebhook([]byte("{")), err: service.ErrInvalidWebhookPayload},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event, err := test.provider.VerifyWebhook(test.payload, test.signature)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v but got %v", test.err, err)
+			}
+			if test.err == nil && (event.ID != "evt_1" || event.Type != service.PaymentSucceededEvent || event.Amount != 3000) {
+				t.Errorf("expected payment succeeded event but got %+v", event)
+			}
+		})
+	}
+}
+
+func TestNewPaymentProvider(t *testing.T) {
+	testcases := []struct {
+		name          string
+		config        service.PaymentConfiguration
+		expectedError error
+	}{
+		{name: "creates the fake provider outside of production", config: service.PaymentConfiguration{Provider: service.FakePaymentProviderName, WebhookSecret: "secret"}},
+		{name: "refuses the fake provider in production", config: service.PaymentConfiguration{Provider: service.FakePaymentProviderName, WebhookSecret: "secret", IsProduction: true}, expectedError: service.ErrInvalidPaymentConfiguration},
+		{name: "creates providers without a webhook secret outside of production", config: service.PaymentConfiguration{Provider: service.FakePaymentProviderName}},
+		{name: "refuses providers without a webhook secret in production", config: service.PaymentConfiguration{Provider: "unknown", IsProduction: true}, expectedError: service.ErrInvalidPaymentConfiguration},
+		{name: "refuses unknown providers", config: service.PaymentConfiguration{Provider: "unknown", WebhookSecret: "secret"}, expectedError: service.ErrUnknownPaymentProvider},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := service.NewPaymentProvider(&tc.config)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v but got %v", tc.expectedError, err)
+			}
+			if tc.expectedError == nil && provider == nil {
+				t.Error("expected a payment provider to be created")
+			}
+		})
+	}
+}
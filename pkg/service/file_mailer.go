@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+type fileMailer struct {
+	logger    logging.Logger
+	directory string
+}
+
+// NewFileMailer creates a Mailer which writes emails to .eml files within the directory instead of delivering them, for development.
+// The files can be opened with most email clients.
+func NewFileMailer(directory string, lw logging.LogWriter) Mailer {
+	return &fileMailer{
+		logger:    logging.NewContextLogger(lw, "FileMailer"),
+		directory: directory,
+	}
+}
+
+// Send writes the message to a new file within the directory, creating the directory if it does not exist.
+func (m *fileMailer) Send(message *mail.Message) error {
+	if err := os.MkdirAll(m.directory, 0o755); err != nil {
+		return err
+	}
+
+	suffix, err := utils.GenerateToken(6)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(m.directory, fmt.Sprintf("%s-%s.eml", message.Date.UTC().Format("20060102T150405Z"), suffix))
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := message.Encode(file); err != nil {
+		return err
+	}
+
+	m.logger.Infof("wrote email '%s' to %s as %s", message.Subject, message.To, name)
+
+	return file.Close()
+}
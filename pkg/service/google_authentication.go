@@ -21,22 +21,20 @@ type GoogleAuthenticationConfiguration struct {
 }
 
 type GoogleJsonWebTokenAuthenticationService struct {
-	logger     logging.Logger
-	config     *GoogleAuthenticationConfiguration
-	jwtService JsonWebTokenService
-	userRepo   repository.UserRepository
+	logger   logging.Logger
+	config   *GoogleAuthenticationConfiguration
+	userRepo repository.UserRepository
 }
 
-func NewGoogleAuthenticationService(config *GoogleAuthenticationConfiguration, userRepo repository.UserRepository, jwtService JsonWebTokenService, lw logging.LogWriter) GoogleAuthenicationService {
+func NewGoogleAuthenticationService(config *GoogleAuthenticationConfiguration, userRepo repository.UserRepository, lw logging.LogWriter) GoogleAuthenicationService {
 	return &GoogleJsonWebTokenAuthenticationService{
-		logger:     logging.NewContextLogger(lw, "GoogleAuthenticationService"),
-		config:     config,
-		jwtService: jwtService,
-		userRepo:   userRepo,
+		logger:   logging.NewContextLogger(lw, "GoogleAuthenticationService"),
+		config:   config,
+		userRepo: userRepo,
 	}
 }
 
-// validates google ID token and logs in google user
+// validates google ID token and logs in google user, the access token of the result is signed once their session is started
 func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignIn(idToken string) (*dtos.LoginSuccess, error) {
 	if svc.config.ClientId == "" {
 		svc.logger.Error(ErrGoogleClietIdNotSet, "google client id not configured")
@@ -67,16 +65,6 @@ func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignIn(idToken
 
 	svc.logger.Infof("successfully verified google user %s", claims.Email)
 
-	token, err := svc.jwtService.SignAccessToken(JwtPayload{
-		Id:   existingUser.ID,
-		Role: existingUser.Role,
-	})
-
-	if err != nil {
-		svc.logger.Error(err, "unable to sign and generate token")
-		return nil, err
-	}
-
 	return &dtos.LoginSuccess{
 		User: dtos.LoginUser{
 			ID:        existingUser.ID,
@@ -85,11 +73,10 @@ func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignIn(idToken
 			LastName:  existingUser.LastName.String,
 			Role:      existingUser.Role,
 		},
-		AccessToken: *token,
 	}, nil
 }
 
-// validates google ID token and registeres google user
+// validates google ID token and registeres google user, the access token of the result is signed once their session is started
 func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignUp(dto *dtos.GoogleSignUpRequest) (*dtos.LoginSuccess, error) {
 
 	if svc.config.ClientId == "" {
@@ -133,6 +120,7 @@ func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignUp(dto *dt
 		Username:  dto.Username,
 		GoogleId:  sql.NullString{String: claims.Id, Valid: true},
 		AvatarUrl: sql.NullString{String: claims.Picture, Valid: true},
+		Verified:  claims.EmailVerified, // google has already verified the email address.
 	}
 
 	err = svc.userRepo.InsertUser(model)
@@ -142,16 +130,6 @@ func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignUp(dto *dt
 		return nil, err
 	}
 
-	token, err := svc.jwtService.SignAccessToken(JwtPayload{
-		Id:   model.ID,
-		Role: model.Role,
-	})
-
-	if err != nil {
-		svc.logger.Error(err, "unable to sign and generate token")
-		return nil, err
-	}
-
 	return &dtos.LoginSuccess{
 		User: dtos.LoginUser{
 			ID:        model.ID,
@@ -160,6 +138,5 @@ func (svc *GoogleJsonWebTokenAuthenticationService) ValidateGoogleSignUp(dto *dt
 			LastName:  model.LastName.String,
 			Role:      model.Role,
 		},
-		AccessToken: *token,
 	}, nil
 }
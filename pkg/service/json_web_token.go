@@ -5,19 +5,34 @@ import (
 	"time"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // JwtPayload represents the json web token claims to be signed or that have been parsed.
 type JwtPayload struct {
-	Id   string
-	Role types.Role
+	Id        string
+	Role      types.Role
+	SessionId string // SessionId the session the token was issued for, the "sid" claim.
 }
 
+// RefreshTokenLifetime how long refresh tokens, and the sessions they are issued for, are valid for without being refreshed.
+const RefreshTokenLifetime = 7 * 24 * time.Hour
+
 // RefreshTokenPayload represents json web token claims to be signed or that have been parsed.
 type RefreshTokenPayload struct {
-	Id string
+	Id        string
+	SessionId string // SessionId the session the token was issued for, the "sid" claim.
+	TokenId   string // TokenId identifies the token within its session, the "jti" claim.
+}
+
+// CheckInTokenPayload represents the claims of a check-in token, admitting an attendee to an occurrence of an event.
+type CheckInTokenPayload struct {
+	UserId     string
+	EventId    string
+	Occurrence time.Time // Occurrence the time the attended occurrence was originally scheduled to start.
+	ExpiresAt  time.Time
 }
 
 // JsonWebTokenService for signing and parsing json web tokens.
@@ -26,12 +41,15 @@ type JsonWebTokenService interface {
 	ParseAccessToken(string) (*JwtPayload, error)
 	SignRefreshToken(RefreshTokenPayload) (*string, error)
 	ParseRefreshToken(string) (*RefreshTokenPayload, error)
+	SignCheckInToken(CheckInTokenPayload) (*string, error)
+	ParseCheckInToken(string) (*CheckInTokenPayload, error)
 }
 
 // JsonWebTokenConfiguration settings for the json web token service.
 type JsonWebTokenConfiguration struct {
 	AccessTokenSecret  string
 	RefreshTokenSecret string
+	CheckInTokenSecret string
 }
 
 type jsonWebTokenService struct {
@@ -47,6 +65,44 @@ func NewJsonWebTokenService(config *JsonWebTokenConfiguration, lw logging.LogWri
 	}
 }
 
+type sessionJsonWebTokenService struct {
+	JsonWebTokenService
+	sessionRepository repository.SessionRepository
+}
+
+// NewSessionJsonWebTokenService wraps the JsonWebTokenService so that access tokens issued for a session are rejected once the session is revoked or has expired,
+// rather than remaining valid until they expire. This lets logging out, or ending a session from another device, take effect immediately.
+func NewSessionJsonWebTokenService(jwtService JsonWebTokenService, sessionRepository repository.SessionRepository) JsonWebTokenService {
+	return &sessionJsonWebTokenService{
+		JsonWebTokenService: jwtService,
+		sessionRepository:   sessionRepository,
+	}
+}
+
+// ParseAccessToken parses the access token, returning ErrSessionEnded when the session it was issued for is no longer active,
+// or an error wrapping ErrSessionLookup when the session could not be looked up.
+func (svc *sessionJsonWebTokenService) ParseAccessToken(tokenString string) (*JwtPayload, error) {
+	payload, err := svc.JsonWebTokenService.ParseAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// access tokens signed before sessions were introduced have no session, and remain valid until they expire.
+	if len(payload.SessionId) < 1 {
+		return payload, nil
+	}
+
+	active, err := svc.sessionRepository.IsSessionActive(payload.Id, payload.SessionId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSessionLookup, err)
+	}
+	if !active {
+		return nil, ErrSessionEnded
+	}
+
+	return payload, nil
+}
+
 // sign generates a signed JWT token for given payload using the provided secret.
 func sign(claims jwt.MapClaims, secret string) (*string, error) {
 	// Create a new token object, specifying signing method and claims
@@ -96,6 +152,9 @@ func (svc *jsonWebTokenService) SignAccessToken(payload JwtPayload) (*string, er
 		"role": payload.Role,
 		"exp":  time.Now().Add(time.Hour * 1).Unix(),
 	}
+	if payload.SessionId != "" {
+		claims["sid"] = payload.SessionId
+	}
 
 	svc.logger.Debugf("signing acccess token payload with sub: '%s', role: '%s'", payload.Id, payload.Role)
 	token, err := sign(claims, svc.config.AccessTokenSecret)
@@ -119,9 +178,12 @@ func (svc *jsonWebTokenService) ParseAccessToken(tokenString string) (*JwtPayloa
 
 	svc.logger.Debugf("parsed access token claims: id => '%s', role => '%s'", claims["sub"], claims["role"])
 
+	sessionId, _ := claims["sid"].(string)
+
 	return &JwtPayload{
-		Id:   claims["sub"].(string),
-		Role: types.Role(claims["role"].(string)),
+		Id:        claims["sub"].(string),
+		Role:      types.Role(claims["role"].(string)),
+		SessionId: sessionId,
 	}, nil
 }
 
@@ -130,11 +192,15 @@ func (svc *jsonWebTokenService) SignRefreshToken(payload RefreshTokenPayload) (*
 		return nil, fmt.Errorf("RefreshTokenPayload must contain an id")
 	}
 
-	week := time.Hour * 24 * 7
-
 	claims := jwt.MapClaims{
 		"sub": payload.Id,
-		"exp": time.Now().Add(week).Unix(), //7 day expiry
+		"exp": time.Now().Add(RefreshTokenLifetime).Unix(),
+	}
+	if payload.SessionId != "" {
+		claims["sid"] = payload.SessionId
+	}
+	if payload.TokenId != "" {
+		claims["jti"] = payload.TokenId
 	}
 
 	svc.logger.Debugf("signing refresh token payload with sub: '%s'", payload.Id)
@@ -159,7 +225,58 @@ func (svc *jsonWebTokenService) ParseRefreshToken(token string) (*RefreshTokenPa
 
 	svc.logger.Debugf("parsing refresh token claims: id => '%s'", claims["sub"])
 
-	return &RefreshTokenPayload{
+	payload := &RefreshTokenPayload{
 		Id: claims["sub"].(string),
+	}
+	payload.SessionId, _ = claims["sid"].(string)
+	payload.TokenId, _ = claims["jti"].(string)
+
+	return payload, nil
+}
+
+func (svc *jsonWebTokenService) SignCheckInToken(payload CheckInTokenPayload) (*string, error) {
+	if len(payload.UserId) < 1 || len(payload.EventId) < 1 {
+		return nil, fmt.Errorf("CheckInTokenPayload must contain a user id and event id")
+	}
+
+	claims := jwt.MapClaims{
+		"sub": payload.UserId,
+		"evt": payload.EventId,
+		"occ": payload.Occurrence.UTC().Format(time.RFC3339Nano),
+		"exp": payload.ExpiresAt.Unix(),
+	}
+
+	svc.logger.Debugf("signing check-in token payload with sub: '%s', evt: '%s'", payload.UserId, payload.EventId)
+
+	return sign(claims, svc.config.CheckInTokenSecret)
+}
+
+func (svc *jsonWebTokenService) ParseCheckInToken(token string) (*CheckInTokenPayload, error) {
+	claims, err := parse(token, svc.config.CheckInTokenSecret)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userId, _ := claims["sub"].(string)
+	eventId, _ := claims["evt"].(string)
+	occ, _ := claims["occ"].(string)
+	occurrence, err := time.Parse(time.RFC3339Nano, occ)
+	if len(userId) < 1 || len(eventId) < 1 || err != nil {
+		return nil, fmt.Errorf("check-in token is missing claims")
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, fmt.Errorf("check-in token is missing claims")
+	}
+
+	svc.logger.Debugf("parsed check-in token claims: sub => '%s', evt => '%s'", userId, eventId)
+
+	return &CheckInTokenPayload{
+		UserId:     userId,
+		EventId:    eventId,
+		Occurrence: occurrence,
+		ExpiresAt:  expiresAt.Time,
 	}, nil
 }
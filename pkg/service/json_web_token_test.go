@@ -1,11 +1,14 @@
 package service_test
 
 import (
+	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
 	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
 )
 
@@ -16,6 +19,7 @@ func init() {
 		&service.JsonWebTokenConfiguration{
 			AccessTokenSecret:  "test123",
 			RefreshTokenSecret: "test456",
+			CheckInTokenSecret: "test789",
 		},
 		logging.NewTextLogWriter(os.Stdout, logging.DEBUG),
 	)
@@ -75,8 +79,9 @@ func TestJsonWebTokenService_InvalidInputs(t *testing.T) {
 
 func TestJsonWebTokenService_SignAndParseSignedAccessToken(t *testing.T) {
 	testPayload := service.JwtPayload{
-		Id:   "test",
-		Role: types.UserRole,
+		Id:        "test",
+		Role:      types.UserRole,
+		SessionId: "session",
 	}
 
 	t.Run("sign and parse access token", func(t *testing.T) {
@@ -98,13 +103,18 @@ func TestJsonWebTokenService_SignAndParseSignedAccessToken(t *testing.T) {
 		if parsedPayload.Role != testPayload.Role {
 			t.Errorf("expected role to be %s but was %s", testPayload.Role, parsedPayload.Role)
 		}
+		if parsedPayload.SessionId != testPayload.SessionId {
+			t.Errorf("expected session id to be %s but was %s", testPayload.SessionId, parsedPayload.SessionId)
+		}
 	})
 
 }
 
 func TestJsonWebTokenService_SignAndParseSignedRefreshToken(t *testing.T) {
 	testPayload := service.RefreshTokenPayload{
-		Id: "test",
+		Id:        "test",
+		SessionId: "session",
+		TokenId:   "token",
 	}
 
 	t.Run("sign and parse refresh token", func(t *testing.T) {
@@ -123,6 +133,114 @@ func TestJsonWebTokenService_SignAndParseSignedRefreshToken(t *testing.T) {
 		if parsedPayload.Id != testPayload.Id {
 			t.Errorf("expected id to be %s but was %s", testPayload.Id, parsedPayload.Id)
 		}
+		if parsedPayload.SessionId != testPayload.SessionId || parsedPayload.TokenId != testPayload.TokenId {
+			t.Errorf("expected token %s of session %s but got token %s of session %s", testPayload.TokenId, testPayload.SessionId, parsedPayload.TokenId, parsedPayload.SessionId)
+		}
+	})
+
+}
+
+func TestJsonWebTokenService_SignAndParseSignedCheckInToken(t *testing.T) {
+	testPayload := service.CheckInTokenPayload{
+		UserId:     "user",
+		EventId:    "event",
+		Occurrence: time.Date(2024, 6, 1, 18, 30, 0, 500, time.UTC),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+
+	t.Run("sign and parse check-in token", func(t *testing.T) {
+		signed, err := jwtService.SignCheckInToken(testPayload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsedPayload, err := jwtService.ParseCheckInToken(*signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsedPayload.UserId != testPayload.UserId || parsedPayload.EventId != testPayload.EventId {
+			t.Errorf("expected check-in token of user for event but got %+v", parsedPayload)
+		}
+		if !parsedPayload.Occurrence.Equal(testPayload.Occurrence) {
+			t.Errorf("expected occurrence to be %v but was %v", testPayload.Occurrence, parsedPayload.Occurrence)
+		}
 	})
 
+	t.Run("attempt parse of expired check-in token", func(t *testing.T) {
+		expired := testPayload
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+		signed, err := jwtService.SignCheckInToken(expired)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := jwtService.ParseCheckInToken(*signed); err == nil {
+			t.Error("jwt service should return error when parsing an expired check-in token")
+		}
+	})
+
+	t.Run("attempt parse of refresh token as check-in token", func(t *testing.T) {
+		signed, err := jwtService.SignRefreshToken(service.RefreshTokenPayload{Id: "user"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := jwtService.ParseCheckInToken(*signed); err == nil {
+			t.Error("jwt service should return error when parsing a token which is not a check-in token")
+		}
+	})
+}
+
+func TestSessionJsonWebTokenService_ParseAccessToken(t *testing.T) {
+	errConnection := errors.New("connection refused")
+
+	testcases := []struct {
+		name          string
+		sessionId     string
+		active        bool
+		activeErr     error
+		expectedError error
+	}{
+		{name: "accepts access tokens of active sessions", sessionId: "session", active: true},
+		{name: "rejects access tokens of sessions which have ended", sessionId: "session", expectedError: service.ErrSessionEnded},
+		{name: "fails when the session can not be looked up", sessionId: "session", activeErr: errConnection, expectedError: service.ErrSessionLookup},
+		{name: "accepts access tokens not issued for a session"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			checked := false
+			sessions := mock.SessionRepository{
+				IsSessionActiveFn: func(userId string, sessionId string) (bool, error) {
+					checked = true
+					if userId != "user" || sessionId != tc.sessionId {
+						t.Errorf("expected session %s of user to be checked but got %s of %s", tc.sessionId, sessionId, userId)
+					}
+					return tc.active, tc.activeErr
+				},
+			}
+			svc := service.NewSessionJsonWebTokenService(jwtService, sessions)
+
+			token, err := svc.SignAccessToken(service.JwtPayload{Id: "user", Role: types.UserRole, SessionId: tc.sessionId})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			payload, err := svc.ParseAccessToken(*token)
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Fatalf("expected error %v but got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if payload.Id != "user" || payload.SessionId != tc.sessionId {
+				t.Errorf("expected the payload of the access token but got %+v", payload)
+			}
+			if checked != (tc.sessionId != "") {
+				t.Errorf("expected the session to be checked only when the token was issued for one")
+			}
+		})
+	}
 }
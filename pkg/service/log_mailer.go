@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+type logMailer struct {
+	logger logging.Logger
+}
+
+// NewLogMailer creates a Mailer which writes the plain text of emails to the log instead of delivering them, for development.
+func NewLogMailer(lw logging.LogWriter) Mailer {
+	return &logMailer{logger: logging.NewContextLogger(lw, "LogMailer")}
+}
+
+// Send writes the message to the log.
+func (m *logMailer) Send(message *mail.Message) error {
+	m.logger.Infof("email to %s: %s\n%s", message.To, message.Subject, message.Text)
+	return nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	netmail "net/mail"
+	"strings"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+const (
+	mailBatchSize  = 20              // mailBatchSize the number of emails sent each time the queue is processed.
+	mailClaimLease = 5 * time.Minute // mailClaimLease how long an email claimed for sending is hidden from other workers.
+)
+
+// MailService renders transactional emails from templates into a persistent queue, from which they are sent through the Mailer.
+// Emails which fail to send are retried with an increasing delay, until the maximum number of attempts.
+type MailService interface {
+	Enqueue(to string, template string, data map[string]any) error
+	ProcessQueue() (int, error)
+	Run(ctx context.Context)
+}
+
+type mailService struct {
+	logger              logging.Logger
+	config              *MailConfiguration
+	mailer              Mailer
+	mailQueueRepository repository.MailQueueRepository
+}
+
+// NewMailService creates a new implementation of the MailService.
+func NewMailService(config *MailConfiguration, mailer Mailer, mailQueueRepository repository.MailQueueRepository, lw logging.LogWriter) MailService {
+	return &mailService{
+		logger:              logging.NewContextLogger(lw, "MailService"),
+		config:              config,
+		mailer:              mailer,
+		mailQueueRepository: mailQueueRepository,
+	}
+}
+
+// Enqueue renders the template with the data into an email to the recipient, queueing it to be sent as soon as possible.
+func (svc *mailService) Enqueue(to string, template string, data map[string]any) error {
+	content, err := mail.Render(template, data)
+	if err != nil {
+		return err
+	}
+
+	queued := models.NewMail(to, content)
+	if err := svc.mailQueueRepository.EnqueueMail(queued); err != nil {
+		return err
+	}
+
+	svc.logger.Infof("queued %s email %s", template, queued.ID)
+
+	return nil
+}
+
+// ProcessQueue sends a batch of the emails which are due to be sent, returning the number which were sent.
+// Failing to send an email does not fail the batch, its failure is recorded so that it is retried.
+func (svc *mailService) ProcessQueue() (int, error) {
+	queued, err := svc.mailQueueRepository.ClaimDueMails(mailBatchSize, mailClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, m := range queued {
+		if err := svc.mailer.Send(svc.message(m)); err != nil {
+			m.RecordFailure(err, time.Now(), svc.config.MaxAttempts, svc.config.RetryDelay)
+			svc.logger.Errorf(err, "unable to send email %s, attempt %d of %d", m.ID, m.Attempts, svc.config.MaxAttempts)
+		} else {
+			m.RecordSent(time.Now())
+			sent++
+		}
+
+		if err := svc.mailQueueRepository.UpdateMail(m); err != nil {
+			return sent, err
+		}
+	}
+
+	if sent > 0 {
+		svc.logger.Infof("sent %d queued emails", sent)
+	}
+
+	return sent, nil
+}
+
+// Run processes the queue immediately and then once every queue interval, until the context is done.
+func (svc *mailService) Run(ctx context.Context) {
+	ticker := time.NewTicker(svc.config.QueueInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := svc.ProcessQueue(); err != nil {
+			svc.logger.Error(err, "unable to process mail queue")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// message creates the message sending the queued email from the configured sender.
+func (svc *mailService) message(m *models.MailModel) *mail.Message {
+	return &mail.Message{
+		From:      svc.config.From,
+		To:        m.Recipient,
+		Subject:   m.Subject,
+		Text:      m.TextBody,
+		HTML:      m.HTMLBody,
+		Date:      time.Now(),
+		MessageID: fmt.Sprintf("<%s@%s>", m.ID, senderDomain(svc.config.From)),
+	}
+}
+
+// senderDomain returns the domain of the sender address, which message ids are generated within.
+func senderDomain(from string) string {
+	if address, err := netmail.ParseAddress(from); err == nil {
+		if _, domain, ok := strings.Cut(address.Address, "@"); ok {
+			return domain
+		}
+	}
+	return "localhost"
+}
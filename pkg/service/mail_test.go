@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+func TestMailService_Enqueue(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+
+	var queued *models.MailModel
+	repo := mock.MailQueueRepository{
+		EnqueueMailFn: func(m *models.MailModel) error {
+			queued = m
+			return nil
+		},
+	}
+	svc := service.NewMailService(&service.MailConfiguration{}, mock.Mailer{}, repo, lw)
+	notifier := service.NewMailNotifier(svc)
+
+	user := &models.UserModel{Model: models.Model{ID: "user"}, Username: "johndoe", Email: "user@example.com"}
+	if err := notifier.Notify(user, service.Notification{Template: mail.PasswordChangedTemplate}); err != nil {
+		t.Fatal(err)
+	}
+
+	if queued == nil || queued.Recipient != user.Email || queued.Status != types.PendingMailStatus {
+		t.Fatalf("expected a pending email to the user to be queued but got %+v", queued)
+	}
+	if !strings.Contains(queued.TextBody, user.Username) || !strings.Contains(queued.HTMLBody, user.Username) {
+		t.Error("expected the email to be rendered with the notified user")
+	}
+
+	if err := svc.Enqueue(user.Email, "unknown", nil); !errors.Is(err, mail.ErrUnknownTemplate) {
+		t.Errorf("expected error %v but got %v", mail.ErrUnknownTemplate, err)
+	}
+}
+
+func TestMailService_ProcessQueue(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.MailConfiguration{
+		From:        "Events <no-reply@example.com>",
+		MaxAttempts: 3,
+		RetryDelay:  time.Minute,
+	}
+
+	queue := []*models.MailModel{
+		{Model: models.Model{ID: "delivered"}, Recipient: "user@example.com", Status: types.PendingMailStatus, Attempts: 1},
+		{Model: models.Model{ID: "retried"}, Recipient: "bounce@example.com", Status: types.PendingMailStatus, Attempts: 1},
+		{Model: models.Model{ID: "abandoned"}, Recipient: "bounce@example.com", Status: types.PendingMailStatus, Attempts: 3},
+	}
+	updated := map[string]*models.MailModel{}
+	repo := mock.MailQueueRepository{
+		ClaimDueMailsFn: func(limit int, lease time.Duration) ([]*models.MailModel, error) {
+			return queue, nil
+		},
+		UpdateMailFn: func(m *models.MailModel) error {
+			updated[m.ID] = m
+			return nil
+		},
+	}
+	mailer := mock.Mailer{
+		SendFn: func(message *mail.Message) error {
+			if message.From != config.From {
+				t.Errorf("expected the email to be sent from %s but was %s", config.From, message.From)
+			}
+			if message.To == "bounce@example.com" {
+				return errors.New("mailbox unavailable")
+			}
+			return nil
+		},
+	}
+	svc := service.NewMailService(config, mailer, repo, lw)
+
+	sent, err := svc.ProcessQueue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Errorf("expected 1 email to be sent but was %d", sent)
+	}
+
+	if m := updated["delivered"]; m.Status != types.SentMailStatus || !m.SentAt.Valid {
+		t.Errorf("expected the delivered email to be sent but got %+v", m)
+	}
+	if m := updated["retried"]; m.Status != types.PendingMailStatus || !m.LastError.Valid || m.NextAttemptAt.Before(time.Now()) {
+		t.Errorf("expected the failed email to be retried later but got %+v", m)
+	}
+	if m := updated["abandoned"]; m.Status != types.FailedMailStatus {
+		t.Errorf("expected the email to fail after its last attempt but got %+v", m)
+	}
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	directory := filepath.Join(t.TempDir(), "mail")
+
+	mailer, err := service.NewMailer(&service.MailConfiguration{Transport: service.FileMailTransport, Directory: directory}, lw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := &mail.Message{From: "no-reply@example.com", To: "user@example.com", Subject: "Hello", Text: "Hello there", Date: time.Now()}
+	if err := mailer.Send(message); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 email to be written but found %d", len(files))
+	}
+
+	contents, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(contents), "To: user@example.com\r\n") {
+		t.Errorf("expected the email to be addressed to the recipient but was %q", contents)
+	}
+}
+
+func TestNewMailer(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+
+	invalid := []service.MailConfiguration{
+		{Transport: "carrier-pigeon"},
+		{Transport: service.SMTPMailTransport},
+		{Transport: service.FileMailTransport},
+	}
+	for _, config := range invalid {
+		t.Run(config.Transport, func(t *testing.T) {
+			if _, err := service.NewMailer(&config, lw); !errors.Is(err, service.ErrInvalidMailConfiguration) {
+				t.Errorf("expected error %v but got %v", service.ErrInvalidMailConfiguration, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+// Names of the transports emails can be sent through.
+const (
+	SMTPMailTransport = "smtp" // SMTPMailTransport delivers emails to an SMTP server.
+	FileMailTransport = "file" // FileMailTransport writes emails to .eml files within a directory, for development.
+	LogMailTransport  = "log"  // LogMailTransport writes emails to the log, for development.
+)
+
+// Mailer sends emails through a transport.
+type Mailer interface {
+	Send(message *mail.Message) error
+}
+
+// MailConfiguration settings for the mailer and the queue of emails awaiting delivery.
+type MailConfiguration struct {
+	Transport     string // Transport the name of the transport emails are sent through, one of "smtp", "file" or "log".
+	From          string // From the sender of emails, such as "Events <no-reply@example.com>".
+	SMTP          SMTPConfiguration
+	Directory     string        // Directory the directory the file transport writes emails to.
+	QueueInterval time.Duration // QueueInterval how often the queue is checked for emails which are due to be sent.
+	MaxAttempts   int           // MaxAttempts the number of times sending an email is attempted before giving up on it.
+	RetryDelay    time.Duration // RetryDelay how long to wait before retrying a failed email, doubling with each attempt.
+}
+
+// SMTPConfiguration settings for the smtp transport, authentication is skipped when there is no username.
+type SMTPConfiguration struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Timeout  time.Duration // Timeout how long sending a single email may take.
+}
+
+// NewMailer creates the mailer sending emails through the transport named by the configuration.
+func NewMailer(config *MailConfiguration, lw logging.LogWriter) (Mailer, error) {
+	switch config.Transport {
+	case SMTPMailTransport:
+		if len(config.SMTP.Host) < 1 {
+			return nil, fmt.Errorf("%w: smtp transport requires a host", ErrInvalidMailConfiguration)
+		}
+		return NewSMTPMailer(&config.SMTP), nil
+	case FileMailTransport:
+		if len(config.Directory) < 1 {
+			return nil, fmt.Errorf("%w: file transport requires a directory", ErrInvalidMailConfiguration)
+		}
+		return NewFileMailer(config.Directory, lw), nil
+	case LogMailTransport:
+		return NewLogMailer(lw), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown transport %s", ErrInvalidMailConfiguration, config.Transport)
+	}
+}
+
+var (
+	ErrInvalidMailConfiguration = errors.New("invalid mail configuration") // ErrInvalidMailConfiguration is returned when the configured mail transport is unknown or missing settings.
+)
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// Notifier informs users of changes which concern them, such as the outcome of their application to become an organizer.
+type Notifier interface {
+	Notify(user *models.UserModel, notification Notification) error
+}
+
+// Notification a message informing a user of a change which concerns them.
+type Notification struct {
+	Template string         // Template the name of the mail template the message is rendered with, see the mail package.
+	Data     map[string]any // Data the values rendered within the template, the notified user is available to it as "User".
+}
+
+type mailNotifier struct {
+	mailService MailService
+}
+
+// NewMailNotifier creates a Notifier which queues notifications as emails to the users email address.
+func NewMailNotifier(mailService MailService) Notifier {
+	return &mailNotifier{mailService: mailService}
+}
+
+// Notify queues the notification as an email to the user.
+func (n *mailNotifier) Notify(user *models.UserModel, notification Notification) error {
+	data := map[string]any{"User": user}
+	for key, value := range notification.Data {
+		data[key] = value
+	}
+
+	return n.mailService.Enqueue(user.Email, notification.Template, data)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+// OrderService places ticket orders for paid events, takes their payment through the PaymentProvider and releases the tickets held by pending orders which were never paid.
+type OrderService interface {
+	PlaceOrder(userId string, occurrence *models.OccurrenceModel, payload dtos.CreateOrder) (*models.OrderModel, error)
+	StartPayment(order *models.OrderModel) (*PaymentIntent, error)
+	ConfirmPayment(order *models.OrderModel) error
+	RefundOrder(order *models.OrderModel) error
+	HandlePaymentWebhook(payload []byte, signature string) error
+	ExpirePendingOrders() (int64, error)
+	Run(ctx context.Context)
+}
+
+// OrderConfiguration settings for the order service.
+type OrderConfiguration struct {
+	HoldDuration   time.Duration // HoldDuration how long the tickets of a pending order are held for, during which it must be paid.
+	ExpiryInterval time.Duration // ExpiryInterval how often pending orders which have expired are cancelled.
+}
+
+type orderService struct {
+	logger               logging.Logger
+	config               *OrderConfiguration
+	paymentProvider      PaymentProvider
+	orderRepository      repository.OrderRepository
+	ticketTypeRepository repository.TicketTypeRepository
+}
+
+// NewOrderService creates a new implementation of the OrderService.
+func NewOrderService(config *OrderConfiguration, paymentProvider PaymentProvider, orderRepository repository.OrderRepository, ticketTypeRepository repository.TicketTypeRepository, lw logging.LogWriter) OrderService {
+	return &orderService{
+		logger:               logging.NewContextLogger(lw, "OrderService"),
+		config:               config,
+		paymentProvider:      paymentProvider,
+		orderRepository:      orderRepository,
+		ticketTypeRepository: ticketTypeRepository,
+	}
+}
+
+// PlaceOrder creates a pending order of the users chosen tickets for the occurrence, holding them for the configured hold duration.
+func (svc *orderService) PlaceOrder(userId string, occurrence *models.OccurrenceModel, payload dtos.CreateOrder) (*models.OrderModel, error) {
+	ticketTypes, err := svc.ticketTypeRepository.ListTicketTypes(occurrence.EventID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := models.NewOrder(userId, occurrence, ticketTypes, payload, time.Now(), svc.config.HoldDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.orderRepository.CreateOrder(order); err != nil {
+		return nil, err
+	}
+
+	svc.logger.Infof("user %s placed order %s for event %s", userId, order.ID, order.EventID)
+
+	return order, nil
+}
+
+// StartPayment creates a payment intent for the total of the order, which must be pending and not yet expired.
+// Starting the payment of an order again replaces its earlier payment intent.
+func (svc *orderService) StartPayment(order *models.OrderModel) (*PaymentIntent, error) {
+	if order.Status != types.PendingOrderStatus || order.IsExpired(time.Now()) {
+		return nil, ErrOrderNotPayable
+	}
+
+	intent, err := svc.paymentProvider.CreatePaymentIntent(order)
+	if err != nil {
+		return nil, err
+	}
+
+	order.SetPaymentIntent(svc.paymentProvider.Name(), intent.ID)
+	if err := svc.orderRepository.SetPaymentIntent(order); err != nil {
+		if errors.Is(err, repository.ErrOrderStatusConflict) {
+			return nil, ErrOrderNotPayable
+		}
+		return nil, err
+	}
+
+	svc.logger.Infof("started payment %s of order %s", intent.ID, order.ID)
+
+	return intent, nil
+}
+
+// ConfirmPayment takes the payment of the orders payment intent and marks the order as paid, confirming an order which is already paid has no effect.
+func (svc *orderService) ConfirmPayment(order *models.OrderModel) error {
+	if order.Status == types.PaidOrderStatus {
+		return nil
+	}
+	if order.Status != types.PendingOrderStatus || order.IsExpired(time.Now()) {
+		return ErrOrderNotPayable
+	}
+	if !order.PaymentIntentID.Valid || order.PaymentProvider.String != svc.paymentProvider.Name() {
+		return ErrPaymentNotStarted
+	}
+
+	intent, err := svc.paymentProvider.ConfirmPayment(order.PaymentIntentID.String)
+	if err != nil {
+		return err
+	}
+	if intent.Status != PaymentSucceeded {
+		return ErrPaymentDeclined
+	}
+
+	return svc.markPaid(order)
+}
+
+// RefundOrder refunds the payment of a paid order and marks it as refunded.
+// Orders which were marked as paid without a payment intent are only marked as refunded, leaving the refund to the organizer.
+func (svc *orderService) RefundOrder(order *models.OrderModel) error {
+	if !order.Status.CanTransitionTo(types.RefundedOrderStatus) {
+		return repository.ErrOrderStatusConflict
+	}
+
+	if order.PaymentIntentID.Valid {
+		refund, err := svc.paymentProvider.Refund(order.PaymentIntentID.String, order.Total)
+		if err != nil {
+			return err
+		}
+		svc.logger.Infof("refunded %d %s of order %s with refund %s", refund.Amount, order.Currency, order.ID, refund.ID)
+	}
+
+	previous := order.Status
+	order.ChangeStatus(types.RefundedOrderStatus, time.Now())
+
+	return svc.orderRepository.ChangeOrderStatus(order, previous)
+}
+
+// HandlePaymentWebhook applies a webhook event of the payment provider to the order it concerns.
+// Events which were already processed are ignored, as providers may deliver the same event more than once.
+func (svc *orderService) HandlePaymentWebhook(payload []byte, signature string) error {
+	event, err := svc.paymentProvider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	provider := svc.paymentProvider.Name()
+	processed, err := svc.orderRepository.IsPaymentEventProcessed(provider, event.ID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		svc.logger.Debugf("ignoring payment event %s which was already processed", event.ID)
+		return nil
+	}
+
+	order, err := svc.orderRepository.GetOrderByID(event.OrderID)
+	if err != nil && !errors.Is(err, repository.ErrOrderNotFound) && !errors.Is(err, repository.ErrInvalidOrderId) {
+		return err
+	}
+
+	orderId := ""
+	if order == nil {
+		svc.logger.Warnf("ignoring payment event %s of unknown order %s", event.ID, event.OrderID)
+	} else {
+		orderId = order.ID
+		switch event.Type {
+		case PaymentSucceededEvent:
+			err = svc.applyPaymentSucceeded(order, event)
+		case RefundSucceededEvent:
+			err = svc.applyRefundSucceeded(order)
+		case PaymentFailedEvent:
+			svc.logger.Infof("payment %s of order %s failed", event.IntentID, order.ID)
+		default:
+			svc.logger.Debugf("ignoring payment event %s of type %s", event.ID, event.Type)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return svc.orderRepository.RecordPaymentEvent(provider, event.ID, string(event.Type), orderId)
+}
+
+// applyPaymentSucceeded marks the order as paid, or refunds the payment when the order can no longer be paid as its tickets were released.
+// Only the payment intent started for the order can pay it, regardless of which order the event claims the payment is for.
+func (svc *orderService) applyPaymentSucceeded(order *models.OrderModel, event *PaymentEvent) error {
+	if !order.PaymentIntentID.Valid || order.PaymentIntentID.String != event.IntentID || order.PaymentProvider.String != svc.paymentProvider.Name() {
+		return ErrPaymentMismatch
+	}
+	if event.Amount != order.Total || event.Currency != order.Currency {
+		return ErrPaymentMismatch
+	}
+
+	// the order may be changed concurrently, such as by the expiry job, so reload it and try again once on a conflict.
+	for attempt := 0; ; attempt++ {
+		switch {
+		case order.Status == types.PaidOrderStatus, order.Status == types.RefundedOrderStatus:
+			return nil
+		case order.Status == types.PendingOrderStatus && !order.IsExpired(time.Now()):
+			err := svc.markPaid(order)
+			if !errors.Is(err, repository.ErrOrderStatusConflict) || attempt > 0 {
+				return err
+			}
+			if order, err = svc.orderRepository.GetOrderByID(order.ID); err != nil {
+				return err
+			}
+		default:
+			refund, err := svc.paymentProvider.Refund(event.IntentID, event.Amount)
+			if err != nil {
+				return err
+			}
+			svc.logger.Warnf("refunded payment %s of %s order %s with refund %s", event.IntentID, order.Status, order.ID, refund.ID)
+			return nil
+		}
+	}
+}
+
+// applyRefundSucceeded marks a paid order as refunded, the order has no longer been paid for when its payment was refunded outside of the application.
+func (svc *orderService) applyRefundSucceeded(order *models.OrderModel) error {
+	if order.Status != types.PaidOrderStatus {
+		return nil
+	}
+
+	order.ChangeStatus(types.RefundedOrderStatus, time.Now())
+
+	return svc.orderRepository.ChangeOrderStatus(order, types.PaidOrderStatus)
+}
+
+// markPaid marks the pending order as paid, making its user an attendee of the ordered occurrence.
+func (svc *orderService) markPaid(order *models.OrderModel) error {
+	order.ChangeStatus(types.PaidOrderStatus, time.Now())
+
+	if err := svc.orderRepository.ChangeOrderStatus(order, types.PendingOrderStatus); err != nil {
+		order.Status = types.PendingOrderStatus
+		order.PaidAt.Valid = false
+		return err
+	}
+
+	svc.logger.Infof("order %s of user %s for event %s was paid", order.ID, order.UserID, order.EventID)
+
+	return nil
+}
+
+// ExpirePendingOrders cancels all pending orders which have expired, returning the number of cancelled orders.
+func (svc *orderService) ExpirePendingOrders() (int64, error) {
+	expired, err := svc.orderRepository.ExpirePendingOrders(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if expired > 0 {
+		svc.logger.Infof("cancelled %d expired pending orders", expired)
+	}
+
+	return expired, nil
+}
+
+// Run expires pending orders immediately and then once every expiry interval, until the context is done.
+func (svc *orderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(svc.config.ExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := svc.ExpirePendingOrders(); err != nil {
+			svc.logger.Error(err, "unable to expire pending orders")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
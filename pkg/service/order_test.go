@@ -0,0 +1,305 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+func TestOrderService_PlaceOrder(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.OrderConfiguration{HoldDuration: 10 * time.Minute, ExpiryInterval: time.Minute}
+	occurrence := &models.OccurrenceModel{EventID: "event", OccurrenceStart: time.Now().AddDate(0, 1, 0)}
+	ticketTypes := mock.TicketTypeRepository{
+		ListTicketTypesFn: func(eventId string, occurrence *time.Time) ([]*models.TicketTypeModel, error) {
+			if eventId != "event" {
+				t.Errorf("expected ticket types of the ordered event but got %s", eventId)
+			}
+			return []*models.TicketTypeModel{{Model: models.Model{ID: "general"}, Name: "General", Price: 1500, Currency: "EUR"}}, nil
+		},
+	}
+	payload := dtos.CreateOrder{Items: []dtos.OrderItem{{TicketTypeID: "general", Quantity: 2}}}
+
+	t.Run("creates a pending order held for the hold duration", func(t *testing.T) {
+		before := time.Now()
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{
+			CreateOrderFn: func(order *models.OrderModel) error {
+				order.ID = "order"
+				return nil
+			},
+		}, ticketTypes, lw)
+
+		order, err := svc.PlaceOrder("user", occurrence, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.ID != "order" || order.UserID != "user" || order.Total != 3000 {
+			t.Errorf("expected order of user for 3000 but got %+v", order)
+		}
+		if order.ExpiresAt.Before(before.Add(config.HoldDuration)) {
+			t.Errorf("expected order to be held for %v but expires at %v", config.HoldDuration, order.ExpiresAt)
+		}
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{
+			CreateOrderFn: func(order *models.OrderModel) error {
+				return repository.ErrTicketsUnavailable
+			},
+		}, ticketTypes, lw)
+
+		if _, err := svc.PlaceOrder("user", occurrence, payload); !errors.Is(err, repository.ErrTicketsUnavailable) {
+			t.Errorf("expected error %v but got %v", repository.ErrTicketsUnavailable, err)
+		}
+	})
+
+	t.Run("rejects unknown ticket types before creating the order", func(t *testing.T) {
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{
+			CreateOrderFn: func(order *models.OrderModel) error {
+				t.Error("expected order to not be created")
+				return nil
+			},
+		}, ticketTypes, lw)
+
+		unknown := dtos.CreateOrder{Items: []dtos.OrderItem{{TicketTypeID: "vip", Quantity: 1}}}
+		if _, err := svc.PlaceOrder("user", occurrence, unknown); !errors.Is(err, models.ErrUnknownTicketType) {
+			t.Errorf("expected error %v but got %v", models.ErrUnknownTicketType, err)
+		}
+	})
+}
+
+func TestOrderService_ExpirePendingOrders(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.OrderConfiguration{HoldDuration: 10 * time.Minute, ExpiryInterval: time.Minute}
+
+	t.Run("expires orders held until before now", func(t *testing.T) {
+		before := time.Now()
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{
+			ExpirePendingOrdersFn: func(now time.Time) (int64, error) {
+				if now.Before(before) {
+					t.Errorf("expected orders expiring before %v to be cancelled but was %v", before, now)
+				}
+				return 3, nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		expired, err := svc.ExpirePendingOrders()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expired != 3 {
+			t.Errorf("expected 3 expired orders but got %d", expired)
+		}
+	})
+}
+
+func TestOrderService_Payments(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.OrderConfiguration{HoldDuration: 10 * time.Minute, ExpiryInterval: time.Minute}
+
+	pendingOrder := func() *models.OrderModel {
+		return &models.OrderModel{Model: models.Model{ID: "order"}, UserID: "user", Status: types.PendingOrderStatus, Total: 3000, Currency: "EUR", ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	t.Run("starts and confirms the payment of a pending order", func(t *testing.T) {
+		var previous types.OrderStatus
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{
+			ChangeOrderStatusFn: func(order *models.OrderModel, p types.OrderStatus) error {
+				previous = p
+				return nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		order := pendingOrder()
+		intent, err := svc.StartPayment(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.PaymentIntentID.String != intent.ID || order.PaymentProvider.String != service.FakePaymentProviderName {
+			t.Errorf("expected order to reference payment intent %s but got %+v", intent.ID, order)
+		}
+
+		if err := svc.ConfirmPayment(order); err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != types.PaidOrderStatus || !order.PaidAt.Valid || previous != types.PendingOrderStatus {
+			t.Errorf("expected pending order to be paid but got %+v", order)
+		}
+	})
+
+	t.Run("rejects payment of expired orders", func(t *testing.T) {
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{}, mock.TicketTypeRepository{}, lw)
+
+		order := pendingOrder()
+		order.ExpiresAt = time.Now().Add(-time.Minute)
+		if _, err := svc.StartPayment(order); !errors.Is(err, service.ErrOrderNotPayable) {
+			t.Errorf("expected error %v but got %v", service.ErrOrderNotPayable, err)
+		}
+	})
+
+	t.Run("rejects confirming a payment which was not started", func(t *testing.T) {
+		svc := service.NewOrderService(config, service.NewFakePaymentProvider("secret"), mock.OrderRepository{}, mock.TicketTypeRepository{}, lw)
+
+		if err := svc.ConfirmPayment(pendingOrder()); !errors.Is(err, service.ErrPaymentNotStarted) {
+			t.Errorf("expected error %v but got %v", service.ErrPaymentNotStarted, err)
+		}
+	})
+
+	t.Run("refunds the payment of a paid order", func(t *testing.T) {
+		provider := service.NewFakePaymentProvider("secret")
+		svc := service.NewOrderService(config, provider, mock.OrderRepository{}, mock.TicketTypeRepository{}, lw)
+
+		order := pendingOrder()
+		if _, err := svc.StartPayment(order); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.ConfirmPayment(order); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.RefundOrder(order); err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != types.RefundedOrderStatus {
+			t.Errorf("expected order to be refunded but was %s", order.Status)
+		}
+		if _, err := provider.Refund(order.PaymentIntentID.String, 1); !errors.Is(err, service.ErrPaymentNotRefundable) {
+			t.Errorf("expected payment to have been refunded by the provider but got %v", err)
+		}
+	})
+}
+
+func TestOrderService_HandlePaymentWebhook(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.OrderConfiguration{HoldDuration: 10 * time.Minute, ExpiryInterval: time.Minute}
+	provider := service.NewFakePaymentProvider("secret")
+	payload := []byte(`{"id":"evt_1","type":"payment.succeeded","intent_id":"fake_pi_1","order_id":"order","amount":3000,"currency":"EUR"}`)
+
+	t.Run("marks the order paid once when the event is delivered again", func(t *testing.T) {
+		recorded := map[string]bool{}
+		paid := 0
+		svc := service.NewOrderService(config, provider, mock.OrderRepository{
+			IsPaymentEventProcessedFn: func(provider string, eventId string) (bool, error) {
+				return recorded[provider+eventId], nil
+			},
+			RecordPaymentEventFn: func(provider string, eventId string, eventType string, orderId string) error {
+				if orderId != "order" || eventType != string(service.PaymentSucceededEvent) {
+					t.Errorf("expected payment succeeded event of order to be recorded but got %s of %s", eventType, orderId)
+				}
+				recorded[provider+eventId] = true
+				return nil
+			},
+			GetOrderByIDFn: func(id string) (*models.OrderModel, error) {
+				return pendingOrder(id, "fake_pi_1", 3000), nil
+			},
+			ChangeOrderStatusFn: func(order *models.OrderModel, previous types.OrderStatus) error {
+				if order.Status != types.PaidOrderStatus {
+					t.Errorf("expected order to be paid but was %s", order.Status)
+				}
+				paid++
+				return nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		for range 2 {
+			if err := svc.HandlePaymentWebhook(payload, provider.SignWebhook(payload)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if paid != 1 {
+			t.Errorf("expected order to be paid once but was paid %d times", paid)
+		}
+	})
+
+	t.Run("rejects events with an invalid signature", func(t *testing.T) {
+		svc := service.NewOrderService(config, provider, mock.OrderRepository{
+			RecordPaymentEventFn: func(provider string, eventId string, eventType string, orderId string) error {
+				t.Error("expected event to not be recorded")
+				return nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		if err := svc.HandlePaymentWebhook(payload, "invalid"); !errors.Is(err, service.ErrInvalidWebhookSignature) {
+			t.Errorf("expected error %v but got %v", service.ErrInvalidWebhookSignature, err)
+		}
+	})
+
+	t.Run("rejects payments of a different amount", func(t *testing.T) {
+		svc := service.NewOrderService(config, provider, mock.OrderRepository{
+			GetOrderByIDFn: func(id string) (*models.OrderModel, error) {
+				return pendingOrder(id, "fake_pi_1", 4500), nil
+			},
+			ChangeOrderStatusFn: func(order *models.OrderModel, previous types.OrderStatus) error {
+				t.Error("expected order to not be paid")
+				return nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		if err := svc.HandlePaymentWebhook(payload, provider.SignWebhook(payload)); !errors.Is(err, service.ErrPaymentMismatch) {
+			t.Errorf("expected error %v but got %v", service.ErrPaymentMismatch, err)
+		}
+	})
+
+	t.Run("rejects payments of another payment intent than the one started for the order", func(t *testing.T) {
+		svc := service.NewOrderService(config, provider, mock.OrderRepository{
+			GetOrderByIDFn: func(id string) (*models.OrderModel, error) {
+				return pendingOrder(id, "fake_pi_2", 3000), nil
+			},
+			ChangeOrderStatusFn: func(order *models.OrderModel, previous types.OrderStatus) error {
+				t.Error("expected order to not be paid")
+				return nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		if err := svc.HandlePaymentWebhook(payload, provider.SignWebhook(payload)); !errors.Is(err, service.ErrPaymentMismatch) {
+			t.Errorf("expected error %v but got %v", service.ErrPaymentMismatch, err)
+		}
+	})
+
+	t.Run("refunds payments of orders cancelled before they were paid", func(t *testing.T) {
+		provider := service.NewFakePaymentProvider("secret")
+		order := &models.OrderModel{Model: models.Model{ID: "order"}, Status: types.PendingOrderStatus, Total: 3000, Currency: "EUR", ExpiresAt: time.Now().Add(time.Minute)}
+		intent, err := provider.CreatePaymentIntent(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := provider.ConfirmPayment(intent.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		svc := service.NewOrderService(config, provider, mock.OrderRepository{
+			GetOrderByIDFn: func(id string) (*models.OrderModel, error) {
+				cancelled := pendingOrder(id, intent.ID, 3000)
+				cancelled.Status = types.CancelledOrderStatus
+				return cancelled, nil
+			},
+			ChangeOrderStatusFn: func(order *models.OrderModel, previous types.OrderStatus) error {
+				t.Error("expected cancelled order to not be paid")
+				return nil
+			},
+		}, mock.TicketTypeRepository{}, lw)
+
+		cancelled := []byte(`{"id":"evt_2","type":"payment.succeeded","intent_id":"` + intent.ID + `","order_id":"order","amount":3000,"currency":"EUR"}`)
+		if err := svc.HandlePaymentWebhook(cancelled, provider.SignWebhook(cancelled)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := provider.Refund(intent.ID, 1); !errors.Is(err, service.ErrPaymentNotRefundable) {
+			t.Errorf("expected payment to have been refunded but got %v", err)
+		}
+	})
+}
+
+// pendingOrder returns a pending order of the total in EUR, which is being paid through the payment intent of the fake provider.
+func pendingOrder(id string, intentId string, total int64) *models.OrderModel {
+	order := &models.OrderModel{Model: models.Model{ID: id}, Status: types.PendingOrderStatus, Total: total, Currency: "EUR", ExpiresAt: time.Now().Add(time.Minute)}
+	order.SetPaymentIntent(service.FakePaymentProviderName, intentId)
+	return order
+}
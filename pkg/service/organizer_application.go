@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+// OrganizerApplicationService handles the applications of users to become organizers, which are reviewed by admins.
+type OrganizerApplicationService interface {
+	Apply(user *models.UserModel, payload dtos.ApplyForOrganizer) (*models.OrganizerApplicationModel, error)
+	Review(id string, reviewer *models.UserModel, payload dtos.ReviewOrganizerApplication) (*models.OrganizerApplicationModel, error)
+}
+
+type organizerApplicationService struct {
+	logger                logging.Logger
+	applicationRepository repository.OrganizerApplicationRepository
+	userRepository        repository.UserRepository
+	notifier              Notifier
+}
+
+// NewOrganizerApplicationService creates a new implementation of the OrganizerApplicationService.
+func NewOrganizerApplicationService(applicationRepository repository.OrganizerApplicationRepository, userRepository repository.UserRepository, notifier Notifier, lw logging.LogWriter) OrganizerApplicationService {
+	return &organizerApplicationService{
+		logger:                logging.NewContextLogger(lw, "OrganizerApplicationService"),
+		applicationRepository: applicationRepository,
+		userRepository:        userRepository,
+		notifier:              notifier,
+	}
+}
+
+// Apply submits the application of the user to become an organizer, which awaits review by an admin.
+// Users who may already create events can not apply, and a user may only have a single application under review.
+func (svc *organizerApplicationService) Apply(user *models.UserModel, payload dtos.ApplyForOrganizer) (*models.OrganizerApplicationModel, error) {
+	if user.Role.Grants(types.CreateEventPermission) {
+		return nil, ErrAlreadyOrganizer
+	}
+
+	application := models.NewOrganizerApplication(user.ID, payload)
+	if err := svc.applicationRepository.CreateApplication(application); err != nil {
+		return nil, err
+	}
+	application.Username = user.Username
+
+	svc.logger.Infof("user %s applied to become an organizer with application %s", user.ID, application.ID)
+
+	return application, nil
+}
+
+// Review approves or rejects the pending application, notifying the applicant of the outcome.
+// Approving an application makes the applicant an organizer.
+func (svc *organizerApplicationService) Review(id string, reviewer *models.UserModel, payload dtos.ReviewOrganizerApplication) (*models.OrganizerApplicationModel, error) {
+	application, err := svc.applicationRepository.GetApplicationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status.IsReviewed() {
+		return nil, repository.ErrApplicationReviewed
+	}
+
+	application.Review(reviewer.ID, payload, time.Now())
+	if err := svc.applicationRepository.ReviewApplication(application); err != nil {
+		return nil, err
+	}
+
+	svc.logger.Infof("organizer application %s of user %s was %s by %s", application.ID, application.UserID, application.Status, reviewer.ID)
+
+	// the decision is stored, so failing to notify the applicant does not fail the review.
+	if err := svc.notifyApplicant(application); err != nil {
+		svc.logger.Errorf(err, "unable to notify user %s of the review of organizer application %s", application.UserID, application.ID)
+	}
+
+	return application, nil
+}
+
+// notifyApplicant informs the applicant of the decision on their application.
+func (svc *organizerApplicationService) notifyApplicant(application *models.OrganizerApplicationModel) error {
+	applicant, err := svc.userRepository.GetUserByID(application.UserID)
+	if err != nil {
+		return err
+	}
+
+	notification := Notification{
+		Template: mail.OrganizerApplicationApprovedTemplate,
+		Data:     map[string]any{"Comment": application.ReviewComment.String},
+	}
+	if !application.IsApproved() {
+		notification.Template = mail.OrganizerApplicationRejectedTemplate
+	}
+
+	return svc.notifier.Notify(applicant, notification)
+}
+
+var (
+	ErrAlreadyOrganizer = errors.New("user can already create events") // ErrAlreadyOrganizer is returned when a user who may already create events applies to become an organizer.
+)
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/net/dtos"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+func TestOrganizerApplicationService_Apply(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	payload := dtos.ApplyForOrganizer{Reason: "I run a monthly meetup for local developers.", ContactEmail: "user@example.com"}
+
+	testcases := []struct {
+		name          string
+		role          types.Role
+		createErr     error
+		expectedError error
+	}{
+		{name: "creates a pending application for users", role: types.UserRole},
+		{name: "rejects organizers", role: types.OrganizerRole, expectedError: service.ErrAlreadyOrganizer},
+		{name: "rejects admins", role: types.AdminRole, expectedError: service.ErrAlreadyOrganizer},
+		{name: "rejects users with a pending application", role: types.UserRole, createErr: repository.ErrApplicationPending, expectedError: repository.ErrApplicationPending},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			created := false
+			repo := mock.OrganizerApplicationRepository{
+				CreateApplicationFn: func(application *models.OrganizerApplicationModel) error {
+					created = true
+					return tc.createErr
+				},
+			}
+			svc := service.NewOrganizerApplicationService(repo, mock.UserRepository{}, mock.Notifier{}, lw)
+
+			user := &models.UserModel{Model: models.Model{ID: "user"}, Username: "user", Role: tc.role}
+			application, err := svc.Apply(user, payload)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v but got %v", tc.expectedError, err)
+			}
+			if tc.expectedError != nil {
+				return
+			}
+
+			if !created {
+				t.Error("expected the application to be stored")
+			}
+			if application.UserID != user.ID || application.Status != types.PendingApplicationStatus {
+				t.Errorf("expected a pending application of user %s but got %+v", user.ID, application)
+			}
+		})
+	}
+}
+
+func TestOrganizerApplicationService_Review(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	reviewer := &models.UserModel{Model: models.Model{ID: "admin"}, Role: types.AdminRole}
+	applicant := &models.UserModel{Model: models.Model{ID: "user"}, Email: "user@example.com", Role: types.UserRole}
+
+	testcases := []struct {
+		name             string
+		status           types.OrganizerApplicationStatus
+		payload          dtos.ReviewOrganizerApplication
+		expectedError    error
+		expectedTemplate string
+	}{
+		{
+			name:             "approves pending applications",
+			status:           types.PendingApplicationStatus,
+			payload:          dtos.ReviewOrganizerApplication{Status: types.ApprovedApplicationStatus},
+			expectedTemplate: mail.OrganizerApplicationApprovedTemplate,
+		},
+		{
+			name:             "rejects pending applications with a comment",
+			status:           types.PendingApplicationStatus,
+			payload:          dtos.ReviewOrganizerApplication{Status: types.RejectedApplicationStatus, Comment: "Please link to a previous event."},
+			expectedTemplate: mail.OrganizerApplicationRejectedTemplate,
+		},
+		{
+			name:          "does not review reviewed applications",
+			status:        types.ApprovedApplicationStatus,
+			payload:       dtos.ReviewOrganizerApplication{Status: types.RejectedApplicationStatus, Comment: "Changed my mind."},
+			expectedError: repository.ErrApplicationReviewed,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			stored := false
+			repo := mock.OrganizerApplicationRepository{
+				GetApplicationByIDFn: func(id string) (*models.OrganizerApplicationModel, error) {
+					return &models.OrganizerApplicationModel{Model: models.Model{ID: id}, UserID: applicant.ID, Status: tc.status}, nil
+				},
+				ReviewApplicationFn: func(application *models.OrganizerApplicationModel) error {
+					stored = true
+					return nil
+				},
+			}
+			users := mock.UserRepository{
+				GetUserByIDFn: func(id string) (*models.UserModel, error) {
+					return applicant, nil
+				},
+			}
+			var notifications []service.Notification
+			notifier := mock.Notifier{
+				NotifyFn: func(user *models.UserModel, notification service.Notification) error {
+					if user.ID != applicant.ID {
+						t.Errorf("expected applicant %s to be notified but was %s", applicant.ID, user.ID)
+					}
+					notifications = append(notifications, notification)
+					return nil
+				},
+			}
+			svc := service.NewOrganizerApplicationService(repo, users, notifier, lw)
+
+			application, err := svc.Review("application", reviewer, tc.payload)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error %v but got %v", tc.expectedError, err)
+			}
+			if tc.expectedError != nil {
+				if stored || len(notifications) > 0 {
+					t.Error("expected the reviewed application to be left unchanged")
+				}
+				return
+			}
+
+			if !stored {
+				t.Error("expected the review to be stored")
+			}
+			if application.Status != tc.payload.Status || application.ReviewedBy.String != reviewer.ID {
+				t.Errorf("expected application %s by %s but got %+v", tc.payload.Status, reviewer.ID, application)
+			}
+			if len(notifications) != 1 {
+				t.Fatalf("expected the applicant to be notified once but was notified %d times", len(notifications))
+			}
+			if notifications[0].Template != tc.expectedTemplate {
+				t.Errorf("expected template %s but was %s", tc.expectedTemplate, notifications[0].Template)
+			}
+			if notifications[0].Data["Comment"] != tc.payload.Comment {
+				t.Errorf("expected the comment of the reviewer %q but was %q", tc.payload.Comment, notifications[0].Data["Comment"])
+			}
+		})
+	}
+}
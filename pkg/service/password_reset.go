@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+// passwordResetTokenSize the number of random bytes within a password reset token.
+const passwordResetTokenSize = 32
+
+// PasswordResetService sends users who forgot their password a link containing a single-use token, which allows them to choose a new password.
+type PasswordResetService interface {
+	RequestReset(email string) error
+	ResetPassword(token string, password string) error
+}
+
+// PasswordResetConfiguration settings for the password reset service.
+type PasswordResetConfiguration struct {
+	ResetURL       string        // ResetURL the page where users choose a new password, the token is appended to it as the 'token' query parameter.
+	TokenLifetime  time.Duration // TokenLifetime how long a password reset link can be followed for after it was sent.
+	ResendInterval time.Duration // ResendInterval how long a user must wait before another password reset link is sent to them.
+	MaxSendsPerDay int           // MaxSendsPerDay the number of password reset links which may be sent to a user within a day.
+}
+
+type passwordResetService struct {
+	logger              logging.Logger
+	config              *PasswordResetConfiguration
+	userTokenRepository repository.UserTokenRepository
+	userRepository      repository.UserRepository
+	notifier            Notifier
+}
+
+// NewPasswordResetService creates a new implementation of the PasswordResetService.
+func NewPasswordResetService(config *PasswordResetConfiguration, userTokenRepository repository.UserTokenRepository, userRepository repository.UserRepository, notifier Notifier, lw logging.LogWriter) PasswordResetService {
+	return &passwordResetService{
+		logger:              logging.NewContextLogger(lw, "PasswordResetService"),
+		config:              config,
+		userTokenRepository: userTokenRepository,
+		userRepository:      userRepository,
+		notifier:            notifier,
+	}
+}
+
+// RequestReset sends a password reset link to the user with the email address, any link sent to them before stops working.
+// To avoid revealing which email addresses have accounts, no error is returned when there is no such user or they requested links too often.
+func (svc *passwordResetService) RequestReset(email string) error {
+	user, err := svc.userRepository.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			svc.logger.Infof("password reset was requested for unknown email %s", email)
+			return nil
+		}
+		return err
+	}
+
+	throttled, err := isTokenThrottled(svc.userTokenRepository, user.ID, types.PasswordResetTokenPurpose, svc.config.ResendInterval, svc.config.MaxSendsPerDay)
+	if err != nil {
+		return err
+	}
+	if throttled {
+		svc.logger.Infof("password reset of user %s was throttled", user.ID)
+		return nil
+	}
+
+	token, err := utils.GenerateToken(passwordResetTokenSize)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.userTokenRepository.CreateToken(models.NewUserToken(user.ID, types.PasswordResetTokenPurpose, token, svc.config.TokenLifetime)); err != nil {
+		return err
+	}
+
+	svc.logger.Infof("sending password reset link to user %s", user.ID)
+
+	return svc.notifier.Notify(user, Notification{
+		Template: mail.ResetPasswordTemplate,
+		Data: map[string]any{
+			"Link":      fmt.Sprintf("%s?token=%s", svc.config.ResetURL, token),
+			"ExpiresIn": svc.config.TokenLifetime.String(),
+		},
+	})
+}
+
+// ResetPassword uses the token sent within a password reset link to replace the password of the user it was sent to, signing them out of every device.
+// Returns ErrInvalidPasswordResetToken when the token is unknown, has expired or was already used.
+func (svc *passwordResetService) ResetPassword(token string, password string) error {
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	userId, err := svc.userTokenRepository.ResetPassword(utils.HashToken(token), *hash)
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenInvalid) {
+			return ErrInvalidPasswordResetToken
+		}
+		return err
+	}
+
+	svc.logger.Infof("user %s reset their password", userId)
+
+	// the password is reset, so failing to notify the user does not fail the reset.
+	if err := svc.notifyPasswordChanged(userId); err != nil {
+		svc.logger.Errorf(err, "unable to notify user %s of their password reset", userId)
+	}
+
+	return nil
+}
+
+// notifyPasswordChanged informs the user that their password was changed, so they can act if they did not change it.
+func (svc *passwordResetService) notifyPasswordChanged(userId string) error {
+	user, err := svc.userRepository.GetUserByID(userId)
+	if err != nil {
+		return err
+	}
+
+	return svc.notifier.Notify(user, Notification{Template: mail.PasswordChangedTemplate})
+}
+
+var (
+	ErrInvalidPasswordResetToken = errors.New("password reset link is invalid or has expired") // ErrInvalidPasswordResetToken is returned when resetting a password with a token which is unknown, expired or was already used.
+)
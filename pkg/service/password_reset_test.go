@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/logging"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/test/mock"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+func TestPasswordResetService_RequestReset(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	config := &service.PasswordResetConfiguration{
+		ResetURL:       "https://events.example.com/reset-password",
+		TokenLifetime:  time.Hour,
+		ResendInterval: time.Minute,
+		MaxSendsPerDay: 5,
+	}
+	users := mock.UserRepository{
+		GetUserByEmailFn: func(email string) (*models.UserModel, error) {
+			if email == "user@example.com" {
+				return &models.UserModel{Model: models.Model{ID: "user"}, Email: email}, nil
+			}
+			return nil, repository.ErrUserNotFound
+		},
+	}
+
+	testcases := []struct {
+		name       string
+		email      string
+		recent     int // recent the number of links sent within the resend interval.
+		expectSent bool
+	}{
+		{name: "sends a link to existing users", email: "user@example.com", expectSent: true},
+		{name: "silently ignores unknown email addresses", email: "unknown@example.com"},
+		{name: "silently throttles links sent within the resend interval", email: "user@example.com", recent: 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created *models.UserTokenModel
+			tokens := mock.UserTokenRepository{
+				CreateTokenFn: func(token *models.UserTokenModel) error {
+					created = token
+					return nil
+				},
+				CountTokensSinceFn: func(userId string, purpose types.UserTokenPurpose, since time.Time) (int, error) {
+					return tc.recent, nil
+				},
+			}
+			var notifications []service.Notification
+			notifier := mock.Notifier{
+				NotifyFn: func(user *models.UserModel, notification service.Notification) error {
+					notifications = append(notifications, notification)
+					return nil
+				},
+			}
+			svc := service.NewPasswordResetService(config, tokens, users, notifier, lw)
+
+			if err := svc.RequestReset(tc.email); err != nil {
+				t.Fatal(err)
+			}
+
+			if !tc.expectSent {
+				if created != nil || len(notifications) > 0 {
+					t.Error("expected no link to be sent")
+				}
+				return
+			}
+
+			if created == nil || created.Purpose != types.PasswordResetTokenPurpose {
+				t.Fatalf("expected a password reset token to be stored but got %+v", created)
+			}
+			if len(notifications) != 1 {
+				t.Fatalf("expected the user to be notified once but was notified %d times", len(notifications))
+			}
+			if notifications[0].Template != mail.ResetPasswordTemplate {
+				t.Errorf("expected template %s but was %s", mail.ResetPasswordTemplate, notifications[0].Template)
+			}
+
+			// the link contains the token itself, while only its hash is stored.
+			link, _ := notifications[0].Data["Link"].(string)
+			token, ok := strings.CutPrefix(link, config.ResetURL+"?token=")
+			if !ok {
+				t.Fatalf("expected the link to be within the notification but was %q", link)
+			}
+			if utils.HashToken(token) != created.TokenHash {
+				t.Errorf("expected the stored hash to match the token within the link")
+			}
+		})
+	}
+}
+
+func TestPasswordResetService_ResetPassword(t *testing.T) {
+	lw := logging.NewTextLogWriter(os.Stdout, logging.DEBUG)
+	var storedHash string
+	tokens := mock.UserTokenRepository{
+		ResetPasswordFn: func(tokenHash string, passwordHash string) (string, error) {
+			if tokenHash != utils.HashToken("valid") {
+				return "", repository.ErrTokenInvalid
+			}
+			storedHash = passwordHash
+			return "user", nil
+		},
+	}
+	users := mock.UserRepository{
+		GetUserByIDFn: func(id string) (*models.UserModel, error) {
+			return &models.UserModel{Model: models.Model{ID: id}}, nil
+		},
+	}
+	notified := 0
+	notifier := mock.Notifier{
+		NotifyFn: func(user *models.UserModel, notification service.Notification) error {
+			notified++
+			return nil
+		},
+	}
+	svc := service.NewPasswordResetService(&service.PasswordResetConfiguration{}, tokens, users, notifier, lw)
+
+	t.Run("rejects unknown, expired or used tokens", func(t *testing.T) {
+		if err := svc.ResetPassword("invalid", "Pa22W0rd123"); !errors.Is(err, service.ErrInvalidPasswordResetToken) {
+			t.Errorf("expected error %v but got %v", service.ErrInvalidPasswordResetToken, err)
+		}
+	})
+
+	t.Run("stores the hash of the new password and notifies the user", func(t *testing.T) {
+		if err := svc.ResetPassword("valid", "Pa22W0rd123"); err != nil {
+			t.Fatal(err)
+		}
+		if !utils.DoesPasswordMatch("Pa22W0rd123", storedHash) {
+			t.Error("expected the stored hash to match the new password")
+		}
+		if notified != 1 {
+			t.Errorf("expected the user to be notified once but was notified %d times", notified)
+		}
+	})
+}
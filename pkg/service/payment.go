@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+// PaymentProvider takes payments for orders through an external payment processor.
+// Payments are made against a payment intent created for the order, and the processor reports their outcome through signed webhook events.
+type PaymentProvider interface {
+	// Name identifies the provider, such as "fake".
+	Name() string
+	// CreatePaymentIntent prepares the payment of the orders total, returning the intent the buyer pays against.
+	CreatePaymentIntent(order *models.OrderModel) (*PaymentIntent, error)
+	// ConfirmPayment attempts to take the payment of the intent, returning its resulting state.
+	ConfirmPayment(intentId string) (*PaymentIntent, error)
+	// Refund returns the amount of a succeeded payment to the buyer.
+	Refund(intentId string, amount int64) (*PaymentRefund, error)
+	// VerifyWebhook checks the signature of a webhook request body, returning the event it describes.
+	VerifyWebhook(payload []byte, signature string) (*PaymentEvent, error)
+}
+
+// PaymentStatus the state of a payment intent.
+type PaymentStatus string
+
+const (
+	PaymentRequiresConfirmation PaymentStatus = "requires_confirmation"
+	PaymentSucceeded            PaymentStatus = "succeeded"
+	PaymentFailed               PaymentStatus = "failed"
+	PaymentRefunded             PaymentStatus = "refunded"
+)
+
+// PaymentIntent the payment of an order, as tracked by a payment provider.
+type PaymentIntent struct {
+	ID       string        `json:"id"`
+	Provider string        `json:"provider"`
+	OrderID  string        `json:"order_id"`
+	Amount   int64         `json:"amount"`   // Amount in the minor unit of the currency, such as cents.
+	Currency string        `json:"currency"` // Currency the ISO 4217 code of the currency, such as "EUR".
+	Status   PaymentStatus `json:"status"`
+	// ClientSecret allows the buyer to complete the payment with the provider directly, it is only shared with the user who placed the order.
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// PaymentRefund a refund of a payment, as tracked by a payment provider.
+type PaymentRefund struct {
+	ID       string `json:"id"`
+	IntentID string `json:"intent_id"`
+	Amount   int64  `json:"amount"`
+}
+
+// PaymentEventType the kind of change a payment provider reports through its webhook.
+type PaymentEventType string
+
+const (
+	PaymentSucceededEvent PaymentEventType = "payment.succeeded"
+	PaymentFailedEvent    PaymentEventType = "payment.failed"
+	RefundSucceededEvent  PaymentEventType = "refund.succeeded"
+)
+
+// PaymentEvent a change to a payment reported by a payment provider.
+// Providers may deliver an event more than once, so events are identified by their id.
+type PaymentEvent struct {
+	ID       string           `json:"id"`
+	Type     PaymentEventType `json:"type"`
+	IntentID string           `json:"intent_id"`
+	OrderID  string           `json:"order_id"`
+	Amount   int64            `json:"amount"`
+	Currency string           `json:"currency"`
+}
+
+// PaymentConfiguration settings for the payment provider.
+type PaymentConfiguration struct {
+	Provider      string // Provider the name of the payment provider to use, only "fake" is currently supported.
+	WebhookSecret string // WebhookSecret the secret webhook events are signed with, required in production and DevelopmentWebhookSecret otherwise when empty.
+	IsProduction  bool   // IsProduction whether payments are taken in production, where the fake provider is refused.
+}
+
+// DevelopmentWebhookSecret the secret webhook events are signed with outside of production when none is configured,
+// so that the server can be run without configuring payments.
+const DevelopmentWebhookSecret = "development"
+
+// NewPaymentProvider creates the payment provider named by the configuration.
+// The fake provider confirms every payment, so it is refused in production.
+func NewPaymentProvider(config *PaymentConfiguration) (PaymentProvider, error) {
+	webhookSecret := config.WebhookSecret
+	if len(webhookSecret) < 1 {
+		if config.IsProduction {
+			return nil, fmt.Errorf("%w: a webhook secret is required in production", ErrInvalidPaymentConfiguration)
+		}
+		webhookSecret = DevelopmentWebhookSecret
+	}
+
+	switch config.Provider {
+	case FakePaymentProviderName:
+		if config.IsProduction {
+			return nil, fmt.Errorf("%w: the fake payment provider can not be used in production", ErrInvalidPaymentConfiguration)
+		}
+		return NewFakePaymentProvider(webhookSecret), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPaymentProvider, config.Provider)
+	}
+}
+
+var (
+	ErrUnknownPaymentProvider      = errors.New("unknown payment provider")         // ErrUnknownPaymentProvider is returned when the configured payment provider is not supported.
+	ErrInvalidPaymentConfiguration = errors.New("invalid payment configuration")    // ErrInvalidPaymentConfiguration is returned when the payment provider can not be used with the configuration.
+	ErrPaymentIntentNotFound       = errors.New("payment intent not found")         // ErrPaymentIntentNotFound is returned when a provider does not know the payment intent.
+	ErrPaymentNotRefundable        = errors.New("payment can not be refunded")      // ErrPaymentNotRefundable is returned when refunding a payment which has not succeeded, or more than was paid.
+	ErrInvalidWebhookSignature     = errors.New("invalid webhook signature")        // ErrInvalidWebhookSignature is returned when a webhook request was not signed by the provider.
+	ErrInvalidWebhookPayload       = errors.New("invalid webhook payload")          // ErrInvalidWebhookPayload is returned when a signed webhook request does not describe a payment event.
+	ErrPaymentDeclined             = errors.New("payment was declined")             // ErrPaymentDeclined is returned when confirming a payment which the provider did not take.
+	ErrOrderNotPayable             = errors.New("order is not awaiting payment")    // ErrOrderNotPayable is returned when paying an order which is not pending or has expired.
+	ErrPaymentMismatch             = errors.New("payment does not match the order") // ErrPaymentMismatch is returned when a payment is for a different amount or currency than its order.
+	ErrPaymentNotStarted           = errors.New("payment of order was not started") // ErrPaymentNotStarted is returned when confirming the payment of an order which has no payment intent.
+)
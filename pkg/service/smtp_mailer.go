@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"crypto/tls"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+type smtpMailer struct {
+	config *SMTPConfiguration
+}
+
+// NewSMTPMailer creates a Mailer delivering emails to the SMTP server, upgrading the connection with STARTTLS when the server supports it.
+func NewSMTPMailer(config *SMTPConfiguration) Mailer {
+	return &smtpMailer{config: config}
+}
+
+// Send delivers the message to the SMTP server, giving up once the timeout has passed.
+func (m *smtpMailer) Send(message *mail.Message) error {
+	from, err := netmail.ParseAddress(message.From)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	if err := message.Encode(&body); err != nil {
+		return err
+	}
+
+	timeout := m.config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port)), timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return err
+		}
+	}
+
+	// PlainAuth refuses to send credentials over an unencrypted connection, except to localhost.
+	if len(m.config.Username) > 0 {
+		if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
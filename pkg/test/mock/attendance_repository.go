@@ -0,0 +1,84 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+type AttendanceRepository struct {
+	AddAttendeeFn               func(eventId string, occurrence time.Time, userId string) (types.AttendanceStatus, error)
+	RemoveAttendeeFn            func(eventId string, occurrence time.Time, userId string) (*repository.AttendanceRemoval, error)
+	PromoteWaitlistedFn         func(eventId string) ([]string, error)
+	IsAttendingFn               func(eventId string, userId string) (bool, error)
+	GetAttendeeFn               func(eventId string, occurrence time.Time, userId string) (*models.AttendeeModel, error)
+	CheckInAttendeeFn           func(eventId string, occurrence time.Time, userId string, checkIn repository.CheckIn) (*models.AttendeeModel, error)
+	ListAttendeesFn             func(eventId string, occurrence *time.Time) ([]*models.AttendeeModel, error)
+	ListWaitlistFn              func(eventId string, occurrence *time.Time) ([]*models.WaitlistEntryModel, error)
+	ListUpcomingEventsForUserFn func(userId string) ([]*models.EventModel, error)
+}
+
+func (a AttendanceRepository) AddAttendee(eventId string, occurrence time.Time, userId string) (types.AttendanceStatus, error) {
+	if a.AddAttendeeFn != nil {
+		return a.AddAttendeeFn(eventId, occurrence, userId)
+	}
+	return types.AttendingStatus, nil
+}
+
+func (a AttendanceRepository) RemoveAttendee(eventId string, occurrence time.Time, userId string) (*repository.AttendanceRemoval, error) {
+	if a.RemoveAttendeeFn != nil {
+		return a.RemoveAttendeeFn(eventId, occurrence, userId)
+	}
+	return &repository.AttendanceRemoval{Status: types.AttendingStatus}, nil
+}
+
+func (a AttendanceRepository) PromoteWaitlisted(eventId string) ([]string, error) {
+	if a.PromoteWaitlistedFn != nil {
+		return a.PromoteWaitlistedFn(eventId)
+	}
+	return []string{}, nil
+}
+
+func (a AttendanceRepository) IsAttending(eventId string, userId string) (bool, error) {
+	if a.IsAttendingFn != nil {
+		return a.IsAttendingFn(eventId, userId)
+	}
+	return false, nil
+}
+
+func (a AttendanceRepository) GetAttendee(eventId string, occurrence time.Time, userId string) (*models.AttendeeModel, error) {
+	if a.GetAttendeeFn != nil {
+		return a.GetAttendeeFn(eventId, occurrence, userId)
+	}
+	return nil, nil
+}
+
+func (a AttendanceRepository) CheckInAttendee(eventId string, occurrence time.Time, userId string, checkIn repository.CheckIn) (*models.AttendeeModel, error) {
+	if a.CheckInAttendeeFn != nil {
+		return a.CheckInAttendeeFn(eventId, occurrence, userId, checkIn)
+	}
+	return nil, nil
+}
+
+func (a AttendanceRepository) ListAttendees(eventId string, occurrence *time.Time) ([]*models.AttendeeModel, error) {
+	if a.ListAttendeesFn != nil {
+		return a.ListAttendeesFn(eventId, occurrence)
+	}
+	return []*models.AttendeeModel{}, nil
+}
+
+func (a AttendanceRepository) ListWaitlist(eventId string, occurrence *time.Time) ([]*models.WaitlistEntryModel, error) {
+	if a.ListWaitlistFn != nil {
+		return a.ListWaitlistFn(eventId, occurrence)
+	}
+	return []*models.WaitlistEntryModel{}, nil
+}
+
+func (a AttendanceRepository) ListUpcomingEventsForUser(userId string) ([]*models.EventModel, error) {
+	if a.ListUpcomingEventsForUserFn != nil {
+		return a.ListUpcomingEventsForUserFn(userId)
+	}
+	return []*models.EventModel{}, nil
+}
@@ -0,0 +1,84 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+type EventRepository struct {
+	CreateEventFn         func(event *models.EventModel) error
+	GetEventByIDFn        func(id string) (*models.EventModel, error)
+	UpdateEventFn         func(event *models.EventModel) error
+	GetEventBySlugFn      func(slug string) (*models.EventModel, error)
+	DeleteEventFn         func(id string) error
+	ListEventsFn          func(filter repository.EventFilter) (*repository.EventPage, error)
+	ChangeEventStatusFn   func(event *models.EventModel, previous types.EventStatus) error
+	CompleteEndedEventsFn func(now time.Time) (int64, error)
+	SplitEventFn          func(event *models.EventModel, next *models.EventModel, occurrence time.Time) error
+}
+
+func (e EventRepository) CreateEvent(event *models.EventModel) error {
+	if e.CreateEventFn != nil {
+		return e.CreateEventFn(event)
+	}
+	return nil
+}
+
+func (e EventRepository) GetEventByID(id string) (*models.EventModel, error) {
+	if e.GetEventByIDFn != nil {
+		return e.GetEventByIDFn(id)
+	}
+	return nil, nil
+}
+
+func (e EventRepository) UpdateEvent(event *models.EventModel) error {
+	if e.UpdateEventFn != nil {
+		return e.UpdateEventFn(event)
+	}
+	return nil
+}
+
+func (e EventRepository) GetEventBySlug(slug string) (*models.EventModel, error) {
+	if e.GetEventBySlugFn != nil {
+		return e.GetEventBySlugFn(slug)
+	}
+	return nil, nil
+}
+
+func (e EventRepository) DeleteEvent(id string) error {
+	if e.DeleteEventFn != nil {
+		return e.DeleteEventFn(id)
+	}
+	return nil
+}
+
+func (e EventRepository) ListEvents(filter repository.EventFilter) (*repository.EventPage, error) {
+	if e.ListEventsFn != nil {
+		return e.ListEventsFn(filter)
+	}
+	return nil, nil
+}
+
+func (e EventRepository) ChangeEventStatus(event *models.EventModel, previous types.EventStatus) error {
+	if e.ChangeEventStatusFn != nil {
+		return e.ChangeEventStatusFn(event, previous)
+	}
+	return nil
+}
+
+func (e EventRepository) CompleteEndedEvents(now time.Time) (int64, error) {
+	if e.CompleteEndedEventsFn != nil {
+		return e.CompleteEndedEventsFn(now)
+	}
+	return 0, nil
+}
+
+func (e EventRepository) SplitEvent(event *models.EventModel, next *models.EventModel, occurrence time.Time) error {
+	if e.SplitEventFn != nil {
+		return e.SplitEventFn(event, next, occurrence)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+)
+
+type EventStaffRepository struct {
+	InviteStaffFn            func(staff *models.EventStaffModel) error
+	GetStaffFn               func(eventId string, userId string) (*models.EventStaffModel, error)
+	ListStaffFn              func(eventId string) ([]*models.EventStaffModel, error)
+	ListInvitationsForUserFn func(userId string) ([]*models.EventStaffModel, error)
+	ChangeStaffRoleFn        func(staff *models.EventStaffModel) error
+	AcceptInvitationFn       func(staff *models.EventStaffModel) error
+	RemoveStaffFn            func(eventId string, userId string) error
+}
+
+func (s EventStaffRepository) InviteStaff(staff *models.EventStaffModel) error {
+	if s.InviteStaffFn != nil {
+		return s.InviteStaffFn(staff)
+	}
+	return nil
+}
+
+func (s EventStaffRepository) GetStaff(eventId string, userId string) (*models.EventStaffModel, error) {
+	if s.GetStaffFn != nil {
+		return s.GetStaffFn(eventId, userId)
+	}
+	return nil, repository.ErrStaffNotFound
+}
+
+func (s EventStaffRepository) ListStaff(eventId string) ([]*models.EventStaffModel, error) {
+	if s.ListStaffFn != nil {
+		return s.ListStaffFn(eventId)
+	}
+	return []*models.EventStaffModel{}, nil
+}
+
+func (s EventStaffRepository) ListInvitationsForUser(userId string) ([]*models.EventStaffModel, error) {
+	if s.ListInvitationsForUserFn != nil {
+		return s.ListInvitationsForUserFn(userId)
+	}
+	return []*models.EventStaffModel{}, nil
+}
+
+func (s EventStaffRepository) ChangeStaffRole(staff *models.EventStaffModel) error {
+	if s.ChangeStaffRoleFn != nil {
+		return s.ChangeStaffRoleFn(staff)
+	}
+	return nil
+}
+
+func (s EventStaffRepository) AcceptInvitation(staff *models.EventStaffModel) error {
+	if s.AcceptInvitationFn != nil {
+		return s.AcceptInvitationFn(staff)
+	}
+	return nil
+}
+
+func (s EventStaffRepository) RemoveStaff(eventId string, userId string) error {
+	if s.RemoveStaffFn != nil {
+		return s.RemoveStaffFn(eventId, userId)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+type MailQueueRepository struct {
+	EnqueueMailFn   func(mail *models.MailModel) error
+	ClaimDueMailsFn func(limit int, lease time.Duration) ([]*models.MailModel, error)
+	UpdateMailFn    func(mail *models.MailModel) error
+}
+
+func (m MailQueueRepository) EnqueueMail(mail *models.MailModel) error {
+	if m.EnqueueMailFn != nil {
+		return m.EnqueueMailFn(mail)
+	}
+	return nil
+}
+
+func (m MailQueueRepository) ClaimDueMails(limit int, lease time.Duration) ([]*models.MailModel, error) {
+	if m.ClaimDueMailsFn != nil {
+		return m.ClaimDueMailsFn(limit, lease)
+	}
+	return []*models.MailModel{}, nil
+}
+
+func (m MailQueueRepository) UpdateMail(mail *models.MailModel) error {
+	if m.UpdateMailFn != nil {
+		return m.UpdateMailFn(mail)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package mock
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+type Mailer struct {
+	SendFn func(message *mail.Message) error
+}
+
+func (m Mailer) Send(message *mail.Message) error {
+	if m.SendFn != nil {
+		return m.SendFn(message)
+	}
+	return nil
+}
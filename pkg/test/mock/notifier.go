@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/service"
+)
+
+type Notifier struct {
+	NotifyFn func(user *models.UserModel, notification service.Notification) error
+}
+
+func (n Notifier) Notify(user *models.UserModel, notification service.Notification) error {
+	if n.NotifyFn != nil {
+		return n.NotifyFn(user, notification)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+type OrderRepository struct {
+	CreateOrderFn             func(order *models.OrderModel) error
+	GetOrderByIDFn            func(id string) (*models.OrderModel, error)
+	ListOrdersForUserFn       func(userId string) ([]*models.OrderModel, error)
+	ListOrdersForEventFn      func(eventId string, occurrence *time.Time) ([]*models.OrderModel, error)
+	ChangeOrderStatusFn       func(order *models.OrderModel, previous types.OrderStatus) error
+	ExpirePendingOrdersFn     func(now time.Time) (int64, error)
+	SetPaymentIntentFn        func(order *models.OrderModel) error
+	IsPaymentEventProcessedFn func(provider string, eventId string) (bool, error)
+	RecordPaymentEventFn      func(provider string, eventId string, eventType string, orderId string) error
+}
+
+func (o OrderRepository) CreateOrder(order *models.OrderModel) error {
+	if o.CreateOrderFn != nil {
+		return o.CreateOrderFn(order)
+	}
+	return nil
+}
+
+func (o OrderRepository) GetOrderByID(id string) (*models.OrderModel, error) {
+	if o.GetOrderByIDFn != nil {
+		return o.GetOrderByIDFn(id)
+	}
+	return nil, nil
+}
+
+func (o OrderRepository) ListOrdersForUser(userId string) ([]*models.OrderModel, error) {
+	if o.ListOrdersForUserFn != nil {
+		return o.ListOrdersForUserFn(userId)
+	}
+	return nil, nil
+}
+
+func (o OrderRepository) ListOrdersForEvent(eventId string, occurrence *time.Time) ([]*models.OrderModel, error) {
+	if o.ListOrdersForEventFn != nil {
+		return o.ListOrdersForEventFn(eventId, occurrence)
+	}
+	return nil, nil
+}
+
+func (o OrderRepository) ChangeOrderStatus(order *models.OrderModel, previous types.OrderStatus) error {
+	if o.ChangeOrderStatusFn != nil {
+		return o.ChangeOrderStatusFn(order, previous)
+	}
+	return nil
+}
+
+func (o OrderRepository) ExpirePendingOrders(now time.Time) (int64, error) {
+	if o.ExpirePendingOrdersFn != nil {
+		return o.ExpirePendingOrdersFn(now)
+	}
+	return 0, nil
+}
+
+func (o OrderRepository) SetPaymentIntent(order *models.OrderModel) error {
+	if o.SetPaymentIntentFn != nil {
+		return o.SetPaymentIntentFn(order)
+	}
+	return nil
+}
+
+func (o OrderRepository) IsPaymentEventProcessed(provider string, eventId string) (bool, error) {
+	if o.IsPaymentEventProcessedFn != nil {
+		return o.IsPaymentEventProcessedFn(provider, eventId)
+	}
+	return false, nil
+}
+
+func (o OrderRepository) RecordPaymentEvent(provider string, eventId string, eventType string, orderId string) error {
+	if o.RecordPaymentEventFn != nil {
+		return o.RecordPaymentEventFn(provider, eventId, eventType, orderId)
+	}
+	return nil
+}
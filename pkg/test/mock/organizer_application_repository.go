@@ -0,0 +1,50 @@
+package mock
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+type OrganizerApplicationRepository struct {
+	CreateApplicationFn           func(application *models.OrganizerApplicationModel) error
+	GetApplicationByIDFn          func(id string) (*models.OrganizerApplicationModel, error)
+	GetLatestApplicationForUserFn func(userId string) (*models.OrganizerApplicationModel, error)
+	ListApplicationsFn            func(status types.OrganizerApplicationStatus) ([]*models.OrganizerApplicationModel, error)
+	ReviewApplicationFn           func(application *models.OrganizerApplicationModel) error
+}
+
+func (a OrganizerApplicationRepository) CreateApplication(application *models.OrganizerApplicationModel) error {
+	if a.CreateApplicationFn != nil {
+		return a.CreateApplicationFn(application)
+	}
+	return nil
+}
+
+func (a OrganizerApplicationRepository) GetApplicationByID(id string) (*models.OrganizerApplicationModel, error) {
+	if a.GetApplicationByIDFn != nil {
+		return a.GetApplicationByIDFn(id)
+	}
+	return nil, repository.ErrApplicationNotFound
+}
+
+func (a OrganizerApplicationRepository) GetLatestApplicationForUser(userId string) (*models.OrganizerApplicationModel, error) {
+	if a.GetLatestApplicationForUserFn != nil {
+		return a.GetLatestApplicationForUserFn(userId)
+	}
+	return nil, repository.ErrApplicationNotFound
+}
+
+func (a OrganizerApplicationRepository) ListApplications(status types.OrganizerApplicationStatus) ([]*models.OrganizerApplicationModel, error) {
+	if a.ListApplicationsFn != nil {
+		return a.ListApplicationsFn(status)
+	}
+	return []*models.OrganizerApplicationModel{}, nil
+}
+
+func (a OrganizerApplicationRepository) ReviewApplication(application *models.OrganizerApplicationModel) error {
+	if a.ReviewApplicationFn != nil {
+		return a.ReviewApplicationFn(application)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package mock
+
+import (
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+)
+
+type SessionRepository struct {
+	CreateSessionFn      func(session *models.SessionModel) (string, error)
+	RotateRefreshTokenFn func(tokenId string, use repository.SessionUse) (*models.SessionModel, string, error)
+	ListActiveSessionsFn func(userId string) ([]*models.SessionModel, error)
+	RevokeSessionFn      func(userId string, sessionId string) error
+	RevokeUserSessionsFn func(userId string, exceptSessionId string) (int64, error)
+	IsSessionActiveFn    func(userId string, sessionId string) (bool, error)
+}
+
+func (s SessionRepository) CreateSession(session *models.SessionModel) (string, error) {
+	if s.CreateSessionFn != nil {
+		return s.CreateSessionFn(session)
+	}
+	return "", nil
+}
+
+func (s SessionRepository) RotateRefreshToken(tokenId string, use repository.SessionUse) (*models.SessionModel, string, error) {
+	if s.RotateRefreshTokenFn != nil {
+		return s.RotateRefreshTokenFn(tokenId, use)
+	}
+	return nil, "", repository.ErrSessionNotFound
+}
+
+func (s SessionRepository) ListActiveSessions(userId string) ([]*models.SessionModel, error) {
+	if s.ListActiveSessionsFn != nil {
+		return s.ListActiveSessionsFn(userId)
+	}
+	return []*models.SessionModel{}, nil
+}
+
+func (s SessionRepository) RevokeSession(userId string, sessionId string) error {
+	if s.RevokeSessionFn != nil {
+		return s.RevokeSessionFn(userId, sessionId)
+	}
+	return repository.ErrSessionNotFound
+}
+
+func (s SessionRepository) RevokeUserSessions(userId string, exceptSessionId string) (int64, error) {
+	if s.RevokeUserSessionsFn != nil {
+		return s.RevokeUserSessionsFn(userId, exceptSessionId)
+	}
+	return 0, nil
+}
+
+func (s SessionRepository) IsSessionActive(userId string, sessionId string) (bool, error) {
+	if s.IsSessionActiveFn != nil {
+		return s.IsSessionActiveFn(userId, sessionId)
+	}
+	return false, nil
+}
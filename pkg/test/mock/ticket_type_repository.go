@@ -0,0 +1,50 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+)
+
+type TicketTypeRepository struct {
+	CreateTicketTypeFn  func(ticketType *models.TicketTypeModel) error
+	GetTicketTypeByIDFn func(id string) (*models.TicketTypeModel, error)
+	ListTicketTypesFn   func(eventId string, occurrence *time.Time) ([]*models.TicketTypeModel, error)
+	UpdateTicketTypeFn  func(ticketType *models.TicketTypeModel) error
+	DeleteTicketTypeFn  func(id string) error
+}
+
+func (t TicketTypeRepository) CreateTicketType(ticketType *models.TicketTypeModel) error {
+	if t.CreateTicketTypeFn != nil {
+		return t.CreateTicketTypeFn(ticketType)
+	}
+	return nil
+}
+
+func (t TicketTypeRepository) GetTicketTypeByID(id string) (*models.TicketTypeModel, error) {
+	if t.GetTicketTypeByIDFn != nil {
+		return t.GetTicketTypeByIDFn(id)
+	}
+	return nil, nil
+}
+
+func (t TicketTypeRepository) ListTicketTypes(eventId string, occurrence *time.Time) ([]*models.TicketTypeModel, error) {
+	if t.ListTicketTypesFn != nil {
+		return t.ListTicketTypesFn(eventId, occurrence)
+	}
+	return nil, nil
+}
+
+func (t TicketTypeRepository) UpdateTicketType(ticketType *models.TicketTypeModel) error {
+	if t.UpdateTicketTypeFn != nil {
+		return t.UpdateTicketTypeFn(ticketType)
+	}
+	return nil
+}
+
+func (t TicketTypeRepository) DeleteTicketType(id string) error {
+	if t.DeleteTicketTypeFn != nil {
+		return t.DeleteTicketTypeFn(id)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/models"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/repository"
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/types"
+)
+
+type UserTokenRepository struct {
+	CreateTokenFn      func(token *models.UserTokenModel) error
+	CountTokensSinceFn func(userId string, purpose types.UserTokenPurpose, since time.Time) (int, error)
+	VerifyEmailFn      func(tokenHash string) (string, error)
+	ResetPasswordFn    func(tokenHash string, passwordHash string) (string, error)
+}
+
+func (u UserTokenRepository) CreateToken(token *models.UserTokenModel) error {
+	if u.CreateTokenFn != nil {
+		return u.CreateTokenFn(token)
+	}
+	return nil
+}
+
+func (u UserTokenRepository) CountTokensSince(userId string, purpose types.UserTokenPurpose, since time.Time) (int, error) {
+	if u.CountTokensSinceFn != nil {
+		return u.CountTokensSinceFn(userId, purpose, since)
+	}
+	return 0, nil
+}
+
+func (u UserTokenRepository) VerifyEmail(tokenHash string) (string, error) {
+	if u.VerifyEmailFn != nil {
+		return u.VerifyEmailFn(tokenHash)
+	}
+	return "", repository.ErrTokenInvalid
+}
+
+func (u UserTokenRepository) ResetPassword(tokenHash string, passwordHash string) (string, error) {
+	if u.ResetPasswordFn != nil {
+		return u.ResetPasswordFn(tokenHash, passwordHash)
+	}
+	return "", repository.ErrTokenInvalid
+}
@@ -0,0 +1,9 @@
+package types
+
+// AttendanceStatus representing a users place at an event after requesting to attend it.
+type AttendanceStatus string
+
+const (
+	AttendingStatus  AttendanceStatus = "attending"
+	WaitlistedStatus AttendanceStatus = "waitlisted"
+)
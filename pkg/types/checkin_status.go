@@ -0,0 +1,12 @@
+package types
+
+// CheckInSyncStatus representing the outcome of applying a check-in scanned by a device while offline.
+type CheckInSyncStatus string
+
+const (
+	CheckedInSyncStatus     CheckInSyncStatus = "checked_in"     // the attendee was checked in by the scan.
+	AlreadySyncedSyncStatus CheckInSyncStatus = "already_synced" // the scan was applied by an earlier sync of the same device.
+	ConflictSyncStatus      CheckInSyncStatus = "conflict"       // the attendee was already checked in by another device or at the door.
+	RevokedSyncStatus       CheckInSyncStatus = "revoked"        // the attendee is no longer attending the occurrence, such as after a refund.
+	InvalidCodeSyncStatus   CheckInSyncStatus = "invalid_code"   // the code was not signed for an attendee of the event.
+)
@@ -0,0 +1,33 @@
+package types
+
+// EventStatus representing the lifecycle state of an event, matching the event_status enum within the database.
+type EventStatus string
+
+func (status EventStatus) IsValid() bool {
+	switch status {
+	case DraftEventStatus, PublishedEventStatus, CancelledEventStatus, CompletedEventStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo returns true if an event can move from the status to the next status.
+// Drafts can only be published, and published events can only be cancelled or completed.
+func (status EventStatus) CanTransitionTo(next EventStatus) bool {
+	switch status {
+	case DraftEventStatus:
+		return next == PublishedEventStatus
+	case PublishedEventStatus:
+		return next == CancelledEventStatus || next == CompletedEventStatus
+	default:
+		return false
+	}
+}
+
+const (
+	DraftEventStatus     EventStatus = "draft"
+	PublishedEventStatus EventStatus = "published"
+	CancelledEventStatus EventStatus = "cancelled"
+	CompletedEventStatus EventStatus = "completed"
+)
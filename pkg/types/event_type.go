@@ -0,0 +1,19 @@
+package types
+
+// EventType representing how an event is attended, matching the event_type enum within the database.
+type EventType string
+
+func (eventType EventType) IsValid() bool {
+	switch eventType {
+	case OfflineEventType, OnlineEventType, BothEventType:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	OfflineEventType EventType = "offline"
+	OnlineEventType  EventType = "online"
+	BothEventType    EventType = "both"
+)
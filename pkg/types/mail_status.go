@@ -0,0 +1,10 @@
+package types
+
+// MailStatus representing the delivery state of a queued email, matching the mail_status enum within the database.
+type MailStatus string
+
+const (
+	PendingMailStatus MailStatus = "pending"
+	SentMailStatus    MailStatus = "sent"
+	FailedMailStatus  MailStatus = "failed"
+)
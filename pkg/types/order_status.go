@@ -0,0 +1,33 @@
+package types
+
+// OrderStatus representing the state of a ticket order, matching the order_status enum within the database.
+type OrderStatus string
+
+func (status OrderStatus) IsValid() bool {
+	switch status {
+	case PendingOrderStatus, PaidOrderStatus, CancelledOrderStatus, RefundedOrderStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionTo returns true if an order can move from the status to the next status.
+// Pending orders can only be paid or cancelled, and paid orders can only be refunded.
+func (status OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	switch status {
+	case PendingOrderStatus:
+		return next == PaidOrderStatus || next == CancelledOrderStatus
+	case PaidOrderStatus:
+		return next == RefundedOrderStatus
+	default:
+		return false
+	}
+}
+
+const (
+	PendingOrderStatus   OrderStatus = "pending"
+	PaidOrderStatus      OrderStatus = "paid"
+	CancelledOrderStatus OrderStatus = "cancelled"
+	RefundedOrderStatus  OrderStatus = "refunded"
+)
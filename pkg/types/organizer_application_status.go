@@ -0,0 +1,24 @@
+package types
+
+// OrganizerApplicationStatus representing the state of an application to become an organizer, matching the organizer_application_status enum within the database.
+type OrganizerApplicationStatus string
+
+func (status OrganizerApplicationStatus) IsValid() bool {
+	switch status {
+	case PendingApplicationStatus, ApprovedApplicationStatus, RejectedApplicationStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReviewed returns true if an admin has approved or rejected the application.
+func (status OrganizerApplicationStatus) IsReviewed() bool {
+	return status == ApprovedApplicationStatus || status == RejectedApplicationStatus
+}
+
+const (
+	PendingApplicationStatus  OrganizerApplicationStatus = "pending"
+	ApprovedApplicationStatus OrganizerApplicationStatus = "approved"
+	RejectedApplicationStatus OrganizerApplicationStatus = "rejected"
+)
@@ -0,0 +1,41 @@
+package types
+
+import "slices"
+
+// Permission representing an action within the system which is granted to users through their role.
+// Actions on a single event are instead governed by EventPermission, which are held by the events organizer and staff.
+type Permission string
+
+const (
+	CreateEventPermission      Permission = "events:create"     // create events, which the user then organizes.
+	ManageAnyEventPermission   Permission = "events:manage_any" // perform every action on events organized by other users.
+	CreateTagPermission        Permission = "tags:create"       // create tags to describe events.
+	ManageCategoriesPermission Permission = "categories:manage" // create, update and delete the categories of events.
+	ManageUsersPermission      Permission = "users:manage"      // create, view, update and delete the accounts of other users.
+	// ReviewOrganizerApplicationsPermission view, approve and reject the applications of users to become organizers.
+	ReviewOrganizerApplicationsPermission Permission = "organizer_applications:review"
+)
+
+// rolePermissions the permissions granted to users with each role, admins are granted every permission.
+var rolePermissions = map[Role][]Permission{
+	UserRole:      {},
+	OrganizerRole: {CreateEventPermission, CreateTagPermission},
+	AdminRole: {
+		CreateEventPermission,
+		ManageAnyEventPermission,
+		CreateTagPermission,
+		ManageCategoriesPermission,
+		ManageUsersPermission,
+		ReviewOrganizerApplicationsPermission,
+	},
+}
+
+// Permissions returns the permissions granted to users with the role.
+func (role Role) Permissions() []Permission {
+	return slices.Clone(rolePermissions[role])
+}
+
+// Grants returns true if users with the role are granted the permission.
+func (role Role) Grants(permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
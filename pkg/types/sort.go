@@ -0,0 +1,39 @@
+package types
+
+// EventSort the field which event listings can be sorted by.
+type EventSort string
+
+func (sort EventSort) IsValid() bool {
+	switch sort {
+	case SortByStartDate, SortByLikes, SortByAttendees:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultOrder returns the order used when none is requested, upcoming events first or most popular first.
+func (sort EventSort) DefaultOrder() SortOrder {
+	if sort == SortByStartDate {
+		return Ascending
+	}
+	return Descending
+}
+
+const (
+	SortByStartDate EventSort = "start_date"
+	SortByLikes     EventSort = "likes"
+	SortByAttendees EventSort = "attendees"
+)
+
+// SortOrder the direction in which a listing is sorted.
+type SortOrder string
+
+func (order SortOrder) IsValid() bool {
+	return order == Ascending || order == Descending
+}
+
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
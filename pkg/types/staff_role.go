@@ -0,0 +1,48 @@
+package types
+
+// StaffRole representing the part a user plays in running an event on behalf of its organizer, matching the event_staff_role enum within the database.
+type StaffRole string
+
+func (role StaffRole) IsValid() bool {
+	switch role {
+	case CoOrganizerStaffRole, CheckInStaffRole, ModeratorStaffRole:
+		return true
+	default:
+		return false
+	}
+}
+
+// Permits returns true if staff with the role may perform the action on their event.
+// Co-organizers may do everything except deleting the event and managing its staff, which is left to its organizer.
+func (role StaffRole) Permits(permission EventPermission) bool {
+	switch role {
+	case CoOrganizerStaffRole:
+		return permission != DeleteEventPermission && permission != ManageStaffPermission
+	case CheckInStaffRole:
+		return permission == ViewDraftPermission || permission == ViewAttendeesPermission || permission == CheckInPermission
+	case ModeratorStaffRole:
+		return permission == ViewDraftPermission || permission == ModerateReviewsPermission
+	default:
+		return false
+	}
+}
+
+const (
+	CoOrganizerStaffRole StaffRole = "co_organizer"
+	CheckInStaffRole     StaffRole = "checkin_staff"
+	ModeratorStaffRole   StaffRole = "moderator"
+)
+
+// EventPermission representing an action on an event which is limited to its organizer, its staff and admins.
+type EventPermission string
+
+const (
+	ViewDraftPermission       EventPermission = "view_draft"       // view the event and its details before it is published.
+	ManageEventPermission     EventPermission = "manage_event"     // change the event, its occurrences, taxonomy and ticket types.
+	DeleteEventPermission     EventPermission = "delete_event"     // delete the event.
+	ManageStaffPermission     EventPermission = "manage_staff"     // invite, change and remove the staff of the event.
+	ViewAttendeesPermission   EventPermission = "view_attendees"   // list the attendees and waitlist of the event.
+	ManageOrdersPermission    EventPermission = "manage_orders"    // list the orders of the event and mark them as paid or refunded.
+	CheckInPermission         EventPermission = "check_in"         // check in attendees and download the check-in roster.
+	ModerateReviewsPermission EventPermission = "moderate_reviews" // delete reviews of the event written by other users.
+)
@@ -0,0 +1,9 @@
+package types
+
+// UserTokenPurpose representing what a single-use token sent to a user may be used for, matching the user_token_purpose enum within the database.
+type UserTokenPurpose string
+
+const (
+	EmailVerificationTokenPurpose UserTokenPurpose = "email_verification"
+	PasswordResetTokenPurpose     UserTokenPurpose = "password_reset"
+)
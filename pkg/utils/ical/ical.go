@@ -0,0 +1,298 @@
+// Package ical encodes calendars of events in the iCalendar format defined by RFC 5545.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProductID identifies this application as the producer of the calendars.
+const ProductID = "-//BEOpenSourceCollabs//EventManagementCore//EN"
+
+// maxLineOctets the maximum length of a content line before it is folded, excluding the line break.
+const maxLineOctets = 75
+
+// dateTimeFormat the UTC date-time form used for date-time properties of events without a time zone.
+const dateTimeFormat = "20060102T150405Z"
+
+// localDateTimeFormat the local date-time form used for date-time properties of events with a time zone, along with a TZID parameter.
+const localDateTimeFormat = "20060102T150405"
+
+// timeZoneYears the number of years after its latest event that the definition of a time zone with recurring events covers.
+const timeZoneYears = 10
+
+// EventStatus the STATUS property of an event.
+type EventStatus string
+
+const (
+	StatusTentative EventStatus = "TENTATIVE"
+	StatusConfirmed EventStatus = "CONFIRMED"
+	StatusCancelled EventStatus = "CANCELLED"
+)
+
+// Calendar a VCALENDAR object containing events.
+type Calendar struct {
+	Name      string    // Name shown by calendar apps which support the X-WR-CALNAME extension, omitted when empty.
+	Timestamp time.Time // Timestamp the time the calendar was generated, used as the DTSTAMP of each event.
+	Events    []Event
+}
+
+// Event a VEVENT component, empty properties are omitted.
+type Event struct {
+	UID          string // UID must remain the same each time the event is exported.
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Status       EventStatus
+	Start        time.Time
+	End          time.Time
+	Created      time.Time
+	LastModified time.Time
+	// RecurrenceRule repeats the event, such as "FREQ=WEEKLY;BYDAY=MO", any UNTIL must be in UTC.
+	RecurrenceRule string
+	// ExceptionDates the original starts of occurrences which are removed from the recurrence.
+	ExceptionDates []time.Time
+	// RecurrenceID the original start of the occurrence which this event replaces, an event with the same UID must define the recurrence.
+	RecurrenceID time.Time
+	// TimeZone the start, end, exception dates and recurrence id are written as local times of the time zone, which is defined by the calendar.
+	// Recurrences then keep the same local time when daylight saving time begins or ends. The times are written in UTC when nil or UTC.
+	TimeZone *time.Location
+}
+
+// Encode writes the calendar to w.
+func (c Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	lw := lineWriter{w: bw}
+
+	lw.write("BEGIN", "VCALENDAR")
+	lw.write("VERSION", "2.0")
+	lw.write("PRODID", ProductID)
+	lw.write("CALSCALE", "GREGORIAN")
+	lw.write("METHOD", "PUBLISH")
+	if len(c.Name) > 0 {
+		lw.write("X-WR-CALNAME", escapeText(c.Name))
+	}
+	for _, zone := range c.timeZones() {
+		zone.encode(&lw)
+	}
+	for _, event := range c.Events {
+		zone := eventTimeZone(event)
+		lw.write("BEGIN", "VEVENT")
+		lw.write("UID", event.UID)
+		lw.write("DTSTAMP", formatDateTime(c.Timestamp))
+		lw.write(dateTimeName("DTSTART", zone), formatDateTimeIn(event.Start, zone))
+		lw.write(dateTimeName("DTEND", zone), formatDateTimeIn(event.End, zone))
+		if !event.RecurrenceID.IsZero() {
+			lw.write(dateTimeName("RECURRENCE-ID", zone), formatDateTimeIn(event.RecurrenceID, zone))
+		}
+		if len(event.RecurrenceRule) > 0 {
+			lw.write("RRULE", event.RecurrenceRule)
+		}
+		if len(event.ExceptionDates) > 0 {
+			dates := make([]string, len(event.ExceptionDates))
+			for i, date := range event.ExceptionDates {
+				dates[i] = formatDateTimeIn(date, zone)
+			}
+			lw.write(dateTimeName("EXDATE", zone), strings.Join(dates, ","))
+		}
+		lw.write("SUMMARY", escapeText(event.Summary))
+		if len(event.Description) > 0 {
+			lw.write("DESCRIPTION", escapeText(event.Description))
+		}
+		if len(event.Location) > 0 {
+			lw.write("LOCATION", escapeText(event.Location))
+		}
+		if len(event.URL) > 0 {
+			lw.write("URL", event.URL)
+		}
+		if len(event.Status) > 0 {
+			lw.write("STATUS", string(event.Status))
+		}
+		if !event.Created.IsZero() {
+			lw.write("CREATED", formatDateTime(event.Created))
+		}
+		if !event.LastModified.IsZero() {
+			lw.write("LAST-MODIFIED", formatDateTime(event.LastModified))
+		}
+		lw.write("END", "VEVENT")
+	}
+	lw.write("END", "VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+// String returns the encoded calendar.
+func (c Calendar) String() string {
+	var sb strings.Builder
+	c.Encode(&sb)
+	return sb.String()
+}
+
+// timeZone a VTIMEZONE component, defining the offsets of a time zone used by events of the calendar between two years.
+type timeZone struct {
+	location *time.Location
+	from     int
+	to       int
+}
+
+// timeZones returns the time zones used by the events of the calendar, in the order they are first used.
+// Each covers the years of its events, along with the following years when any of them recur.
+func (c Calendar) timeZones() []*timeZone {
+	zones := []*timeZone{}
+	byName := map[string]*timeZone{}
+	for _, event := range c.Events {
+		location := eventTimeZone(event)
+		if location == nil {
+			continue
+		}
+		from, to := event.Start.In(location).Year(), event.End.In(location).Year()
+		if len(event.RecurrenceRule) > 0 {
+			to += timeZoneYears
+		}
+
+		zone, ok := byName[location.String()]
+		if !ok {
+			zone = &timeZone{location: location, from: from, to: to}
+			byName[location.String()] = zone
+			zones = append(zones, zone)
+		}
+		zone.from = min(zone.from, from)
+		zone.to = max(zone.to, to)
+	}
+	return zones
+}
+
+// encode writes the time zone as the offset in effect at the start of its first year, followed by each transition until the end of its last year.
+// Calendar apps which know the time zone by its TZID may use their own definition instead.
+func (z *timeZone) encode(lw *lineWriter) {
+	lw.write("BEGIN", "VTIMEZONE")
+	lw.write("TZID", z.location.String())
+	start := time.Date(z.from, time.January, 1, 0, 0, 0, 0, z.location)
+	writeObservance(lw, start, start)
+	for onset := start; ; {
+		_, next := onset.ZoneBounds()
+		if next.IsZero() || next.Year() > z.to {
+			break
+		}
+		writeObservance(lw, next, onset)
+		onset = next
+	}
+	lw.write("END", "VTIMEZONE")
+}
+
+// writeObservance writes the STANDARD or DAYLIGHT observance which begins at onset, changing the offset from the one in effect at the previous time.
+func writeObservance(lw *lineWriter, onset time.Time, previous time.Time) {
+	name, offset := onset.Zone()
+	_, previousOffset := previous.Zone()
+
+	kind := "STANDARD"
+	if onset.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	lw.write("BEGIN", kind)
+	// the onset is written as the local time before the observance begins.
+	lw.write("DTSTART", onset.In(time.FixedZone("", previousOffset)).Format(localDateTimeFormat))
+	lw.write("TZOFFSETFROM", formatOffset(previousOffset))
+	lw.write("TZOFFSETTO", formatOffset(offset))
+	lw.write("TZNAME", escapeText(name))
+	lw.write("END", kind)
+}
+
+// lineWriter writes content lines, keeping the first error encountered.
+type lineWriter struct {
+	w   io.StringWriter
+	err error
+}
+
+// write writes the content line 'name:value', folding it onto continuation lines when it is too long.
+func (lw *lineWriter) write(name string, value string) {
+	if lw.err != nil {
+		return
+	}
+	_, lw.err = lw.w.WriteString(foldLine(name+":"+value) + "\r\n")
+}
+
+// foldLine splits a content line longer than 75 octets into multiple lines, each continuation line starting with a space.
+// Lines are never split within a multi-byte UTF-8 character.
+func foldLine(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var sb strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts towards their length.
+		limit = maxLineOctets - 1
+	}
+	sb.WriteString(line)
+	return sb.String()
+}
+
+// escapeText escapes a TEXT property value.
+var escapeText = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+).Replace
+
+// formatDateTime formats the time as a UTC date-time.
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+// formatDateTimeIn formats the time as a local date-time of the time zone, or as a UTC date-time when the time zone is nil.
+func formatDateTimeIn(t time.Time, zone *time.Location) string {
+	if zone == nil {
+		return formatDateTime(t)
+	}
+	return t.In(zone).Format(localDateTimeFormat)
+}
+
+// dateTimeName returns the name of a date-time property, along with the TZID parameter of the time zone its values are local times of.
+func dateTimeName(name string, zone *time.Location) string {
+	if zone == nil {
+		return name
+	}
+	return name + ";TZID=" + zone.String()
+}
+
+// eventTimeZone returns the time zone of the events local times, which is nil when its times are written in UTC.
+func eventTimeZone(event Event) *time.Location {
+	if event.TimeZone == nil || event.TimeZone == time.UTC {
+		return nil
+	}
+	return event.TimeZone
+}
+
+// formatOffset formats an offset from UTC in seconds as a UTC-OFFSET value, such as "+0100".
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
@@ -0,0 +1,206 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/ical"
+)
+
+func TestCalendar_Encode(t *testing.T) {
+	brussels, err := time.LoadLocation("Europe/Brussels")
+	if err != nil {
+		t.Skip("time zone database is unavailable")
+	}
+
+	calendar := ical.Calendar{
+		Name:      "My events",
+		Timestamp: time.Date(2024, time.June, 1, 9, 30, 0, 0, time.UTC),
+		Events: []ical.Event{
+			{
+				UID:         "4f6c0c8e-7d3a-4b8e-9a57-3c1f0b1f2e10@event-management-core",
+				Summary:     "Tomorrowland, day one; main stage",
+				Description: "Line one\nLine two",
+				Location:    "Boom, BE",
+				Status:      ical.StatusConfirmed,
+				Start:       time.Date(2024, time.July, 19, 12, 0, 0, 0, brussels),
+				End:         time.Date(2024, time.July, 20, 1, 0, 0, 0, brussels),
+			},
+		},
+	}
+
+	encoded := calendar.String()
+
+	expectedLines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + ical.ProductID,
+		"X-WR-CALNAME:My events",
+		"BEGIN:VEVENT",
+		"UID:4f6c0c8e-7d3a-4b8e-9a57-3c1f0b1f2e10@event-management-core",
+		"DTSTAMP:20240601T093000Z",
+		"DTSTART:20240719T100000Z",
+		"DTEND:20240719T230000Z",
+		`SUMMARY:Tomorrowland\, day one\; main stage`,
+		`DESCRIPTION:Line one\nLine two`,
+		`LOCATION:Boom\, BE`,
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(encoded, line+"\r\n") {
+			t.Errorf("expected calendar to contain line %q", line)
+		}
+	}
+	if strings.Contains(encoded, "CREATED:") {
+		t.Error("expected empty created time to be omitted")
+	}
+	if strings.Count(encoded, "\n") != strings.Count(encoded, "\r\n") {
+		t.Error("expected all lines to end with CRLF")
+	}
+}
+
+func TestCalendar_Folding(t *testing.T) {
+	calendar := ical.Calendar{
+		Events: []ical.Event{
+			{
+				UID:         "folding",
+				Summary:     "Folding",
+				Description: strings.Repeat("é", 100),
+			},
+		},
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(calendar.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines to be folded to at most 75 octets but found %d: %q", len(line), line)
+		}
+		if !strings.HasPrefix(line, " ") && !strings.Contains(line, ":") {
+			t.Errorf("expected continuation lines to start with a space: %q", line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("expected lines to not split characters: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(calendar.String(), "\r\n ", "")
+	if !strings.Contains(unfolded, "DESCRIPTION:"+strings.Repeat("é", 100)+"\r\n") {
+		t.Error("expected unfolding to restore the description")
+	}
+}
+
+func TestCalendar_Recurrence(t *testing.T) {
+	start := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	calendar := ical.Calendar{
+		Events: []ical.Event{
+			{
+				UID:            "series",
+				Summary:        "Weekly meetup",
+				Start:          start,
+				End:            start.Add(2 * time.Hour),
+				RecurrenceRule: "FREQ=WEEKLY;COUNT=4",
+				ExceptionDates: []time.Time{start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)},
+			},
+			{
+				UID:          "series",
+				Summary:      "Weekly meetup",
+				Start:        start.AddDate(0, 0, 22),
+				End:          start.AddDate(0, 0, 22).Add(2 * time.Hour),
+				RecurrenceID: start.AddDate(0, 0, 21),
+			},
+		},
+	}
+
+	encoded := calendar.String()
+
+	expectedLines := []string{
+		"RRULE:FREQ=WEEKLY;COUNT=4",
+		"EXDATE:20240610T180000Z,20240617T180000Z",
+		"RECURRENCE-ID:20240624T180000Z",
+		"DTSTART:20240625T180000Z",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(encoded, line+"\r\n") {
+			t.Errorf("expected calendar to contain line %q", line)
+		}
+	}
+	if strings.Count(encoded, "RRULE:") != 1 {
+		t.Error("expected only the series to have a recurrence rule")
+	}
+}
+
+func TestCalendar_TimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is unavailable")
+	}
+
+	// a weekly meetup at 19:00 in Berlin, daylight saving time begins on the 31st of March between its second and third occurrence.
+	start := time.Date(2024, time.March, 19, 18, 0, 0, 0, time.UTC)
+	calendar := ical.Calendar{
+		Events: []ical.Event{
+			{
+				UID:            "series",
+				Summary:        "Weekly meetup",
+				Start:          start,
+				End:            start.Add(2 * time.Hour),
+				RecurrenceRule: "FREQ=WEEKLY;COUNT=4",
+				ExceptionDates: []time.Time{time.Date(2024, time.April, 2, 17, 0, 0, 0, time.UTC)},
+				TimeZone:       berlin,
+			},
+			{
+				UID:          "series",
+				Summary:      "Weekly meetup",
+				Start:        time.Date(2024, time.April, 10, 17, 0, 0, 0, time.UTC),
+				End:          time.Date(2024, time.April, 10, 19, 0, 0, 0, time.UTC),
+				RecurrenceID: time.Date(2024, time.April, 9, 17, 0, 0, 0, time.UTC),
+				TimeZone:     berlin,
+			},
+		},
+	}
+
+	encoded := calendar.String()
+
+	expectedLines := []string{
+		"DTSTART;TZID=Europe/Berlin:20240319T190000",
+		"DTEND;TZID=Europe/Berlin:20240319T210000",
+		"EXDATE;TZID=Europe/Berlin:20240402T190000",
+		"RECURRENCE-ID;TZID=Europe/Berlin:20240409T190000",
+		"DTSTART;TZID=Europe/Berlin:20240410T190000",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(encoded, line+"\r\n") {
+			t.Errorf("expected calendar to contain line %q", line)
+		}
+	}
+
+	expectedTimeZone := strings.Join([]string{
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240331T020000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0200",
+		"TZNAME:CEST",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20241027T030000",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"TZNAME:CET",
+		"END:STANDARD",
+	}, "\r\n")
+	if !strings.Contains(encoded, expectedTimeZone) {
+		t.Error("expected calendar to define the daylight saving time transitions of the time zone")
+	}
+	if strings.Count(encoded, "BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n") != 1 {
+		t.Error("expected the time zone to be defined once")
+	}
+	if strings.Index(encoded, "BEGIN:VTIMEZONE") > strings.Index(encoded, "BEGIN:VEVENT") {
+		t.Error("expected the time zone to be defined before the events")
+	}
+	if strings.Contains(encoded, "TZID=Europe/Berlin:20240402T180000") {
+		t.Error("expected occurrences after daylight saving time begins to keep their local time")
+	}
+}
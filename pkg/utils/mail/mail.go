@@ -0,0 +1,95 @@
+// Package mail composes transactional emails from templates and encodes them in the Internet Message Format defined by RFC 5322.
+package mail
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message an email with a plain text body and an alternative html body, empty bodies are omitted.
+type Message struct {
+	From      string // From the sender, such as "Events <no-reply@example.com>".
+	To        string
+	Subject   string
+	Text      string
+	HTML      string
+	Date      time.Time
+	MessageID string // MessageID uniquely identifies the message, such as "<id@example.com>", omitted when empty.
+}
+
+// Encode writes the message to w, with CRLF line breaks as expected by SMTP servers.
+func (m Message) Encode(w io.Writer) error {
+	for _, header := range []string{m.From, m.To, m.Subject, m.MessageID} {
+		if strings.ContainsAny(header, "\r\n") {
+			return ErrInvalidHeader
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	mw := multipart.NewWriter(bw)
+
+	headers := [][2]string{
+		{"From", m.From},
+		{"To", m.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", m.Date.Format(time.RFC1123Z)},
+		{"Message-ID", m.MessageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	}
+	for _, header := range headers {
+		if len(header[1]) > 0 {
+			fmt.Fprintf(bw, "%s: %s\r\n", header[0], header[1])
+		}
+	}
+	bw.WriteString("\r\n")
+
+	parts := [][2]string{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	}
+	for _, part := range parts {
+		if len(part[1]) < 1 {
+			continue
+		}
+		if err := writePart(mw, part[0], part[1]); err != nil {
+			return err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// writePart writes a quoted-printable encoded part with the content type.
+func writePart(mw *multipart.Writer, contentType string, body string) error {
+	pw, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	qw := quotedprintable.NewWriter(pw)
+	if _, err := qw.Write([]byte(body)); err != nil {
+		return err
+	}
+
+	return qw.Close()
+}
+
+var (
+	ErrInvalidHeader = errors.New("mail header must not contain line breaks") // ErrInvalidHeader is returned when encoding a message with a header which could inject further headers.
+)
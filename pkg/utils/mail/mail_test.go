@@ -0,0 +1,95 @@
+package mail_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+func TestMessage_Encode(t *testing.T) {
+	message := mail.Message{
+		From:      "Events <no-reply@example.com>",
+		To:        "user@example.com",
+		Subject:   "Verify your émail address",
+		Text:      "Follow the link below.\n\nhttps://example.com/api/auth/verify?token=abc",
+		HTML:      "<p>Follow the link below.</p>",
+		Date:      time.Date(2024, time.June, 1, 18, 30, 0, 0, time.UTC),
+		MessageID: "<1@example.com>",
+	}
+
+	var buf bytes.Buffer
+	if err := message.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := netmail.ReadMessage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != message.Subject {
+		t.Errorf("expected subject %q but was %q", message.Subject, subject)
+	}
+	if to := parsed.Header.Get("To"); to != message.To {
+		t.Errorf("expected recipient %q but was %q", message.To, to)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative but was %s", mediaType)
+	}
+
+	bodies := map[string]string{}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[contentType] = strings.ReplaceAll(string(body), "\r\n", "\n")
+	}
+
+	if bodies["text/plain"] != message.Text {
+		t.Errorf("expected text body %q but was %q", message.Text, bodies["text/plain"])
+	}
+	if bodies["text/html"] != message.HTML {
+		t.Errorf("expected html body %q but was %q", message.HTML, bodies["text/html"])
+	}
+}
+
+func TestMessage_EncodeRejectsHeaderInjection(t *testing.T) {
+	message := mail.Message{
+		From:    "no-reply@example.com",
+		To:      "user@example.com\r\nBcc: other@example.com",
+		Subject: "Hello",
+		Text:    "Hello",
+	}
+
+	if err := message.Encode(io.Discard); !errors.Is(err, mail.ErrInvalidHeader) {
+		t.Errorf("expected error %v but got %v", mail.ErrInvalidHeader, err)
+	}
+}
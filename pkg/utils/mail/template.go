@@ -0,0 +1,84 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Names of the templates of transactional messages.
+const (
+	VerifyEmailTemplate                  = "verify_email"
+	ResetPasswordTemplate                = "reset_password"
+	PasswordChangedTemplate              = "password_changed"
+	OrganizerApplicationApprovedTemplate = "organizer_application_approved"
+	OrganizerApplicationRejectedTemplate = "organizer_application_rejected"
+)
+
+// templateFS contains a pair of templates for each message, "<name>.txt" defining its "subject" and plain text body and "<name>.html" defining its html "content".
+// The html content of every message is rendered within "layout.html".
+//
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	textTemplates = map[string]*texttemplate.Template{}
+	htmlTemplates = map[string]*htmltemplate.Template{}
+)
+
+func init() {
+	names, err := templateFS.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, entry := range names {
+		name, ok := strings.CutSuffix(entry.Name(), ".txt")
+		if !ok {
+			continue
+		}
+		textTemplates[name] = texttemplate.Must(texttemplate.New(entry.Name()).Option("missingkey=error").ParseFS(templateFS, path.Join("templates", entry.Name())))
+		htmlTemplates[name] = htmltemplate.Must(htmltemplate.New("layout.html").Option("missingkey=error").ParseFS(templateFS, "templates/layout.html", path.Join("templates", name+".html")))
+	}
+}
+
+// Content the subject and bodies of a rendered message.
+type Content struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Render renders the message template with the name, values within the data are escaped within the html body.
+func Render(name string, data any) (*Content, error) {
+	textTemplate, ok := textTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := textTemplate.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := htmlTemplates[name].Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &Content{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+var (
+	ErrUnknownTemplate = errors.New("unknown mail template") // ErrUnknownTemplate is returned when rendering a template which does not exist.
+)
@@ -0,0 +1,65 @@
+package mail_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/mail"
+)
+
+type templateUser struct {
+	Username string
+}
+
+func TestRender(t *testing.T) {
+	user := templateUser{Username: "<b>johndoe</b>"}
+
+	testcases := []struct {
+		name string
+		data map[string]any
+	}{
+		{name: mail.VerifyEmailTemplate, data: map[string]any{"User": user, "Link": "https://example.com/verify?token=a&b", "ExpiresIn": "24h0m0s"}},
+		{name: mail.ResetPasswordTemplate, data: map[string]any{"User": user, "Link": "https://example.com/reset?token=a&b", "ExpiresIn": "1h0m0s"}},
+		{name: mail.PasswordChangedTemplate, data: map[string]any{"User": user}},
+		{name: mail.OrganizerApplicationApprovedTemplate, data: map[string]any{"User": user, "Comment": ""}},
+		{name: mail.OrganizerApplicationRejectedTemplate, data: map[string]any{"User": user, "Comment": "Please link to a previous event."}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := mail.Render(tc.name, tc.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(content.Subject) < 1 || strings.Contains(content.Subject, "\n") {
+				t.Errorf("expected a single line subject but was %q", content.Subject)
+			}
+			if !strings.HasPrefix(content.Text, "Hi <b>johndoe</b>,\n") {
+				t.Errorf("expected text body to greet the user but was %q", content.Text)
+			}
+			if strings.Contains(content.HTML, "<b>johndoe</b>") || !strings.Contains(content.HTML, "&lt;b&gt;johndoe&lt;/b&gt;") {
+				t.Errorf("expected html body to escape the username but was %q", content.HTML)
+			}
+			if link, ok := tc.data["Link"].(string); ok && !strings.Contains(content.Text, link) {
+				t.Errorf("expected text body to contain the link %q but was %q", link, content.Text)
+			}
+			if comment, ok := tc.data["Comment"].(string); ok && len(comment) > 0 && !strings.Contains(content.Text, comment) {
+				t.Errorf("expected text body to contain the comment %q but was %q", comment, content.Text)
+			}
+		})
+	}
+
+	t.Run("missing data", func(t *testing.T) {
+		if _, err := mail.Render(mail.VerifyEmailTemplate, map[string]any{"User": user}); err == nil {
+			t.Error("expected an error rendering a template without its data")
+		}
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		if _, err := mail.Render("unknown", nil); !errors.Is(err, mail.ErrUnknownTemplate) {
+			t.Errorf("expected error %v but got %v", mail.ErrUnknownTemplate, err)
+		}
+	})
+}
@@ -8,10 +8,18 @@ import (
 )
 
 type ServerResponse struct {
-	Success  bool        `json:"success"`
-	Code     string      `json:"code,omitempty"`
-	Messages interface{} `json:"messages,omitempty"`
-	Data     interface{} `json:"data,omitempty"`
+	Success    bool        `json:"success"`
+	Code       string      `json:"code,omitempty"`
+	Messages   interface{} `json:"messages,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination metadata describing the position of a page of results within a cursor paginated listing.
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func WriteErrorJsonResponse(w http.ResponseWriter, errorCode string, statusCode int, messages interface{}) {
@@ -39,6 +47,19 @@ func WriteSuccessJsonResponse(w http.ResponseWriter, statusCode int, data interf
 	json.NewEncoder(w).Encode(res)
 }
 
+func WritePaginatedJsonResponse(w http.ResponseWriter, statusCode int, data interface{}, pagination Pagination) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	res := &ServerResponse{
+		Success:    true,
+		Data:       data,
+		Pagination: &pagination,
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
+
 func WriteInternalErrorJsonResponse(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,373 @@
+// Package rrule parses and expands the subset of RFC 5545 recurrence rules supported for recurring events:
+// DAILY, WEEKLY and MONTHLY frequencies with INTERVAL, BYDAY, COUNT and UNTIL.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency how often a rule repeats.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+const (
+	MaxCount    = 1000 // MaxCount the largest COUNT accepted by Parse.
+	MaxInterval = 1000 // MaxInterval the largest INTERVAL accepted by Parse.
+	// maxPeriods bounds the number of periods examined when expanding a rule, protecting against rules which rarely match.
+	maxPeriods = 100000
+)
+
+// untilFormat the UTC date-time form of UNTIL, the date form untilDateFormat is also accepted.
+const (
+	untilFormat     = "20060102T150405Z"
+	untilDateFormat = "20060102"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum a BYDAY value, such as MO for every monday or -1FR for the last friday of a month.
+type WeekdayNum struct {
+	N   int // N the ordinal of the weekday within the month, zero for every occurrence of the weekday.
+	Day time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	day := strings.ToUpper(w.Day.String()[:2])
+	if w.N == 0 {
+		return day
+	}
+	return strconv.Itoa(w.N) + day
+}
+
+// Rule a parsed recurrence rule.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	Count    int       // Count the total number of occurrences including the first, zero when unbounded by count.
+	Until    time.Time // Until the latest time an occurrence can start, zero when unbounded by time.
+}
+
+// Parse parses a recurrence rule such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10", an optional "RRULE:" prefix is ignored.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if len(s) < 1 {
+		return nil, ErrMissingFrequency
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || len(value) < 1 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s is repeated", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				return nil, fmt.Errorf("%w: FREQ must be one of DAILY, WEEKLY or MONTHLY", ErrInvalidRule)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > MaxInterval {
+				return nil, fmt.Errorf("%w: INTERVAL must be between 1 and %d", ErrInvalidRule, MaxInterval)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > MaxCount {
+				return nil, fmt.Errorf("%w: COUNT must be between 1 and %d", ErrInvalidRule, MaxCount)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL must be a UTC date-time such as 20240605T120000Z", ErrInvalidRule)
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekdayNum, err := parseWeekdayNum(strings.ToUpper(day))
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekdayNum)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s is not supported", ErrInvalidRule, name)
+		}
+	}
+
+	if len(rule.Freq) < 1 {
+		return nil, ErrMissingFrequency
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL can not both be set", ErrInvalidRule)
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, fmt.Errorf("%w: BYDAY ordinals are only supported for MONTHLY rules", ErrInvalidRule)
+		}
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(untilFormat, value); err == nil {
+		return until, nil
+	}
+	// a date includes occurrences starting at any time during that day.
+	date, err := time.Parse(untilDateFormat, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.Add(24*time.Hour - time.Second), nil
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("%w: BYDAY value %q", ErrInvalidRule, value)
+	}
+	day, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("%w: BYDAY value %q", ErrInvalidRule, value)
+	}
+	weekdayNum := WeekdayNum{Day: day}
+	if ordinal := value[:len(value)-2]; len(ordinal) > 0 {
+		n, err := strconv.Atoi(ordinal)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("%w: BYDAY ordinal must be between -5 and 5 in %q", ErrInvalidRule, value)
+		}
+		weekdayNum.N = n
+	}
+	return weekdayNum, nil
+}
+
+// String formats the rule, such that parsing the result returns an equal rule.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilFormat))
+	}
+	return strings.Join(parts, ";")
+}
+
+// IsBounded returns true if the rule has a last occurrence.
+func (r *Rule) IsBounded() bool {
+	return r.Count > 0 || !r.Until.IsZero()
+}
+
+// Between returns the start of each occurrence starting at or after from and before to, in order.
+// The first occurrence is always dtstart, later occurrences keep the wall clock time of dtstart within its location.
+func (r *Rule) Between(dtstart time.Time, from time.Time, to time.Time) []time.Time {
+	occurrences := []time.Time{}
+	r.iterate(dtstart, func(occurrence time.Time) bool {
+		if !occurrence.Before(to) {
+			return false
+		}
+		if !occurrence.Before(from) {
+			occurrences = append(occurrences, occurrence)
+		}
+		return true
+	})
+	return occurrences
+}
+
+// Last returns the start of the last occurrence, and false if the rule is unbounded.
+func (r *Rule) Last(dtstart time.Time) (time.Time, bool) {
+	if !r.IsBounded() {
+		return time.Time{}, false
+	}
+	last := dtstart
+	r.iterate(dtstart, func(occurrence time.Time) bool {
+		last = occurrence
+		return true
+	})
+	return last, true
+}
+
+// Includes returns true if an occurrence of the rule starts at the provided time.
+func (r *Rule) Includes(dtstart time.Time, occurrence time.Time) bool {
+	found := false
+	r.iterate(dtstart, func(candidate time.Time) bool {
+		if candidate.Equal(occurrence) {
+			found = true
+		}
+		return candidate.Before(occurrence)
+	})
+	return found
+}
+
+// Truncated returns a copy of the rule which ends before the provided occurrence, used when splitting a series.
+func (r *Rule) Truncated(before time.Time) *Rule {
+	truncated := *r
+	truncated.Count = 0
+	truncated.Until = before.Add(-time.Second).UTC()
+	return &truncated
+}
+
+// iterate calls yield with each occurrence in order, until yield returns false or the rule ends.
+func (r *Rule) iterate(dtstart time.Time, yield func(time.Time) bool) {
+	count := 0
+	emit := func(occurrence time.Time) bool {
+		if !r.Until.IsZero() && occurrence.After(r.Until) {
+			return false
+		}
+		count++
+		if !yield(occurrence) {
+			return false
+		}
+		return r.Count < 1 || count < r.Count
+	}
+
+	// the first occurrence is always the start of the series.
+	if !emit(dtstart) {
+		return
+	}
+
+	for period := 0; period < maxPeriods; period++ {
+		for _, candidate := range r.candidates(dtstart, period) {
+			if !candidate.After(dtstart) {
+				continue
+			}
+			if !emit(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// candidates returns the occurrences within the nth period after the period containing dtstart, in order.
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	year, month, day := dtstart.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+	}
+
+	switch r.Freq {
+	case Daily:
+		candidate := at(year, month, day+period*r.Interval)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(candidate.Weekday()) {
+			return nil
+		}
+		return []time.Time{candidate}
+	case Weekly:
+		// weeks start on monday.
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		weekStart := day - offset + period*r.Interval*7
+		if len(r.ByDay) < 1 {
+			return []time.Time{at(year, month, weekStart+offset)}
+		}
+		candidates := []time.Time{}
+		for i := 0; i < 7; i++ {
+			candidate := at(year, month, weekStart+i)
+			if r.matchesWeekday(candidate.Weekday()) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+	case Monthly:
+		first := time.Date(year, month+time.Month(period*r.Interval), 1, 0, 0, 0, 0, dtstart.Location())
+		if len(r.ByDay) < 1 {
+			// months without the day of the month of dtstart are skipped.
+			candidate := at(first.Year(), first.Month(), day)
+			if candidate.Month() != first.Month() {
+				return nil
+			}
+			return []time.Time{candidate}
+		}
+		return r.monthlyByDay(first, at)
+	default:
+		return nil
+	}
+}
+
+// monthlyByDay returns the days of the month matching the rules BYDAY values, in order.
+func (r *Rule) monthlyByDay(first time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	daysInMonth := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, first.Location()).Day()
+
+	matches := map[int]bool{}
+	for _, byDay := range r.ByDay {
+		days := []int{}
+		for d := 1; d <= daysInMonth; d++ {
+			if time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, first.Location()).Weekday() == byDay.Day {
+				days = append(days, d)
+			}
+		}
+		switch {
+		case byDay.N == 0:
+			for _, d := range days {
+				matches[d] = true
+			}
+		case byDay.N > 0 && byDay.N <= len(days):
+			matches[days[byDay.N-1]] = true
+		case byDay.N < 0 && -byDay.N <= len(days):
+			matches[days[len(days)+byDay.N]] = true
+		}
+	}
+
+	days := make([]int, 0, len(matches))
+	for d := range matches {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+
+	candidates := make([]time.Time, len(days))
+	for i, d := range days {
+		candidates[i] = at(first.Year(), first.Month(), d)
+	}
+	return candidates
+}
+
+func (r *Rule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	ErrInvalidRule      = errors.New("invalid recurrence rule")                  // ErrInvalidRule is returned when a recurrence rule can not be parsed.
+	ErrMissingFrequency = errors.New("recurrence rule must contain a FREQ part") // ErrMissingFrequency is returned when a recurrence rule has no frequency.
+)
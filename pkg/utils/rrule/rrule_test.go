@@ -0,0 +1,213 @@
+package rrule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils/rrule"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		err      bool
+	}{
+		{name: "daily", input: "FREQ=DAILY", expected: "FREQ=DAILY"},
+		{name: "prefix and lower case", input: "RRULE:freq=weekly;byday=mo,we", expected: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{name: "interval and count", input: "FREQ=WEEKLY;INTERVAL=2;COUNT=10", expected: "FREQ=WEEKLY;INTERVAL=2;COUNT=10"},
+		{name: "until", input: "FREQ=DAILY;UNTIL=20240630T235959Z", expected: "FREQ=DAILY;UNTIL=20240630T235959Z"},
+		{name: "until date", input: "FREQ=DAILY;UNTIL=20240630", expected: "FREQ=DAILY;UNTIL=20240630T235959Z"},
+		{name: "monthly ordinal", input: "FREQ=MONTHLY;BYDAY=-1FR,2MO", expected: "FREQ=MONTHLY;BYDAY=-1FR,2MO"},
+		{name: "empty", input: "", err: true},
+		{name: "missing frequency", input: "COUNT=2", err: true},
+		{name: "yearly", input: "FREQ=YEARLY", err: true},
+		{name: "count and until", input: "FREQ=DAILY;COUNT=2;UNTIL=20240630", err: true},
+		{name: "zero interval", input: "FREQ=DAILY;INTERVAL=0", err: true},
+		{name: "count too large", input: "FREQ=DAILY;COUNT=1001", err: true},
+		{name: "weekly ordinal", input: "FREQ=WEEKLY;BYDAY=1MO", err: true},
+		{name: "invalid day", input: "FREQ=WEEKLY;BYDAY=XX", err: true},
+		{name: "unsupported part", input: "FREQ=MONTHLY;BYMONTHDAY=1", err: true},
+		{name: "repeated part", input: "FREQ=DAILY;FREQ=WEEKLY", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := rrule.Parse(tc.input)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error parsing %q", tc.input)
+				}
+				if !errors.Is(err, rrule.ErrInvalidRule) && !errors.Is(err, rrule.ErrMissingFrequency) {
+					t.Errorf("unexpected error type %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q but got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	// monday 3rd of june 2024.
+	dtstart := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 18, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		rule     string
+		from     time.Time
+		to       time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "daily count",
+			rule:     "FREQ=DAILY;COUNT=3",
+			from:     dtstart,
+			to:       day(time.July, 1),
+			expected: []time.Time{day(time.June, 3), day(time.June, 4), day(time.June, 5)},
+		},
+		{
+			name:     "daily weekdays within window",
+			rule:     "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			from:     day(time.June, 7),
+			to:       day(time.June, 11),
+			expected: []time.Time{day(time.June, 7), day(time.June, 10)},
+		},
+		{
+			name:     "weekly by day",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+			from:     dtstart,
+			to:       day(time.July, 1),
+			expected: []time.Time{day(time.June, 3), day(time.June, 5), day(time.June, 10), day(time.June, 12)},
+		},
+		{
+			name:     "fortnightly until",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=20240701T180000Z",
+			from:     dtstart,
+			to:       day(time.August, 1),
+			expected: []time.Time{day(time.June, 3), day(time.June, 17), day(time.July, 1)},
+		},
+		{
+			name:     "monthly day of month skips short months",
+			rule:     "FREQ=MONTHLY;COUNT=3",
+			from:     time.Time{},
+			to:       time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{day(time.June, 3), day(time.July, 3), day(time.August, 3)},
+		},
+		{
+			name:     "monthly last friday",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			from:     dtstart,
+			to:       time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{day(time.June, 3), day(time.June, 28), day(time.July, 26)},
+		},
+		{
+			name:     "unbounded stops at window",
+			rule:     "FREQ=WEEKLY",
+			from:     day(time.June, 10),
+			to:       day(time.June, 24),
+			expected: []time.Time{day(time.June, 10), day(time.June, 17)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := rrule.Parse(tc.rule)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			occurrences := rule.Between(dtstart, tc.from, tc.to)
+			if len(occurrences) != len(tc.expected) {
+				t.Fatalf("expected %d occurrences but got %d: %v", len(tc.expected), len(occurrences), occurrences)
+			}
+			for i, occurrence := range occurrences {
+				if !occurrence.Equal(tc.expected[i]) {
+					t.Errorf("expected occurrence %d to be %v but got %v", i, tc.expected[i], occurrence)
+				}
+			}
+		})
+	}
+}
+
+func TestRule_KeepsWallClockTime(t *testing.T) {
+	brussels, err := time.LoadLocation("Europe/Brussels")
+	if err != nil {
+		t.Skip("time zone database is unavailable")
+	}
+
+	// daylight saving time starts on the 31st of march 2024 in brussels.
+	dtstart := time.Date(2024, time.March, 30, 10, 0, 0, 0, brussels)
+	rule, _ := rrule.Parse("FREQ=DAILY;COUNT=2")
+
+	occurrences := rule.Between(dtstart, dtstart, dtstart.AddDate(0, 0, 7))
+	if len(occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences but got %d", len(occurrences))
+	}
+	if occurrences[1].Hour() != 10 {
+		t.Errorf("expected second occurrence at 10:00 local time but got %v", occurrences[1])
+	}
+	if occurrences[1].Sub(occurrences[0]) != 23*time.Hour {
+		t.Errorf("expected 23 hours between occurrences but got %v", occurrences[1].Sub(occurrences[0]))
+	}
+}
+
+func TestRule_Last(t *testing.T) {
+	dtstart := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+
+	rule, _ := rrule.Parse("FREQ=WEEKLY;BYDAY=MO,FR;COUNT=5")
+	last, ok := rule.Last(dtstart)
+	if !ok {
+		t.Fatal("expected bounded rule to have a last occurrence")
+	}
+	if expected := time.Date(2024, time.June, 17, 18, 0, 0, 0, time.UTC); !last.Equal(expected) {
+		t.Errorf("expected last occurrence %v but got %v", expected, last)
+	}
+
+	unbounded, _ := rrule.Parse("FREQ=DAILY")
+	if _, ok := unbounded.Last(dtstart); ok {
+		t.Error("expected unbounded rule to have no last occurrence")
+	}
+}
+
+func TestRule_Includes(t *testing.T) {
+	dtstart := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	rule, _ := rrule.Parse("FREQ=WEEKLY;BYDAY=MO,WE")
+
+	if !rule.Includes(dtstart, time.Date(2024, time.June, 12, 18, 0, 0, 0, time.UTC)) {
+		t.Error("expected wednesday occurrence to be included")
+	}
+	if rule.Includes(dtstart, time.Date(2024, time.June, 13, 18, 0, 0, 0, time.UTC)) {
+		t.Error("expected thursday to not be included")
+	}
+	if rule.Includes(dtstart, time.Date(2024, time.June, 12, 17, 0, 0, 0, time.UTC)) {
+		t.Error("expected a different time of day to not be included")
+	}
+}
+
+func TestRule_Truncated(t *testing.T) {
+	dtstart := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	rule, _ := rrule.Parse("FREQ=DAILY;COUNT=10")
+
+	truncated := rule.Truncated(time.Date(2024, time.June, 6, 18, 0, 0, 0, time.UTC))
+	if truncated.Count != 0 {
+		t.Error("expected count to be replaced by until")
+	}
+	last, _ := truncated.Last(dtstart)
+	if expected := time.Date(2024, time.June, 5, 18, 0, 0, 0, time.UTC); !last.Equal(expected) {
+		t.Errorf("expected last occurrence %v but got %v", expected, last)
+	}
+	if rule.Count != 10 {
+		t.Error("expected original rule to be unchanged")
+	}
+}
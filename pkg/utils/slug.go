@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// MaxSlugLength the maximum number of characters in a slug generated by Slugify, excluding any suffix added by UniqueSlug.
+const MaxSlugLength = 80
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify returns a url friendly representation of the provided string 's', containing only lowercase alphanumeric characters separated by hyphens.
+func Slugify(s string) string {
+	slug := strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimRight(slug[:MaxSlugLength], "-")
+	}
+	return slug
+}
+
+// UniqueSlug returns the provided slug 'base' if it is not within 'taken', otherwise the base suffixed with the lowest available number starting from 2.
+func UniqueSlug(base string, taken []string) string {
+	if !slices.Contains(taken, base) {
+		return base
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", base, n)
+		if !slices.Contains(taken, candidate) {
+			return candidate
+		}
+	}
+}
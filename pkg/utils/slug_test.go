@@ -0,0 +1,86 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+func TestUtils_Slugify(t *testing.T) {
+	testcases := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{
+			name:     "already a slug",
+			in:       "tomorrowland-2024",
+			expected: "tomorrowland-2024",
+		},
+		{
+			name:     "mixed case with spaces",
+			in:       "Tomorrowland 2024",
+			expected: "tomorrowland-2024",
+		},
+		{
+			name:     "symbols and repeated whitespace",
+			in:       "  Go & Coffee!! -- Meetup ",
+			expected: "go-coffee-meetup",
+		},
+		{
+			name:     "truncated to max length",
+			in:       strings.Repeat("ab ", 40),
+			expected: strings.TrimSuffix(strings.Repeat("ab-", 27), "-"),
+		},
+		{
+			name:     "only symbols",
+			in:       "!!!",
+			expected: "",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if actual := utils.Slugify(testcase.in); actual != testcase.expected {
+				t.Errorf("expected '%s' but was '%s'", testcase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUtils_UniqueSlug(t *testing.T) {
+	testcases := []struct {
+		name     string
+		base     string
+		taken    []string
+		expected string
+	}{
+		{
+			name:     "base not taken",
+			base:     "meetup",
+			taken:    []string{"meetup-2"},
+			expected: "meetup",
+		},
+		{
+			name:     "base taken",
+			base:     "meetup",
+			taken:    []string{"meetup"},
+			expected: "meetup-2",
+		},
+		{
+			name:     "lowest available suffix",
+			base:     "meetup",
+			taken:    []string{"meetup", "meetup-2", "meetup-4"},
+			expected: "meetup-3",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if actual := utils.UniqueSlug(testcase.base, testcase.taken); actual != testcase.expected {
+				t.Errorf("expected '%s' but was '%s'", testcase.expected, actual)
+			}
+		})
+	}
+}
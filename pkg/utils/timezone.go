@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"errors"
+	"time"
+)
+
+// LoadTimeZone returns the location of the IANA time zone with the provided name, such as "Europe/Berlin".
+// Unlike time.LoadLocation, an empty name and "Local" are rejected as they depend on the server rather than naming a time zone.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if len(name) < 1 || name == "Local" {
+		return nil, ErrUnknownTimeZone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrUnknownTimeZone
+	}
+	return location, nil
+}
+
+// IsTimeZone returns true if the provided string 's' is the name of an IANA time zone.
+func IsTimeZone(s string) bool {
+	_, err := LoadTimeZone(s)
+	return err == nil
+}
+
+var (
+	ErrUnknownTimeZone = errors.New("unknown time zone") // ErrUnknownTimeZone is returned when a name is not found in the IANA time zone database.
+)
@@ -0,0 +1,27 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+func TestValidation_IsTimeZone(t *testing.T) {
+	testcases := []stringValidationTestCase{
+		{name: "utc", in: "UTC", expected: true},
+		{name: "iana zone", in: "Europe/Berlin", expected: true},
+		{name: "empty", in: "", expected: false},
+		{name: "local", in: "Local", expected: false},
+		{name: "unknown zone", in: "Europe/Atlantis", expected: false},
+		{name: "offset", in: "+02:00", expected: false},
+		{name: "path traversal", in: "../../etc/passwd", expected: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if utils.IsTimeZone(testcase.in) != testcase.expected {
+				t.Errorf("expected IsTimeZone(%q) to return %v", testcase.in, testcase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/BEOpenSourceCollabs/EventManagementCore/pkg/utils"
+)
+
+func TestToken_GenerateToken(t *testing.T) {
+	t.Run("tokens are unique", func(t *testing.T) {
+		a, err := utils.GenerateToken(32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := utils.GenerateToken(32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a == b {
+			t.Error("expected generated tokens to differ")
+		}
+		if len(a) != 43 {
+			t.Errorf("expected a 32 byte token to be encoded as 43 characters but was %d", len(a))
+		}
+	})
+}
+
+func TestToken_HashToken(t *testing.T) {
+	t.Run("hash is stable", func(t *testing.T) {
+		if utils.HashToken("secret") != utils.HashToken("secret") {
+			t.Error("expected the same token to produce the same hash")
+		}
+		if utils.HashToken("secret") == utils.HashToken("Secret") {
+			t.Error("expected different tokens to produce different hashes")
+		}
+	})
+}
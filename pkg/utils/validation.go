@@ -2,6 +2,7 @@ package utils
 
 import (
 	"net/mail"
+	"net/url"
 	"regexp"
 )
 
@@ -20,6 +21,12 @@ func IsEmail(email string) bool {
 	return err == nil && addr.Address == email
 }
 
+// IsWebURL returns true if the provided string 's' is an absolute http or https URL with a host.
+func IsWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0
+}
+
 // ContainsAlphabeticCharacters returns true if the provided string 's' alphabetic numbers.
 func ContainsAlphabeticCharacters(s string) bool {
 	return regexp.MustCompile(`[a-zA-Z]`).MatchString(s)
@@ -44,3 +51,13 @@ func ContainsWhitespace(s string) bool {
 func IsAlphaNumeric(s string) bool {
 	return len(regexp.MustCompile(`[a-zA-Z0-9]+`).FindString(s)) == len(s) && len(s) > 0
 }
+
+// IsCurrencyCode returns true if the provided string 's' has the form of an ISO 4217 currency code, three letters such as "EUR".
+func IsCurrencyCode(s string) bool {
+	return len(s) == 3 && len(regexp.MustCompile(`[a-zA-Z]+`).FindString(s)) == 3
+}
+
+// IsUUID returns true if the provided string 's' is a uuid in its canonical hyphenated form, such as the ids of rows.
+func IsUUID(s string) bool {
+	return regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString(s)
+}
@@ -55,3 +55,115 @@ func TestValidation_IsAlphaNumeric(t *testing.T) {
 		})
 	}
 }
+
+func TestValidation_IsCurrencyCode(t *testing.T) {
+	testcases := []stringValidationTestCase{
+		{
+			name:     "upper case code",
+			in:       "EUR",
+			expected: true,
+		},
+		{
+			name:     "lower case code",
+			in:       "usd",
+			expected: true,
+		},
+		{
+			name:     "too short",
+			in:       "EU",
+			expected: false,
+		},
+		{
+			name:     "too long",
+			in:       "EURO",
+			expected: false,
+		},
+		{
+			name:     "contains numbers",
+			in:       "E1R",
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			actual := utils.IsCurrencyCode(testcase.in)
+			if actual != testcase.expected {
+				t.Errorf("expected '%v' but was '%v'", testcase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidation_IsWebURL(t *testing.T) {
+	testcases := []stringValidationTestCase{
+		{
+			name:     "https url",
+			in:       "https://example.com/events",
+			expected: true,
+		},
+		{
+			name:     "http url",
+			in:       "http://example.com",
+			expected: true,
+		},
+		{
+			name:     "other scheme",
+			in:       "ftp://example.com",
+			expected: false,
+		},
+		{
+			name:     "missing scheme",
+			in:       "example.com",
+			expected: false,
+		},
+		{
+			name:     "missing host",
+			in:       "https://",
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			actual := utils.IsWebURL(testcase.in)
+			if actual != testcase.expected {
+				t.Errorf("expected '%v' but was '%v'", testcase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidation_IsUUID(t *testing.T) {
+	testcases := []stringValidationTestCase{
+		{
+			name:     "uuid",
+			in:       "1df76107-4585-49f0-b772-85e830b437af",
+			expected: true,
+		},
+		{
+			name:     "uppercase uuid",
+			in:       "1DF76107-4585-49F0-B772-85E830B437AF",
+			expected: true,
+		},
+		{
+			name:     "not a uuid",
+			in:       "x",
+			expected: false,
+		},
+		{
+			name:     "uuid with trailing characters",
+			in:       "1df76107-4585-49f0-b772-85e830b437af'--",
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			actual := utils.IsUUID(testcase.in)
+			if actual != testcase.expected {
+				t.Errorf("expected '%v' but was '%v'", testcase.expected, actual)
+			}
+		})
+	}
+}